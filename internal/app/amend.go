@@ -0,0 +1,122 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// pendingAmend is the commit whose message is being edited in the commit
+// bar, with its message as it was.
+type pendingAmend struct {
+	hash    string
+	message string
+}
+
+// amendLoadedMsg carries the message of the commit to amend or reword, and
+// the upstream that already has it, if any.
+type amendLoadedMsg struct {
+	mode     modals.CommitMode
+	hash     string
+	message  string
+	upstream string
+	err      error
+}
+
+// handleAmend opens the commit bar to amend HEAD with the staged changes,
+// pre-filled with its subject.
+func (m Model) handleAmend() (tea.Model, tea.Cmd) {
+	return m, m.loadAmendCmd(modals.CommitAmend, "HEAD")
+}
+
+// handleReword opens the commit bar to reword the selected commit, which
+// must be on the current branch.
+func (m Model) handleReword() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	if commit == nil || commit.Hash == git.UncommittedHash {
+		m.actionBar.SetMessage("Select a commit to reword")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	if m.state.Rebase != nil {
+		m.actionBar.SetMessage("A rebase is already in progress")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	return m, m.loadAmendCmd(modals.CommitReword, commit.Hash)
+}
+
+func (m Model) loadAmendCmd(mode modals.CommitMode, rev string) tea.Cmd {
+	return func() tea.Msg {
+		hash := rev
+		if rev == "HEAD" {
+			var err error
+			if hash, err = m.repo.HeadHash(); err != nil {
+				return amendLoadedMsg{mode: mode, err: err}
+			}
+		}
+		message, err := m.repo.CommitMessage(hash)
+		if err != nil {
+			return amendLoadedMsg{mode: mode, err: err}
+		}
+		upstream, err := m.repo.PushedTo(hash)
+		return amendLoadedMsg{mode: mode, hash: hash, message: message, upstream: upstream, err: err}
+	}
+}
+
+// handleAmendLoaded shows the commit bar with the commit's subject, and
+// warns if the commit is already on its upstream, since rewriting it will
+// need a force push.
+func (m Model) handleAmendLoaded(msg amendLoadedMsg) (tea.Model, tea.Cmd) {
+	operation := "amend"
+	if msg.mode == modals.CommitReword {
+		operation = "reword"
+	}
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: operation, err: msg.err})
+	}
+
+	m.pendingAmend = pendingAmend{hash: msg.hash, message: msg.message}
+	subject, _, _ := strings.Cut(msg.message, "\n")
+	m.commitModal.ShowMode(msg.mode, subject)
+	if msg.upstream != "" {
+		m.actionBar.SetMessage("Warning: " + shortHash(msg.hash) + " is already on " + msg.upstream +
+			", rewriting it will need a force push")
+	}
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// submitAmend amends or rewords with the subject typed in the commit bar,
+// keeping the rest of the message. An amend with the subject unchanged
+// keeps the message as it is.
+func (m Model) submitAmend(mode modals.CommitMode, subject string) (tea.Model, tea.Cmd) {
+	p := m.pendingAmend
+	m.pendingAmend = pendingAmend{}
+	old, _, _ := strings.Cut(p.message, "\n")
+	changed := subject != old
+
+	if mode == modals.CommitReword {
+		if !changed {
+			m.actionBar.ClearMessage()
+			return m, nil
+		}
+		m.actionBar.SetMessage("Rewording " + shortHash(p.hash) + "...")
+		message := git.ReplaceSubject(p.message, subject)
+		return m, func() tea.Msg {
+			err := m.repo.Reword(p.hash, message)
+			return operationResultMsg{operation: "reword", err: err}
+		}
+	}
+
+	message := ""
+	if changed {
+		message = git.ReplaceSubject(p.message, subject)
+	}
+	m.actionBar.SetMessage("Amending...")
+	return m, func() tea.Msg {
+		err := m.repo.Amend(message)
+		return operationResultMsg{operation: "amend", err: err}
+	}
+}
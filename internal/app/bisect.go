@@ -0,0 +1,226 @@
+package app
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// bisectResultMsg is sent when a bisect step returns. status is the bisect
+// after it, nil once it has ended.
+type bisectResultMsg struct {
+	operation string
+	status    *git.BisectStatus
+	err       error
+}
+
+// bisectOutputMsg carries one line of output from a running `git bisect
+// run`; run delivers the next one.
+type bisectOutputMsg struct {
+	line string
+	run  *bisectRun
+}
+
+// bisectRun streams the output of `git bisect run` from the goroutine
+// running it, then its result.
+type bisectRun struct {
+	lines chan string
+	done  chan bisectResultMsg
+}
+
+// next waits for the next line of output, or the result once the command
+// has finished.
+func (r *bisectRun) next() tea.Msg {
+	if line, ok := <-r.lines; ok {
+		return bisectOutputMsg{line: line, run: r}
+	}
+	return <-r.done
+}
+
+// bisectActions are the bisect menu choices, in menu order.
+var bisectActions = []string{"bad", "good", "skip", "run"}
+
+// handleBisect offers to mark the selected commit, starting a bisect if
+// none is in progress, or to let a command find the first bad commit.
+func (m Model) handleBisect() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	if commit == nil || commit.Hash == git.UncommittedHash {
+		m.actionBar.SetMessage("Select a commit to mark")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	title := "Bisect: mark " + commit.ShortHash
+	if m.state.Bisect == nil {
+		if cmd, ok := m.checkNothingInProgress(); !ok {
+			return m, cmd
+		}
+		title = "Start bisect: mark " + commit.ShortHash
+	}
+	m.menuModal.Show(title, []modals.MenuItem{
+		{Key: "b", Label: "bad", Description: "this commit has the change looked for"},
+		{Key: "g", Label: "good", Description: "this commit predates it"},
+		{Key: "s", Label: "skip", Description: "this commit can't be tested"},
+		{Key: "r", Label: "run command", Description: "test commits with a command until the first bad one"},
+	})
+	m.menuPurpose = menuBisect
+	m.menuTarget = commit.Hash
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleBisectAction(hash, action string) (tea.Model, tea.Cmd) {
+	var mark func(string) error
+	switch action {
+	case "bad":
+		mark = m.repo.BisectBad
+	case "good":
+		mark = m.repo.BisectGood
+	case "skip":
+		mark = m.repo.BisectSkip
+	case "run":
+		if b := m.state.Bisect; b == nil || b.Bad == "" || len(b.Good) == 0 {
+			m.actionBar.SetMessage("Mark a bad and a good commit first")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		m.inputModal.Show("Command:", "")
+		m.inputModal.SetPlaceholder("exit 0 good, 125 skip, 1-127 bad")
+		m.inputPurpose = inputBisectRun
+		m.recalcGraphSize()
+		return m, nil
+	}
+
+	start := m.state.Bisect == nil
+	m.actionBar.SetMessage("Marking " + shortHash(hash) + " " + action + "...")
+	return m, m.bisectCmd("bisect "+action, func() error {
+		if start {
+			if err := m.repo.BisectStart(); err != nil {
+				return err
+			}
+		}
+		return mark(hash)
+	})
+}
+
+// handleBisectStep skips the commit under test, or resets to end the
+// bisect. There is nothing to continue: commits are marked good or bad.
+func (m Model) handleBisectStep(step string) (tea.Model, tea.Cmd) {
+	switch step {
+	case "skip":
+		m.actionBar.SetMessage("Skipping commit...")
+		return m, m.bisectCmd("bisect skip", func() error { return m.repo.BisectSkip("") })
+	case "abort":
+		m.actionBar.SetMessage("Ending bisect...")
+		return m, m.bisectCmd("bisect reset", m.repo.BisectReset)
+	}
+	m.actionBar.SetMessage("A bisect has no continue — B to mark the commit, or skip or reset it")
+	return m, m.clearMessageAfter(3 * time.Second)
+}
+
+func (m Model) bisectCmd(operation string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		err := run()
+		status, _ := m.repo.BisectStatus() // best-effort
+		return bisectResultMsg{operation: operation, status: status, err: err}
+	}
+}
+
+// bisectRunCmd runs `git bisect run` with command, streaming its output
+// into the output panel.
+func (m Model) bisectRunCmd(command string) tea.Cmd {
+	run := &bisectRun{lines: make(chan string), done: make(chan bisectResultMsg, 1)}
+	return func() tea.Msg {
+		go func() {
+			w := &lineWriter{lines: run.lines}
+			err := m.repo.BisectRun(command, w)
+			w.flush()
+			close(run.lines)
+			status, _ := m.repo.BisectStatus() // best-effort
+			run.done <- bisectResultMsg{operation: "bisect run", status: status, err: err}
+		}()
+		return run.next()
+	}
+}
+
+func (m Model) runBisect(command string) (tea.Model, tea.Cmd) {
+	m.outputModal.Show("git bisect run "+command, "")
+	m.recalcGraphSize()
+	m.actionBar.SetMessage("Bisecting with " + command + "...")
+	return m, m.bisectRunCmd(command)
+}
+
+func (m Model) handleBisectOutput(msg bisectOutputMsg) (tea.Model, tea.Cmd) {
+	m.outputModal.Append(msg.line)
+	m.recalcGraphSize()
+	return m, msg.run.next
+}
+
+func (m Model) handleBisectResult(msg bisectResultMsg) (tea.Model, tea.Cmd) {
+	m.state.Bisect = msg.status
+	m.graphPanel.SetBisect(msg.status)
+	m.updateStatus()
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+	}
+
+	s := msg.status
+	switch {
+	case s == nil:
+		m.actionBar.SetMessage("Bisect ended")
+	case s.FirstBad != "":
+		expand := m.showFirstBad(s.FirstBad)
+		return m, tea.Batch(expand, m.clearMessageAfter(5*time.Second), m.loadCommitsCmd())
+	case s.Bad == "":
+		m.actionBar.SetMessage("Marked — now mark a bad commit")
+	case len(s.Good) == 0:
+		m.actionBar.SetMessage("Marked — now mark a good commit")
+	case s.Steps >= 0:
+		m.actionBar.SetMessage("Testing " + shortHash(s.Current) + ", about " + pluralize(s.Steps, "step") + " left")
+	default:
+		m.actionBar.SetMessage("Testing " + shortHash(s.Current))
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd())
+}
+
+// showFirstBad selects and expands the first bad commit a bisect found.
+func (m *Model) showFirstBad(hash string) tea.Cmd {
+	commit := m.graphPanel.FindCommit(hash)
+	if commit == nil || !m.graphPanel.SelectCommit(hash) {
+		m.actionBar.SetMessage("First bad commit: " + shortHash(hash))
+		return nil
+	}
+	m.actionBar.SetMessage("First bad commit: " + commit.ShortHash + " " + commit.Subject)
+	if m.graphPanel.IsExpanded() && m.graphPanel.ExpandedIdx() == m.graphPanel.Index() {
+		return nil
+	}
+	return m.graphPanel.ToggleExpand(m.repo)
+}
+
+// lineWriter sends each complete line written to it on lines.
+type lineWriter struct {
+	lines chan<- string
+	buf   []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.lines <- strings.TrimRight(string(w.buf[:i]), "\r")
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// flush sends a last line left without a trailing newline.
+func (w *lineWriter) flush() {
+	if len(w.buf) > 0 {
+		w.lines <- string(w.buf)
+		w.buf = nil
+	}
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/keys"
+)
+
+// blameLoadedMsg is sent when the blame of path at rev has been read.
+type blameLoadedMsg struct {
+	rev   string
+	path  string
+	lines []git.BlameLine
+	err   error
+}
+
+// handleBlame opens a blame of the file selected in the expanded commit,
+// as it was after that commit. A file the commit deleted is blamed as it
+// was before.
+func (m Model) handleBlame() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	file := m.graphPanel.SelectedFile()
+	if commit == nil || file == nil {
+		m.actionBar.SetMessage("Select a file in an expanded commit to blame it")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	rev := commit.Hash
+	switch {
+	case rev == git.UncommittedHash:
+		rev = ""
+	case file.Status == "D":
+		if len(commit.Parents) == 0 {
+			return m, nil
+		}
+		rev = commit.Parents[0]
+	}
+	m.actionBar.SetMessage("Blaming " + file.Path + "...")
+	return m, m.blameCmd(rev, file.Path)
+}
+
+func (m Model) blameCmd(rev, path string) tea.Cmd {
+	return func() tea.Msg {
+		lines, err := m.repo.Blame(rev, path)
+		return blameLoadedMsg{rev: rev, path: path, lines: lines, err: err}
+	}
+}
+
+func (m Model) handleBlameLoaded(msg blameLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "blame", err: msg.err})
+	}
+	m.blamePanel.Push(msg.rev, msg.path, msg.lines)
+	m.actionBar.SetMessage("Enter go to commit · p blame before it · Esc back")
+	return m, m.clearMessageAfter(5 * time.Second)
+}
+
+// handleBlameKey handles keys while the blame view is open: Enter jumps to
+// the commit of the selected line, p blames the file as it was before that
+// commit, and Esc goes back to the previous blame or the graph.
+func (m Model) handleBlameKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case keys.MatchesKey(msg, m.keyMap.Quit):
+		return m, tea.Quit
+	case msg.String() == "esc" || keys.MatchesKey(msg, m.keyMap.Blame):
+		m.blamePanel.Back()
+		return m, nil
+	case keys.MatchesKey(msg, m.keyMap.Enter):
+		line := m.blamePanel.SelectedLine()
+		if line == nil {
+			return m, nil
+		}
+		m.blamePanel.Close()
+		if !m.graphPanel.SelectCommit(line.Hash) {
+			m.actionBar.SetMessage(shortHash(line.Hash) + " is not loaded in the graph")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		return m, nil
+	case msg.String() == "p":
+		line := m.blamePanel.SelectedLine()
+		if line == nil {
+			return m, nil
+		}
+		if line.Previous == "" {
+			m.actionBar.SetMessage("Nothing before " + shortHash(line.Hash) + ": it added the file")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		m.actionBar.SetMessage("Blaming " + line.PreviousPath + " at " + shortHash(line.Previous) + "...")
+		return m, m.blameCmd(line.Previous, line.PreviousPath)
+	}
+
+	var cmd tea.Cmd
+	m.blamePanel, cmd = m.blamePanel.Update(msg)
+	return m, cmd
+}
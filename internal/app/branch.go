@@ -0,0 +1,203 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// branchResultMsg is sent when a branch create, rename, delete or upstream
+// change returns.
+type branchResultMsg struct {
+	operation string // "create branch", "rename branch", "delete branch", ...
+	branch    string
+	err       error
+
+	// upstream is set when a delete of both the branch and its upstream
+	// stopped at the local branch, for the forced delete to finish.
+	upstream *git.Branch
+}
+
+// deleteScopes are the branch and tag delete menu choices, in menu
+// order: local, remote, or both.
+var deleteScopes = []struct{ local, remote bool }{
+	{local: true},
+	{remote: true},
+	{local: true, remote: true},
+}
+
+// handleNewBranch prompts for the name of a branch starting at the selected
+// commit, or at HEAD for the uncommitted changes entry.
+func (m Model) handleNewBranch() (tea.Model, tea.Cmd) {
+	start, label := "HEAD", "HEAD"
+	if commit := m.graphPanel.SelectedCommit(); commit != nil && commit.Hash != git.UncommittedHash {
+		start, label = commit.Hash, commit.ShortHash
+	}
+	m.showNewBranchInput(start, label)
+	return m, nil
+}
+
+func (m *Model) showNewBranchInput(start, label string) {
+	m.inputModal.Show("New branch from "+label+":", "")
+	m.inputPurpose = inputNewBranch
+	m.inputTarget = start
+	m.recalcGraphSize()
+}
+
+// handleBranchAction handles the branch panel keys other than checkout.
+func (m Model) handleBranchAction(key string) (tea.Model, tea.Cmd) {
+	if key == "n" {
+		m.showNewBranchInput("HEAD", "HEAD")
+		return m, nil
+	}
+
+	branch := m.branchModal.SelectedBranch()
+	if branch == nil {
+		return m, nil
+	}
+	name := branch.Name
+
+	switch key {
+	case "m":
+		if branch.IsCurrent {
+			m.actionBar.SetMessage("Can't merge " + name + " into itself")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		m.branchModal.Hide()
+		m.recalcGraphSize()
+		return m.showMergeMenu(name)
+	case "r":
+		m.inputModal.Show("Rename "+name+" to:", name)
+		m.inputPurpose = inputRenameBranch
+		m.inputTarget = name
+		m.recalcGraphSize()
+	case "d":
+		if !branch.HasRemoteUpstream() {
+			return m.deleteBranch(branch, true, false)
+		}
+		m.menuModal.Show("Delete "+name, []modals.MenuItem{
+			{Key: "l", Label: "local branch", Description: name},
+			{Key: "r", Label: "remote branch", Description: branch.Upstream},
+			{Key: "b", Label: "both", Description: name + " and " + branch.Upstream},
+		})
+		m.menuPurpose = menuDeleteBranch
+		m.menuTarget = name
+		m.recalcGraphSize()
+	case "u":
+		upstream := branch.Upstream
+		if upstream == "" {
+			upstream = "origin/" + name
+		}
+		m.inputModal.Show("Upstream of "+name+":", upstream)
+		m.inputPurpose = inputSetUpstream
+		m.inputTarget = name
+		m.recalcGraphSize()
+	case "U":
+		if branch.Upstream == "" {
+			m.actionBar.SetMessage(name + " has no upstream")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		return m, m.branchCmd("unset upstream", name, func() error {
+			return m.repo.UnsetUpstream(name)
+		})
+	}
+	return m, nil
+}
+
+// deleteBranch deletes the branch locally and/or its upstream on the
+// remote. The local delete refuses unmerged branches; handleBranchResult
+// then asks to force it. The upstream goes only once the local branch is
+// gone, since git judges it merged against the upstream too.
+func (m Model) deleteBranch(branch *git.Branch, local, remote bool) (tea.Model, tea.Cmd) {
+	if local && branch.IsCurrent {
+		m.actionBar.SetMessage("Can't delete the checked-out branch " + branch.Name)
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	b := *branch
+	m.actionBar.SetMessage("Deleting " + b.Name + "...")
+	return m, func() tea.Msg {
+		msg := branchResultMsg{operation: "delete branch", branch: b.Name}
+		if local {
+			if msg.err = m.repo.DeleteBranch(b.Name, false); msg.err != nil {
+				if remote {
+					msg.upstream = &b
+				}
+				return msg
+			}
+		}
+		if remote {
+			msg.err = m.repo.DeleteRemoteBranch(&b)
+		}
+		return msg
+	}
+}
+
+// forceDeleteBranch deletes the branch despite unmerged commits, then its
+// upstream if that was asked for too.
+func (m Model) forceDeleteBranch(name string) (tea.Model, tea.Cmd) {
+	upstream := m.pendingDeleteUpstream
+	m.pendingDeleteUpstream = nil
+	m.actionBar.SetMessage("Deleting " + name + "...")
+	return m, m.branchCmd("delete branch", name, func() error {
+		if err := m.repo.DeleteBranch(name, true); err != nil {
+			return err
+		}
+		if upstream != nil {
+			return m.repo.DeleteRemoteBranch(upstream)
+		}
+		return nil
+	})
+}
+
+func (m Model) branchCmd(operation, branch string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return branchResultMsg{operation: operation, branch: branch, err: run()}
+	}
+}
+
+// handleBranchResult reports a branch change and refreshes the graph refs
+// and, if it is open, the branch panel.
+func (m Model) handleBranchResult(msg branchResultMsg) (tea.Model, tea.Cmd) {
+	if msg.operation == "delete branch" && git.IsErrorKind(msg.err, git.ErrorKindBranchNotMerged) {
+		m.actionBar.ClearMessage()
+		lines := []string{
+			msg.branch + " has commits that aren't merged into HEAD or its upstream.",
+			"They will only be reachable through the reflog.",
+		}
+		if msg.upstream != nil {
+			lines = append(lines, "Its upstream "+msg.upstream.Upstream+" is deleted afterwards.")
+		}
+		m.confirmModal.Show(fmt.Sprintf("Force delete %s?", msg.branch), lines)
+		m.confirmPurpose = confirmForceDeleteBranch
+		m.confirmTarget = msg.branch
+		m.pendingDeleteUpstream = msg.upstream
+		m.recalcGraphSize()
+		return m, nil
+	}
+
+	var reload tea.Cmd
+	if m.branchModal.IsVisible() {
+		reload = m.showBranchPickerCmd()
+	}
+	if msg.err != nil {
+		model, cmd := m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		return model, tea.Batch(cmd, reload)
+	}
+
+	switch msg.operation {
+	case "create branch":
+		m.actionBar.SetMessage("Created and checked out " + msg.branch)
+	case "rename branch":
+		m.actionBar.SetMessage("Renamed to " + msg.branch)
+	case "delete branch":
+		m.actionBar.SetMessage("Deleted " + msg.branch)
+	case "set upstream":
+		m.actionBar.SetMessage("Upstream of " + msg.branch + " set")
+	case "unset upstream":
+		m.actionBar.SetMessage("Upstream of " + msg.branch + " removed")
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd(), reload)
+}
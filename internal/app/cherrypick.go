@@ -0,0 +1,210 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// sequencerResultMsg is sent when a cherry-pick or revert command returns.
+// state is the sequence left stopped afterwards, if any.
+type sequencerResultMsg struct {
+	operation string
+	count     int
+	err       error
+	state     git.Sequencer
+}
+
+func (m Model) handleMark() (tea.Model, tea.Cmd) {
+	m.graphPanel.ToggleMark()
+	if n := m.graphPanel.MarkCount(); n > 0 {
+		m.actionBar.SetMessage(fmt.Sprintf("%d marked — C cherry-pick, R revert", n))
+	} else {
+		m.actionBar.SetMessage("No commits marked")
+	}
+	return m, m.clearMessageAfter(3 * time.Second)
+}
+
+// checkNothingInProgress reports, in the action bar, a rebase or sequence
+// that has to finish before another one can start.
+func (m *Model) checkNothingInProgress() (tea.Cmd, bool) {
+	switch {
+	case m.state.Rebase != nil:
+		m.actionBar.SetMessage("A rebase is in progress — continue or abort it first")
+	case m.state.Sequencer != git.SequencerNone:
+		m.actionBar.SetMessage(fmt.Sprintf("A %s is in progress — continue or abort it first", m.state.Sequencer))
+	default:
+		return nil, true
+	}
+	return m.clearMessageAfter(3 * time.Second), false
+}
+
+// handleCherryPick applies the marked commits, or the selected one, on
+// top of HEAD, oldest first.
+func (m Model) handleCherryPick() (tea.Model, tea.Cmd) {
+	if cmd, ok := m.checkNothingInProgress(); !ok {
+		return m, cmd
+	}
+	commits := m.graphPanel.MarkedCommits()
+	if len(commits) == 0 {
+		m.actionBar.SetMessage("Select a commit to cherry-pick")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	hashes := make([]string, 0, len(commits))
+	for i := len(commits) - 1; i >= 0; i-- {
+		if len(commits[i].Parents) > 1 {
+			m.actionBar.SetMessage("Merge commits can't be cherry-picked: " + commits[i].ShortHash)
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		hashes = append(hashes, commits[i].Hash)
+	}
+
+	m.actionBar.SetMessage(fmt.Sprintf("Cherry-picking %s...", pluralCommits(len(hashes))))
+	return m, m.sequencerCmd("cherry-pick", len(hashes), func() error {
+		return m.repo.CherryPick(hashes...)
+	})
+}
+
+// handleRevert reverts the marked commits, or the selected one, newest
+// first. A single merge commit opens a menu to choose the mainline parent.
+func (m Model) handleRevert() (tea.Model, tea.Cmd) {
+	if cmd, ok := m.checkNothingInProgress(); !ok {
+		return m, cmd
+	}
+	commits := m.graphPanel.MarkedCommits()
+	if len(commits) == 0 {
+		m.actionBar.SetMessage("Select a commit to revert")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	if len(commits) == 1 && len(commits[0].Parents) > 1 {
+		m.showRevertParentMenu(commits[0])
+		return m, nil
+	}
+
+	hashes := make([]string, 0, len(commits))
+	for _, c := range commits {
+		if len(c.Parents) > 1 {
+			m.actionBar.SetMessage(git.ErrMergeNeedsMainline.Error() + ": " + c.ShortHash)
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		hashes = append(hashes, c.Hash)
+	}
+
+	m.actionBar.SetMessage(fmt.Sprintf("Reverting %s...", pluralCommits(len(hashes))))
+	return m, m.sequencerCmd("revert", len(hashes), func() error {
+		return m.repo.Revert(hashes...)
+	})
+}
+
+// showRevertParentMenu lists the parents of a merge commit; reverting
+// keeps the chosen parent's side and undoes the others.
+func (m *Model) showRevertParentMenu(commit *git.Commit) {
+	items := make([]modals.MenuItem, len(commit.Parents))
+	for i, parent := range commit.Parents {
+		item := modals.MenuItem{Key: strconv.Itoa(i + 1), Label: shortHash(parent)}
+		if c := m.graphPanel.FindCommit(parent); c != nil {
+			item.Description = c.Subject
+		}
+		items[i] = item
+	}
+	m.menuModal.Show("Revert "+commit.ShortHash+" keeping parent", items)
+	m.menuPurpose = menuRevertParent
+	m.menuTarget = commit.Hash
+	m.recalcGraphSize()
+}
+
+// sequencerCmd runs a cherry-pick or revert command and reports whether it
+// left a sequence stopped on conflicts.
+func (m Model) sequencerCmd(operation string, count int, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		err := run()
+		state, _ := m.repo.SequencerInProgress() // best-effort
+		return sequencerResultMsg{operation: operation, count: count, err: err, state: state}
+	}
+}
+
+// handleStep runs continue, skip or abort on whatever is in progress: a
+// rebase, or else a cherry-pick, revert or merge, or else a bisect.
+func (m Model) handleStep(step string) (tea.Model, tea.Cmd) {
+	if m.state.Operation() == "bisect" {
+		return m.handleBisectStep(step)
+	}
+	if m.state.Rebase != nil || m.state.Sequencer == git.SequencerNone {
+		return m.handleRebaseStep(step)
+	}
+
+	s := m.state.Sequencer
+	var run func() error
+	switch step {
+	case "continue":
+		m.actionBar.SetMessage(fmt.Sprintf("Continuing %s...", s))
+		run = func() error { return m.repo.SequencerContinue(s) }
+	case "skip":
+		if s == git.SequencerMerge {
+			m.actionBar.SetMessage("A merge can't be skipped — alt+a to abort it")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		m.actionBar.SetMessage("Skipping commit...")
+		run = func() error { return m.repo.SequencerSkip(s) }
+	case "abort":
+		m.actionBar.SetMessage(fmt.Sprintf("Aborting %s...", s))
+		run = func() error { return m.repo.SequencerAbort(s) }
+	}
+	return m, m.sequencerCmd(string(s)+" "+step, 0, run)
+}
+
+func (m Model) handleSequencerResult(msg sequencerResultMsg) (tea.Model, tea.Cmd) {
+	m.state.Sequencer = msg.state
+	m.updateStatus()
+
+	if msg.state == git.SequencerNone {
+		if msg.err != nil {
+			return m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		}
+		m.graphPanel.ClearMarks()
+		switch msg.operation {
+		case "cherry-pick":
+			m.actionBar.SetMessage("Cherry-picked " + pluralCommits(msg.count))
+		case "revert":
+			m.actionBar.SetMessage("Reverted " + pluralCommits(msg.count))
+		default:
+			m.actionBar.SetMessage(msg.operation + " completed")
+		}
+		return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd())
+	}
+
+	// Still in progress: stopped on conflicts, or on a commit that became
+	// empty and has to be skipped.
+	var gitErr *git.GitError
+	if errors.As(msg.err, &gitErr) {
+		m.lastGitErr = gitErr
+	}
+	switch {
+	case git.IsErrorKind(msg.err, git.ErrorKindMergeConflict):
+		m.actionBar.SetMessage(fmt.Sprintf("Conflicts during %s — resolve and stage them, then alt+c to continue", msg.state))
+	case msg.err != nil:
+		text := fmt.Sprintf("%s stopped: %s", msg.operation, errorSummary(msg.err))
+		if gitErr != nil {
+			text += " (o: details)"
+		}
+		m.actionBar.SetMessage(text)
+	default:
+		m.actionBar.SetMessage(fmt.Sprintf("%s paused — alt+c to continue, alt+a to abort", msg.state))
+	}
+	return m, tea.Batch(m.clearMessageAfter(5*time.Second), m.loadCommitsCmd(), m.conflictsCmd(msg.err))
+}
+
+// pluralCommits formats a commit count for messages.
+func pluralCommits(n int) string {
+	if n == 1 {
+		return "1 commit"
+	}
+	return fmt.Sprintf("%d commits", n)
+}
@@ -0,0 +1,192 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/keys"
+)
+
+// conflictsLoadedMsg carries the unmerged paths for the conflict panel.
+type conflictsLoadedMsg struct {
+	files []git.ChangedFile
+	err   error
+}
+
+// conflictContentMsg carries the parsed conflict hunks of one file.
+type conflictContentMsg struct {
+	path    string
+	content *git.ConflictedContent
+	err     error
+}
+
+// conflictResultMsg is sent when resolving a hunk or file returns.
+// resolved is set once the file is staged as resolved.
+type conflictResultMsg struct {
+	path     string
+	resolved bool
+	err      error
+}
+
+// conflictChoices maps the panel's o / t / b keys to a resolution.
+var conflictChoices = map[string]git.ConflictChoice{
+	"o": git.ChooseOurs,
+	"t": git.ChooseTheirs,
+	"b": git.ChooseBoth,
+}
+
+// conflictFileChoices are the hunk view's keys for resolving the whole
+// file.
+var conflictFileChoices = map[string]git.ConflictChoice{
+	"O": git.ChooseOurs,
+	"T": git.ChooseTheirs,
+	"B": git.ChooseBoth,
+}
+
+func (m Model) loadConflictsCmd() tea.Cmd {
+	return func() tea.Msg {
+		files, err := m.repo.ConflictedFiles()
+		return conflictsLoadedMsg{files: files, err: err}
+	}
+}
+
+// handleConflictsLoaded opens or refreshes the conflict panel. An empty
+// list only opens it when asked for, to report there is nothing to do.
+func (m Model) handleConflictsLoaded(msg conflictsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "conflict list", err: msg.err})
+	}
+	if len(msg.files) == 0 && !m.conflictModal.IsVisible() {
+		m.actionBar.SetMessage("No conflicts")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	m.conflictModal.Show(msg.files)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) loadConflictContentCmd(path string) tea.Cmd {
+	return func() tea.Msg {
+		content, err := m.repo.ReadConflicts(path)
+		return conflictContentMsg{path: path, content: content, err: err}
+	}
+}
+
+func (m Model) handleConflictContent(msg conflictContentMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "read conflicts", err: msg.err})
+	}
+	if !m.conflictModal.IsVisible() {
+		return m, nil
+	}
+	m.conflictModal.OpenFile(msg.path, msg.content)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleConflictModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch {
+	case key == "esc" && m.conflictModal.InFile():
+		m.conflictModal.CloseFile()
+		m.recalcGraphSize()
+		return m, nil
+	case key == "esc" || keys.MatchesKey(msg, m.keyMap.Conflicts):
+		m.conflictModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case key == "j" || key == "down":
+		m.conflictModal.MoveDown()
+		m.recalcGraphSize()
+		return m, nil
+	case key == "k" || key == "up":
+		m.conflictModal.MoveUp()
+		m.recalcGraphSize()
+		return m, nil
+	case keys.MatchesKey(msg, m.keyMap.Continue):
+		m.conflictModal.Hide()
+		m.recalcGraphSize()
+		return m.handleStep("continue")
+	case keys.MatchesKey(msg, m.keyMap.Abort):
+		m.conflictModal.Hide()
+		m.recalcGraphSize()
+		return m.handleStep("abort")
+	}
+
+	if path := m.conflictModal.OpenPath(); path != "" {
+		if choice, ok := conflictChoices[key]; ok {
+			hunk := m.conflictModal.SelectedHunk()
+			return m, m.conflictCmd(path, func() (bool, error) {
+				remaining, err := m.repo.ResolveHunk(path, hunk, choice)
+				if err != nil || remaining > 0 {
+					return false, err
+				}
+				return true, m.repo.MarkResolved(path)
+			})
+		}
+		if choice, ok := conflictFileChoices[key]; ok {
+			return m.resolveConflictFile(path, choice)
+		}
+		return m, nil
+	}
+
+	file := m.conflictModal.SelectedFile()
+	if file == nil {
+		return m, nil
+	}
+	path := file.Path
+	switch key {
+	case "enter":
+		return m, m.loadConflictContentCmd(path)
+	case "a":
+		return m, m.conflictCmd(path, func() (bool, error) {
+			return true, m.repo.MarkResolved(path)
+		})
+	}
+	if choice, ok := conflictChoices[key]; ok {
+		return m.resolveConflictFile(path, choice)
+	}
+	return m, nil
+}
+
+func (m Model) resolveConflictFile(path string, choice git.ConflictChoice) (tea.Model, tea.Cmd) {
+	return m, m.conflictCmd(path, func() (bool, error) {
+		return true, m.repo.ResolveFile(path, choice)
+	})
+}
+
+func (m Model) conflictCmd(path string, run func() (bool, error)) tea.Cmd {
+	return func() tea.Msg {
+		resolved, err := run()
+		return conflictResultMsg{path: path, resolved: resolved, err: err}
+	}
+}
+
+// handleConflictResult refreshes the conflict panel, going back to the
+// file list once the open file is resolved.
+func (m Model) handleConflictResult(msg conflictResultMsg) (tea.Model, tea.Cmd) {
+	reload := []tea.Cmd{m.loadConflictsCmd()}
+	if msg.err != nil {
+		model, cmd := m.handleOperationResult(operationResultMsg{operation: "resolve", err: msg.err})
+		return model, tea.Batch(append(reload, cmd)...)
+	}
+	if msg.resolved {
+		m.conflictModal.CloseFile()
+		m.recalcGraphSize()
+		m.actionBar.SetMessage("Resolved " + msg.path)
+		reload = append(reload, m.clearMessageAfter(3*time.Second), m.loadCommitsCmd())
+	} else if m.conflictModal.OpenPath() == msg.path {
+		reload = append(reload, m.loadConflictContentCmd(msg.path))
+	}
+	return m, tea.Batch(reload...)
+}
+
+// conflictsCmd opens the conflict panel after an operation stopped on
+// conflicts.
+func (m Model) conflictsCmd(err error) tea.Cmd {
+	if !git.IsErrorKind(err, git.ErrorKindMergeConflict) {
+		return nil
+	}
+	return m.loadConflictsCmd()
+}
@@ -0,0 +1,131 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// fixupKinds are the fixup menu choices, in menu order.
+var fixupKinds = []git.FixupKind{git.FixupPlain, git.FixupAmend, git.FixupSquash}
+
+// autosquashPlanMsg carries the rebase that would fold the pending fixups
+// into their targets, for the preview.
+type autosquashPlanMsg struct {
+	plan *git.AutosquashPlan
+	err  error
+}
+
+// handleFixup offers to commit the staged changes as a fixup!, amend! or
+// squash! of the selected commit.
+func (m Model) handleFixup() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	if commit == nil || commit.Hash == git.UncommittedHash {
+		m.actionBar.SetMessage("Select a commit to fix up")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	m.menuModal.Show("Fix up "+commit.ShortHash+" with the staged changes", []modals.MenuItem{
+		{Key: "f", Label: "fixup!", Description: "fold in, keep its message"},
+		{Key: "a", Label: "amend!", Description: "fold in and replace its message"},
+		{Key: "s", Label: "squash!", Description: "fold in and combine the messages"},
+	})
+	m.menuPurpose = menuFixup
+	m.menuTarget = commit.Hash
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleFixupKind creates the fixup, first asking for the new subject of
+// an amend!.
+func (m Model) handleFixupKind(hash string, kind git.FixupKind) (tea.Model, tea.Cmd) {
+	if kind == git.FixupAmend {
+		subject := ""
+		if c := m.graphPanel.FindCommit(hash); c != nil {
+			subject = c.Subject
+		}
+		m.inputModal.Show("New subject:", subject)
+		m.inputPurpose = inputFixupSubject
+		m.inputTarget = hash
+		m.recalcGraphSize()
+		return m, nil
+	}
+	return m.commitFixup(hash, kind, "")
+}
+
+// commitAmendFixup creates an amend! commit that gives hash the subject
+// typed, keeping the rest of its message.
+func (m Model) commitAmendFixup(hash, subject string) (tea.Model, tea.Cmd) {
+	message := subject
+	if c := m.graphPanel.FindCommit(hash); c != nil {
+		message = git.ReplaceSubject(c.Message, subject)
+	}
+	return m.commitFixup(hash, git.FixupAmend, message)
+}
+
+func (m Model) commitFixup(hash string, kind git.FixupKind, message string) (tea.Model, tea.Cmd) {
+	m.actionBar.SetMessage(fmt.Sprintf("Committing %s! for %s...", kind, shortHash(hash)))
+	return m, func() tea.Msg {
+		err := m.repo.CommitFixup(hash, kind, message)
+		return operationResultMsg{operation: string(kind) + "! commit", err: err}
+	}
+}
+
+// handleAutosquash previews folding every pending fixup on the branch into
+// its target.
+func (m Model) handleAutosquash() (tea.Model, tea.Cmd) {
+	if cmd, ok := m.checkNothingInProgress(); !ok {
+		return m, cmd
+	}
+	return m, func() tea.Msg {
+		plan, err := m.repo.PlanAutosquash()
+		return autosquashPlanMsg{plan: plan, err: err}
+	}
+}
+
+// handleAutosquashPlan shows the todo list git made for the autosquash,
+// oldest first, with the fixups folded into each commit, and asks to go
+// ahead.
+func (m Model) handleAutosquashPlan(msg autosquashPlanMsg) (tea.Model, tea.Cmd) {
+	if msg.err == git.ErrNoFixups {
+		m.actionBar.SetMessage("No fixup commits to squash")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "autosquash", err: msg.err})
+	}
+
+	fixups := 0
+	lines := make([]string, 0, len(msg.plan.Todo))
+	for _, item := range msg.plan.Todo {
+		if item.Action == git.RebasePick {
+			lines = append(lines, item.Commit.ShortHash+" "+item.Commit.Subject)
+			continue
+		}
+		fixups++
+		lines = append(lines, fmt.Sprintf("  └ %-8s %s %s", item.Action, item.Commit.ShortHash, item.Commit.Subject))
+	}
+	m.confirmModal.Show(fmt.Sprintf("Squash %s into %s..HEAD? Result, oldest first:",
+		pluralize(fixups, "fixup"), shortHash(msg.plan.Base)), lines)
+	m.confirmPurpose = confirmAutosquash
+	m.confirmTarget = msg.plan.Base
+	m.pendingAutosquash = msg.plan
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// autosquash runs the previewed plan.
+func (m Model) autosquash() (tea.Model, tea.Cmd) {
+	plan := m.pendingAutosquash
+	m.pendingAutosquash = nil
+	if plan == nil {
+		return m, nil
+	}
+	m.actionBar.SetMessage("Squashing fixups...")
+	return m, m.rebaseStepCmd("autosquash", func() error {
+		return m.repo.Autosquash(plan)
+	})
+}
@@ -0,0 +1,61 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// handleFileHistory filters the graph to the history of the file selected
+// in the expanded commit, or asks for a path if none is selected. In a
+// file's history with no file selected it goes back to all commits.
+func (m Model) handleFileHistory() (tea.Model, tea.Cmd) {
+	if file := m.graphPanel.SelectedFile(); file != nil {
+		return m.showFileHistory(file.Path)
+	}
+	if m.historyPath != "" {
+		return m.exitFileHistory()
+	}
+	m.inputModal.Show("History of:", "")
+	m.inputModal.SetPlaceholder("path relative to the repository root")
+	m.inputPurpose = inputFileHistory
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) showFileHistory(path string) (tea.Model, tea.Cmd) {
+	m.historyPath = path
+	m.graphPanel.Collapse()
+	m.actionBar.SetMessage("Enter shows each commit's change to the file · Esc back to all commits")
+	return m, tea.Batch(m.clearMessageAfter(5*time.Second), m.loadCommitsCmd())
+}
+
+func (m Model) exitFileHistory() (tea.Model, tea.Cmd) {
+	m.historyPath = ""
+	m.graphPanel.Collapse()
+	m.graphPanel.SetFileScope(nil)
+	m.actionBar.ClearMessage()
+	return m, m.loadCommitsCmd()
+}
+
+// showNoFileHistory goes back to all commits when no commit touched path,
+// e.g. because it was mistyped.
+func (m Model) showNoFileHistory(path string) (tea.Model, tea.Cmd) {
+	m.historyPath = ""
+	m.actionBar.SetMessage("No commits change " + path)
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd())
+}
+
+// loadFileHistoryCmd loads the commits that changed path, following it
+// across renames, up to max_commits.
+func (m Model) loadFileHistoryCmd(path string) tea.Cmd {
+	limit := m.config.Performance.MaxCommits
+	return func() tea.Msg {
+		commits, files, err := m.repo.FileHistory(path, limit)
+		if err != nil {
+			return commitsLoadedMsg{history: path, err: err}
+		}
+		state, _ := m.repo.State() // best-effort
+		return commitsLoadedMsg{commits: commits, state: state, history: path, scope: files}
+	}
+}
@@ -0,0 +1,149 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// mergeResultMsg is sent when a merge returns. state is the merge left
+// stopped on conflicts, if any.
+type mergeResultMsg struct {
+	ref    string
+	squash bool
+	result git.MergeResult
+	err    error
+	state  git.Sequencer
+}
+
+// mergeKinds are the merge menu choices, in menu order.
+var mergeKinds = []git.MergeOptions{
+	{},
+	{FFOnly: true},
+	{NoFF: true},
+	{Squash: true},
+}
+
+// pendingMerge is a merge whose commit message is being prompted for.
+type pendingMerge struct {
+	ref  string
+	opts git.MergeOptions
+}
+
+// handleMergeSelected merges a ref on the selected graph row into the
+// current branch: a local branch, else a remote branch, else a tag, else
+// the commit itself.
+func (m Model) handleMergeSelected() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	if commit == nil || commit.Hash == git.UncommittedHash {
+		m.actionBar.SetMessage("Select a commit or branch to merge")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	return m.showMergeMenu(mergeRef(commit))
+}
+
+// mergeRef picks the most meaningful name for a commit to merge.
+func mergeRef(commit *git.Commit) string {
+	var remote, tag string
+	for _, ref := range commit.Refs {
+		switch {
+		case ref.RefType == git.RefTypeBranch && !ref.IsRemote && !ref.IsHead:
+			return ref.Name
+		case ref.RefType == git.RefTypeBranch && ref.IsRemote && remote == "":
+			remote = ref.Name
+		case ref.RefType == git.RefTypeTag && tag == "":
+			tag = ref.Name
+		}
+	}
+	switch {
+	case remote != "":
+		return remote
+	case tag != "":
+		return tag
+	}
+	return commit.ShortHash
+}
+
+// showMergeMenu asks how to merge ref into the current branch.
+func (m Model) showMergeMenu(ref string) (tea.Model, tea.Cmd) {
+	if cmd, ok := m.checkNothingInProgress(); !ok {
+		return m, cmd
+	}
+	m.menuModal.Show("Merge "+ref+" into HEAD", []modals.MenuItem{
+		{Key: "m", Label: "merge", Description: "fast-forward when possible"},
+		{Key: "f", Label: "fast-forward only", Description: "fail if the branches diverged"},
+		{Key: "n", Label: "merge commit", Description: "always create one (--no-ff)"},
+		{Key: "s", Label: "squash", Description: "one ordinary commit with all the changes"},
+	})
+	m.menuPurpose = menuMerge
+	m.menuTarget = ref
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleMergeKind merges right away, or first asks for the message of the
+// commit a merge commit or squash creates.
+func (m Model) handleMergeKind(ref string, opts git.MergeOptions) (tea.Model, tea.Cmd) {
+	if !opts.NoFF && !opts.Squash {
+		return m.merge(ref, opts)
+	}
+	m.pendingMerge = pendingMerge{ref: ref, opts: opts}
+	m.inputModal.Show("Message:", "")
+	m.inputModal.SetPlaceholder("empty for git's default message")
+	m.inputPurpose = inputMergeMessage
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) mergeWithMessage(message string) (tea.Model, tea.Cmd) {
+	p := m.pendingMerge
+	m.pendingMerge = pendingMerge{}
+	p.opts.Message = message
+	return m.merge(p.ref, p.opts)
+}
+
+func (m Model) merge(ref string, opts git.MergeOptions) (tea.Model, tea.Cmd) {
+	m.actionBar.SetMessage("Merging " + ref + "...")
+	return m, func() tea.Msg {
+		result, err := m.repo.Merge(ref, opts)
+		state, _ := m.repo.SequencerInProgress() // best-effort
+		return mergeResultMsg{ref: ref, squash: opts.Squash, result: result, err: err, state: state}
+	}
+}
+
+func (m Model) handleMergeResult(msg mergeResultMsg) (tea.Model, tea.Cmd) {
+	m.state.Sequencer = msg.state
+	m.updateStatus()
+	name := msg.ref
+
+	if msg.result == git.MergeConflicted {
+		var gitErr *git.GitError
+		if errors.As(msg.err, &gitErr) {
+			m.lastGitErr = gitErr
+		}
+		if msg.squash {
+			m.actionBar.SetMessage("Conflicts squashing " + name + " — resolve and stage them, then commit")
+		} else {
+			m.actionBar.SetMessage("Conflicts merging " + name + " — resolve and stage them, then alt+c to continue")
+		}
+		return m, tea.Batch(m.clearMessageAfter(5*time.Second), m.loadCommitsCmd(), m.loadConflictsCmd())
+	}
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "merge", err: msg.err})
+	}
+
+	switch msg.result {
+	case git.MergeUpToDate:
+		m.actionBar.SetMessage("Already up to date with " + name)
+	case git.MergeFastForward:
+		m.actionBar.SetMessage("Fast-forwarded to " + name)
+	case git.MergeCommitted:
+		m.actionBar.SetMessage("Merged " + name)
+	case git.MergeSquashed:
+		m.actionBar.SetMessage("Squashed " + name + " into one commit")
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd())
+}
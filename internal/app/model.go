@@ -1,6 +1,7 @@
 package app
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/yourusername/lazygit-lite/internal/config"
 	"github.com/yourusername/lazygit-lite/internal/git"
 	"github.com/yourusername/lazygit-lite/internal/ui/components/actionbar"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/blame"
 	"github.com/yourusername/lazygit-lite/internal/ui/components/graph"
 	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
 	"github.com/yourusername/lazygit-lite/internal/ui/keys"
@@ -27,11 +29,91 @@ type Model struct {
 	keyMap keys.KeyMap
 
 	graphPanel graph.Model
+	blamePanel blame.Model
 	actionBar  actionbar.Model
 
-	commitModal modals.CommitModal
-	helpModal   modals.HelpModal
-	branchModal modals.BranchModal
+	commitModal    modals.CommitModal
+	helpModal      modals.HelpModal
+	branchModal    modals.BranchModal
+	outputModal    modals.OutputModal
+	rebaseModal    modals.RebaseModal
+	inputModal     modals.InputModal
+	menuModal      modals.MenuModal
+	confirmModal   modals.ConfirmModal
+	stashModal     modals.StashModal
+	tagModal       modals.TagModal
+	remoteModal    modals.RemoteModal
+	worktreeModal  modals.WorktreeModal
+	submoduleModal modals.SubmoduleModal
+	reflogModal    modals.ReflogModal
+	conflictModal  modals.ConflictModal
+
+	// inputPurpose records what the value typed into inputModal is for,
+	// and inputTarget the commit or branch it applies to.
+	inputPurpose inputPurpose
+	inputTarget  string
+
+	// menuPurpose records what the choice made in menuModal is for, and
+	// menuTarget the commit it applies to.
+	menuPurpose menuPurpose
+	menuTarget  string
+
+	// confirmPurpose records what confirmModal is asking about, and
+	// confirmTarget the commit it applies to.
+	confirmPurpose confirmPurpose
+	confirmTarget  string
+
+	// pendingStash holds the stash options chosen while the message is
+	// prompted for.
+	pendingStash git.StashOptions
+
+	// pendingTag holds the tag being created while its name and message
+	// are prompted for.
+	pendingTag pendingTag
+
+	// pendingPush holds the remote, branch and flags chosen while the
+	// remote branch name is prompted for, or the force push being
+	// confirmed.
+	pendingPush git.PushOptions
+
+	// pendingMerge holds the merge whose commit message is prompted for.
+	pendingMerge pendingMerge
+
+	// pendingAmend holds the commit being amended or reworded in
+	// commitModal.
+	pendingAmend pendingAmend
+
+	// pendingWorktree holds the worktree being added while its branch is
+	// prompted for.
+	pendingWorktree pendingWorktree
+
+	// pendingDeleteUpstream is the branch whose upstream is deleted after
+	// the forced delete being confirmed, or nil.
+	pendingDeleteUpstream *git.Branch
+
+	// pendingAutosquash holds the previewed autosquash plan while it is
+	// confirmed.
+	pendingAutosquash *git.AutosquashPlan
+
+	// historyPath is the file whose history the graph is filtered to, or
+	// "" to show all commits.
+	historyPath string
+
+	// parents are the repositories left to open nested submodule
+	// sessions, innermost last.
+	parents []parentRepo
+
+	// watchSession counts the repositories opened, so the watcher of one
+	// switched away from is ignored when it fires.
+	watchSession int
+
+	// state is the rebase, cherry-pick, revert, merge or bisect in
+	// progress at the last reload, if any.
+	state git.RepoState
+
+	// lastGitErr is the most recent failed git command, kept so its full
+	// output can be inspected after the action bar message clears.
+	lastGitErr *git.GitError
 
 	width  int
 	height int
@@ -48,16 +130,86 @@ func New(cfg *config.Config, repoPath string) (*Model, error) {
 	st := styles.NewStyles(theme)
 
 	return &Model{
-		config:      cfg,
-		repo:        repo,
-		styles:      st,
-		keyMap:      keys.DefaultKeyMap(),
-		commitModal: modals.NewCommitModal(st),
-		helpModal:   modals.NewHelpModal(st),
-		branchModal: modals.NewBranchModal(st),
+		config:         cfg,
+		repo:           repo,
+		styles:         st,
+		keyMap:         keys.DefaultKeyMap(),
+		commitModal:    modals.NewCommitModal(st),
+		helpModal:      modals.NewHelpModal(st),
+		branchModal:    modals.NewBranchModal(st),
+		outputModal:    modals.NewOutputModal(st),
+		rebaseModal:    modals.NewRebaseModal(st),
+		inputModal:     modals.NewInputModal(st),
+		menuModal:      modals.NewMenuModal(st),
+		confirmModal:   modals.NewConfirmModal(st),
+		stashModal:     modals.NewStashModal(st),
+		tagModal:       modals.NewTagModal(st),
+		remoteModal:    modals.NewRemoteModal(st),
+		worktreeModal:  modals.NewWorktreeModal(st),
+		submoduleModal: modals.NewSubmoduleModal(st),
+		reflogModal:    modals.NewReflogModal(st),
+		conflictModal:  modals.NewConflictModal(st),
 	}, nil
 }
 
+// inputPurpose identifies what the shared input prompt is collecting.
+type inputPurpose int
+
+const (
+	inputNone inputPurpose = iota
+	inputRebaseReword
+	inputStashMessage
+	inputStashBranch
+	inputNewBranch
+	inputRenameBranch
+	inputSetUpstream
+	inputTagName
+	inputTagMessage
+	inputRemoteName
+	inputRemoteURL
+	inputRenameRemote
+	inputRemoteEditURL
+	inputPushBranch
+	inputMergeMessage
+	inputBisectRun
+	inputFileHistory
+	inputFixupSubject
+	inputWorktreePath
+	inputWorktreeBranch
+)
+
+// menuPurpose identifies what the shared choice menu is for.
+type menuPurpose int
+
+const (
+	menuNone menuPurpose = iota
+	menuRevertParent
+	menuReset
+	menuStash
+	menuDeleteBranch
+	menuNewTag
+	menuDeleteTag
+	menuMerge
+	menuBisect
+	menuFixup
+	menuPushTo
+)
+
+// confirmPurpose identifies what the confirmation prompt is guarding.
+type confirmPurpose int
+
+const (
+	confirmNone confirmPurpose = iota
+	confirmHardReset
+	confirmStashDrop
+	confirmForceDeleteBranch
+	confirmRemoveRemote
+	confirmAutosquash
+	confirmRemoveWorktree
+	confirmForceRemoveWorktree
+	confirmForcePush
+)
+
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		m.commitModal.Init(),
@@ -75,6 +227,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleMouse(msg)
 
 	case tea.KeyMsg:
+		// The input prompt can open on top of another panel, so it
+		// gets keys first.
+		if m.inputModal.IsVisible() {
+			return m.handleInputModal(msg)
+		}
+
+		if m.confirmModal.IsVisible() {
+			return m.handleConfirmModal(msg)
+		}
+
+		if m.menuModal.IsVisible() {
+			return m.handleMenuModal(msg)
+		}
+
 		if m.commitModal.IsVisible() {
 			return m.handleCommitModal(msg)
 		}
@@ -83,6 +249,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleBranchModal(msg)
 		}
 
+		if m.rebaseModal.IsVisible() {
+			return m.handleRebaseModal(msg)
+		}
+
+		if m.stashModal.IsVisible() {
+			return m.handleStashModal(msg)
+		}
+
+		if m.tagModal.IsVisible() {
+			return m.handleTagModal(msg)
+		}
+
+		if m.remoteModal.IsVisible() {
+			return m.handleRemoteModal(msg)
+		}
+
+		if m.worktreeModal.IsVisible() {
+			return m.handleWorktreeModal(msg)
+		}
+
+		if m.submoduleModal.IsVisible() {
+			return m.handleSubmoduleModal(msg)
+		}
+
+		if m.reflogModal.IsVisible() {
+			return m.handleReflogModal(msg)
+		}
+
+		if m.conflictModal.IsVisible() {
+			return m.handleConflictModal(msg)
+		}
+
+		if m.outputModal.IsVisible() {
+			return m.handleOutputModal(msg)
+		}
+
 		if m.helpModal.IsVisible() {
 			if keys.MatchesKey(msg, m.keyMap.Help) || msg.String() == "esc" {
 				m.helpModal.Toggle()
@@ -92,6 +294,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if m.blamePanel.IsVisible() {
+			return m.handleBlameKey(msg)
+		}
+
 		return m.handleKey(msg)
 
 	case commitsLoadedMsg:
@@ -107,7 +313,99 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case branchesLoadedMsg:
 		return m.handleBranchesLoaded(msg)
 
+	case branchInfoLoadedMsg:
+		return m.handleBranchInfoLoaded(msg)
+
+	case rebaseCommitsLoadedMsg:
+		return m.handleRebaseCommitsLoaded(msg)
+
+	case rebaseResultMsg:
+		return m.handleRebaseResult(msg)
+
+	case sequencerResultMsg:
+		return m.handleSequencerResult(msg)
+
+	case resetFilesLoadedMsg:
+		return m.handleResetFilesLoaded(msg)
+
+	case resetResultMsg:
+		return m.handleResetResult(msg)
+
+	case branchResultMsg:
+		return m.handleBranchResult(msg)
+
+	case tagsLoadedMsg:
+		return m.handleTagsLoaded(msg)
+
+	case tagResultMsg:
+		return m.handleTagResult(msg)
+
+	case worktreesLoadedMsg:
+		return m.handleWorktreesLoaded(msg)
+
+	case worktreeResultMsg:
+		return m.handleWorktreeResult(msg)
+
+	case submodulesLoadedMsg:
+		return m.handleSubmodulesLoaded(msg)
+
+	case submoduleResultMsg:
+		return m.handleSubmoduleResult(msg)
+
+	case reflogLoadedMsg:
+		return m.handleReflogLoaded(msg)
+
+	case undoResultMsg:
+		return m.handleUndoResult(msg)
+
+	case remotesLoadedMsg:
+		return m.handleRemotesLoaded(msg)
+
+	case remoteResultMsg:
+		return m.handleRemoteResult(msg)
+
+	case pushRejectedMsg:
+		return m.handlePushRejected(msg)
+
+	case mergeResultMsg:
+		return m.handleMergeResult(msg)
+
+	case amendLoadedMsg:
+		return m.handleAmendLoaded(msg)
+
+	case autosquashPlanMsg:
+		return m.handleAutosquashPlan(msg)
+
+	case blameLoadedMsg:
+		return m.handleBlameLoaded(msg)
+
+	case bisectResultMsg:
+		return m.handleBisectResult(msg)
+
+	case bisectOutputMsg:
+		return m.handleBisectOutput(msg)
+
+	case conflictsLoadedMsg:
+		return m.handleConflictsLoaded(msg)
+
+	case conflictContentMsg:
+		return m.handleConflictContent(msg)
+
+	case conflictResultMsg:
+		return m.handleConflictResult(msg)
+
+	case stashesLoadedMsg:
+		return m.handleStashesLoaded(msg)
+
+	case stashShownMsg:
+		return m.handleStashShown(msg)
+
 	case gitRepoChangedMsg:
+		// A watcher left over from a repository switched away from stops
+		// here.
+		if msg.session != m.watchSession {
+			return m, nil
+		}
 		// External .git change detected — reload commits and restart watcher.
 		return m, tea.Batch(m.loadCommitsCmd(), m.watchGitDirCmd())
 
@@ -115,8 +413,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// No auto-load needed — diffs are shown inline on expand.
 		return m, nil
 
-	case graph.FilesLoadedMsg, graph.FileDiffLoadedMsg:
+	case graph.FilesLoadedMsg, graph.FileDiffLoadedMsg, graph.CommitsPageLoadedMsg:
 		// Check for errors and display in action bar.
+		var errCmd tea.Cmd
 		switch typedMsg := msg.(type) {
 		case graph.FilesLoadedMsg:
 			if typedMsg.Err != nil {
@@ -128,11 +427,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.actionBar.SetMessage("Failed to load diff: " + typedMsg.Err.Error())
 				return m, m.clearMessageAfter(3 * time.Second)
 			}
+		case graph.CommitsPageLoadedMsg:
+			// The graph still needs the message to stop paging.
+			if typedMsg.Err != nil {
+				m.actionBar.SetMessage("Failed to load more commits: " + typedMsg.Err.Error())
+				errCmd = m.clearMessageAfter(3 * time.Second)
+			}
 		}
 		// Forward to graph panel.
 		var cmd tea.Cmd
 		m.graphPanel, cmd = m.graphPanel.Update(msg)
-		return m, cmd
+		// The page may have landed while the cursor sits at the new end.
+		// LoadMoreIfNeeded marks the graph as loading, so it must run
+		// before m is returned.
+		more := m.graphPanel.LoadMoreIfNeeded(m.repo)
+		return m, tea.Batch(cmd, errCmd, more)
 	}
 
 	if m.ready {
@@ -154,17 +463,67 @@ func (m Model) View() string {
 	mainPanel := m.graphPanel.View()
 	actionBarView := m.actionBar.View()
 
-	// Determine if any inline bottom panel is active.
-	var extraPanel string
+	// Stack the visible inline bottom panels; usually there is at most
+	// one, but a prompt can open below the panel it belongs to.
+	var panels []string
 	if m.commitModal.IsVisible() {
-		extraPanel = m.commitModal.View()
-	} else if m.branchModal.IsVisible() {
-		extraPanel = m.branchModal.View()
-	} else if m.helpModal.IsVisible() {
-		extraPanel = m.helpModal.View()
+		panels = append(panels, m.commitModal.View())
+	}
+	if m.branchModal.IsVisible() {
+		panels = append(panels, m.branchModal.View())
+	}
+	if m.rebaseModal.IsVisible() {
+		panels = append(panels, m.rebaseModal.View())
+	}
+	if m.stashModal.IsVisible() {
+		panels = append(panels, m.stashModal.View())
+	}
+	if m.tagModal.IsVisible() {
+		panels = append(panels, m.tagModal.View())
+	}
+	if m.remoteModal.IsVisible() {
+		panels = append(panels, m.remoteModal.View())
+	}
+	if m.worktreeModal.IsVisible() {
+		panels = append(panels, m.worktreeModal.View())
+	}
+	if m.submoduleModal.IsVisible() {
+		panels = append(panels, m.submoduleModal.View())
+	}
+	if m.reflogModal.IsVisible() {
+		panels = append(panels, m.reflogModal.View())
+	}
+	if m.conflictModal.IsVisible() {
+		panels = append(panels, m.conflictModal.View())
 	}
+	if m.menuModal.IsVisible() {
+		panels = append(panels, m.menuModal.View())
+	}
+	if m.confirmModal.IsVisible() {
+		panels = append(panels, m.confirmModal.View())
+	}
+	if m.outputModal.IsVisible() {
+		panels = append(panels, m.outputModal.View())
+	}
+	if m.helpModal.IsVisible() {
+		panels = append(panels, m.helpModal.View())
+	}
+	if m.inputModal.IsVisible() {
+		panels = append(panels, m.inputModal.View())
+	}
+	extraPanel := strings.Join(panels, "\n")
 
-	return m.layout.RenderWithExtra(mainPanel, extraPanel, actionBarView)
+	if m.blamePanel.IsVisible() {
+		return m.layout.RenderTitled(m.blamePanel.Title(), m.blamePanel.View(), extraPanel, actionBarView)
+	}
+	title := "Commits"
+	if m.historyPath != "" {
+		title = "History of " + m.historyPath
+	}
+	if label := m.submoduleLabel(); label != "" {
+		title += " in submodule " + label
+	}
+	return m.layout.RenderTitled(title, mainPanel, extraPanel, actionBarView)
 }
 
 func (m Model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
@@ -176,36 +535,59 @@ func (m Model) handleResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 			m.styles.Theme.Background, m.styles.Theme.Border, m.styles.Theme.Foreground)
 		contentW, contentH := m.layout.Calculate()
 
-		commits, err := m.repo.GetCommits(m.config.Performance.MaxCommits)
+		limit := m.commitLimit()
+		commits, err := m.repo.GetCommits(0, limit)
+		hasMore := err == nil && m.hasMoreCommits(git.HistoryLen(commits), limit)
+		state, _ := m.repo.State() // best-effort
 		if err == nil {
-			commits = m.prependUncommitted(commits)
+			commits = m.prependUncommitted(commits, state)
 		}
-		m.graphPanel = graph.New(commits, m.styles.Theme, contentW, contentH)
+		m.graphPanel = graph.New(nil, m.styles.Theme, contentW, contentH)
+		perf := m.config.Performance
+		m.graphPanel.SetPaging(perf.PageSize, perf.LazyLoadThreshold, perf.MaxCommits)
+		m.graphPanel.SetCommits(commits, hasMore)
+		m.blamePanel = blame.New(m.styles.Theme, contentW, contentH)
 		m.actionBar = actionbar.New(m.styles, m.width)
 
-		// Set current branch on the action bar.
-		m.updateBranchInfo()
+		m.setState(state)
 
-		// Size the modals to terminal dimensions.
-		m.commitModal.SetSize(m.width, m.height)
-		m.helpModal.SetSize(m.width, m.height)
-		m.branchModal.SetSize(m.width, m.height)
+		m.sizeModals()
 
 		m.ready = true
+		// Set current branch on the action bar.
+		return m, m.loadBranchInfoCmd()
 	} else {
 		m.layout.SetSize(m.width, m.height)
 		contentW, contentH := m.layout.Calculate()
 
 		m.graphPanel.SetSize(contentW, contentH)
+		m.blamePanel.SetSize(contentW, contentH)
 		m.actionBar.SetWidth(m.width)
-		m.commitModal.SetSize(m.width, m.height)
-		m.helpModal.SetSize(m.width, m.height)
-		m.branchModal.SetSize(m.width, m.height)
+		m.sizeModals()
 	}
 
 	return m, nil
 }
 
+// sizeModals sizes the inline panels to the terminal dimensions.
+func (m *Model) sizeModals() {
+	m.commitModal.SetSize(m.width, m.height)
+	m.helpModal.SetSize(m.width, m.height)
+	m.branchModal.SetSize(m.width, m.height)
+	m.outputModal.SetSize(m.width, m.height)
+	m.rebaseModal.SetSize(m.width, m.height)
+	m.inputModal.SetSize(m.width, m.height)
+	m.menuModal.SetSize(m.width, m.height)
+	m.confirmModal.SetSize(m.width, m.height)
+	m.stashModal.SetSize(m.width, m.height)
+	m.tagModal.SetSize(m.width, m.height)
+	m.remoteModal.SetSize(m.width, m.height)
+	m.worktreeModal.SetSize(m.width, m.height)
+	m.submoduleModal.SetSize(m.width, m.height)
+	m.reflogModal.SetSize(m.width, m.height)
+	m.conflictModal.SetSize(m.width, m.height)
+}
+
 // recalcGraphSize recalculates the graph panel dimensions based on the current
 // visibility of inline panels (commit input, help). Call this whenever a modal
 // is toggled so the graph's scroll and rendering use the correct height.
@@ -213,39 +595,81 @@ func (m *Model) recalcGraphSize() {
 	if m.layout == nil {
 		return
 	}
-	extra := m.commitModal.Height() + m.helpModal.Height() + m.branchModal.Height()
+	extra := m.inlinePanelHeight()
 
 	// If the modal(s) would leave the graph panel with fewer than 3 rows,
 	// auto-close the help modal (the largest one) to reclaim space.
 	_, testH := m.layout.CalculateWithExtra(extra)
 	if testH <= 3 && m.helpModal.IsVisible() {
 		m.helpModal.Toggle()
-		extra = m.commitModal.Height() + m.helpModal.Height() + m.branchModal.Height()
+		extra = m.inlinePanelHeight()
 	}
 
 	contentW, contentH := m.layout.CalculateWithExtra(extra)
 	m.graphPanel.SetSize(contentW, contentH)
+	m.blamePanel.SetSize(contentW, contentH)
 }
 
-func (m *Model) updateBranchInfo() {
-	branches, err := m.repo.GetBranches()
-	if err != nil {
-		return
+// inlinePanelHeight returns the total height of all visible inline panels.
+func (m *Model) inlinePanelHeight() int {
+	return m.commitModal.Height() + m.helpModal.Height() + m.branchModal.Height() +
+		m.outputModal.Height() + m.rebaseModal.Height() + m.inputModal.Height() +
+		m.menuModal.Height() + m.confirmModal.Height() + m.stashModal.Height() +
+		m.tagModal.Height() + m.remoteModal.Height() + m.worktreeModal.Height() +
+		m.submoduleModal.Height() + m.reflogModal.Height() + m.conflictModal.Height()
+}
+
+// loadBranchInfoCmd reads the current branch and how it tracks its
+// upstream for the action bar. GetBranches runs git, so this stays off
+// the UI goroutine.
+func (m Model) loadBranchInfoCmd() tea.Cmd {
+	repo := m.repo
+	return func() tea.Msg {
+		branch, err := repo.CurrentBranch()
+		return branchInfoLoadedMsg{repo: repo, branch: branch, err: err}
 	}
-	for _, b := range branches {
-		if b.IsHead {
-			m.actionBar.SetBranch(b.Name)
-			return
-		}
+}
+
+// handleBranchInfoLoaded shows the current branch on the action bar. A
+// detached HEAD or a failed load keeps what is shown.
+func (m Model) handleBranchInfoLoaded(msg branchInfoLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.repo != m.repo || msg.err != nil {
+		return m, nil
 	}
+	m.actionBar.SetBranch(msg.branch.Name)
+	m.actionBar.SetTracking(msg.branch.Ahead, msg.branch.Behind, msg.branch.UpstreamGone)
+	return m, nil
+}
+
+// openRepo points the app at repo, dropping the views tied to the one it
+// leaves. The graph reloads and the watcher follows it.
+func (m Model) openRepo(repo *git.Repository, message string) (tea.Model, tea.Cmd) {
+	m.repo = repo
+	m.watchSession++
+	m.worktreeModal.Hide()
+	m.submoduleModal.Hide()
+	m.reflogModal.Hide()
+	m.blamePanel.Close()
+	m.historyPath = ""
+	m.graphPanel.Collapse()
+	m.graphPanel.ClearMarks()
+	m.graphPanel.SetFileScope(nil)
+	m.recalcGraphSize()
+	m.actionBar.SetMessage(message)
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd(), m.watchGitDirCmd())
 }
 
 // prependUncommitted checks for working tree changes and prepends a synthetic
 // "Uncommitted changes" entry to the commit list so it appears at the top.
-func (m Model) prependUncommitted(commits []*git.Commit) []*git.Commit {
+// The entry names the operation in progress, if any, so it stands out.
+func (m Model) prependUncommitted(commits []*git.Commit, state git.RepoState) []*git.Commit {
 	if !m.repo.HasWorkingTreeChanges() {
 		return commits
 	}
+	subject := "Uncommitted changes"
+	if label := stateLabel(state); label != "" {
+		subject += " — " + label
+	}
 
 	parentHash := ""
 	if len(commits) > 0 {
@@ -258,8 +682,8 @@ func (m Model) prependUncommitted(commits []*git.Commit) []*git.Commit {
 		Author:    "You",
 		Email:     "",
 		Date:      time.Now(),
-		Message:   "Uncommitted changes",
-		Subject:   "Uncommitted changes",
+		Message:   subject,
+		Subject:   subject,
 		Parents:   []string{parentHash},
 		Refs:      nil,
 	}
@@ -284,6 +708,14 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
+	if keys.MatchesKey(msg, m.keyMap.Amend) {
+		return m.handleAmend()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Reword) {
+		return m.handleReword()
+	}
+
 	if keys.MatchesKey(msg, m.keyMap.Push) {
 		m.actionBar.SetMessage("Pushing...")
 		return m, m.pushCmd()
@@ -303,18 +735,25 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, m.showBranchPickerCmd()
 	}
 
+	if keys.MatchesKey(msg, m.keyMap.NewBranch) {
+		return m.handleNewBranch()
+	}
+
 	// Enter toggles expand on the selected commit / file.
 	if keys.MatchesKey(msg, m.keyMap.Enter) {
 		cmd := m.graphPanel.ToggleExpand(m.repo)
 		return m, cmd
 	}
 
-	// Esc collapses any expanded commit.
+	// Esc collapses any expanded commit, then leaves a file's history.
 	if msg.String() == "esc" {
 		if m.graphPanel.IsExpanded() {
 			m.graphPanel.Collapse()
 			return m, nil
 		}
+		if m.historyPath != "" {
+			return m.exitFileHistory()
+		}
 		return m, nil
 	}
 
@@ -330,24 +769,179 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleCopyDiff()
 	}
 
-	// All other keys (j/k/g/G/ctrl+d/ctrl+u) go to the graph panel.
+	if keys.MatchesKey(msg, m.keyMap.Stage) {
+		return m.handleStageToggle()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Output) {
+		if m.lastGitErr == nil {
+			m.actionBar.SetMessage("No failed command to show")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		m.outputModal.Show(outputTitle(m.lastGitErr), m.lastGitErr.Output())
+		m.recalcGraphSize()
+		return m, nil
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Rebase) {
+		commit := m.graphPanel.SelectedCommit()
+		if commit == nil || commit.Hash == git.UncommittedHash {
+			m.actionBar.SetMessage("Select a commit to rebase from")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		if m.state.Rebase != nil {
+			m.actionBar.SetMessage("A rebase is already in progress")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		return m, m.loadRebaseCommitsCmd(commit.Hash)
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Mark) {
+		return m.handleMark()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.CherryPick) {
+		return m.handleCherryPick()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Revert) {
+		return m.handleRevert()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Reset) {
+		return m.handleReset()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Stash) {
+		return m.handleStashMenu()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Stashes) {
+		return m, m.loadStashesCmd()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Tags) {
+		return m, m.loadTagsCmd()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Fixup) {
+		return m.handleFixup()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Autosquash) {
+		return m.handleAutosquash()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Merge) {
+		return m.handleMergeSelected()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Conflicts) {
+		return m, m.loadConflictsCmd()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Bisect) {
+		return m.handleBisect()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Blame) {
+		return m.handleBlame()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.FileHistory) {
+		return m.handleFileHistory()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Remotes) {
+		return m, m.loadRemotesCmd()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Worktrees) {
+		return m, m.loadWorktreesCmd()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Submodules) {
+		return m, m.loadSubmodulesCmd()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.ParentRepo) {
+		return m.leaveSubmodule()
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Reflog) {
+		return m, m.loadReflogCmd("HEAD")
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Undo) {
+		return m.handleUndo(false)
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Redo) {
+		return m.handleUndo(true)
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Continue) {
+		return m.handleStep("continue")
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Skip) {
+		return m.handleStep("skip")
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.Abort) {
+		return m.handleStep("abort")
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.NextHunk) {
+		m.graphPanel.SelectHunk(1)
+		return m, nil
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.PrevHunk) {
+		m.graphPanel.SelectHunk(-1)
+		return m, nil
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.NextLine) {
+		m.graphPanel.SelectLine(1)
+		return m, nil
+	}
+
+	if keys.MatchesKey(msg, m.keyMap.PrevLine) {
+		m.graphPanel.SelectLine(-1)
+		return m, nil
+	}
+
+	// All other keys (j/k/g/G/ctrl+d/ctrl+u) go to the graph panel, which
+	// may have moved close enough to the end to need the next page.
 	var cmd tea.Cmd
 	m.graphPanel, cmd = m.graphPanel.Update(msg)
-	return m, cmd
+	more := m.graphPanel.LoadMoreIfNeeded(m.repo)
+	return m, tea.Batch(cmd, more)
 }
 
 func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	if !m.ready || m.commitModal.IsVisible() || m.helpModal.IsVisible() {
 		return m, nil
 	}
+	if m.blamePanel.IsVisible() {
+		var cmd tea.Cmd
+		m.blamePanel, cmd = m.blamePanel.Update(msg)
+		return m, cmd
+	}
 
 	var cmd tea.Cmd
 	m.graphPanel, cmd = m.graphPanel.Update(msg)
-	return m, cmd
+	more := m.graphPanel.LoadMoreIfNeeded(m.repo)
+	return m, tea.Batch(cmd, more)
 }
 
 func (m Model) handleCommitModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	if msg.String() == "esc" {
+		if m.commitModal.Mode() != modals.CommitNew {
+			m.pendingAmend = pendingAmend{}
+			m.actionBar.ClearMessage()
+		}
 		m.commitModal.Hide()
 		m.recalcGraphSize()
 		return m, nil
@@ -361,6 +955,9 @@ func (m Model) handleCommitModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.commitModal.Hide()
 		m.recalcGraphSize()
+		if mode := m.commitModal.Mode(); mode != modals.CommitNew {
+			return m.submitAmend(mode, message)
+		}
 		m.actionBar.SetMessage("Committing...")
 		return m, m.commitCmd(message)
 	}
@@ -417,6 +1014,48 @@ func (m Model) handleCopyDiff() (tea.Model, tea.Cmd) {
 	return m, m.clearMessageAfter(3 * time.Second)
 }
 
+// handleStageToggle stages or unstages the selected line or hunk of the open diff, or
+// the selected file when no hunk is selected, in the "Uncommitted changes"
+// entry.
+func (m Model) handleStageToggle() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	file := m.graphPanel.SelectedFile()
+	if commit == nil || commit.Hash != git.UncommittedHash || file == nil {
+		m.actionBar.SetMessage("Select a file in Uncommitted changes to stage it")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	if patch, staged, ok := m.graphPanel.SelectedHunkPatch(); ok {
+		what := "hunk"
+		if m.graphPanel.LineSelected() {
+			what = "line"
+		}
+		if staged {
+			return m, m.applyPatchCmd("unstage "+what, patch, true)
+		}
+		return m, m.applyPatchCmd("stage "+what, patch, false)
+	}
+
+	path := file.Path
+	if file.Unstaged {
+		return m, func() tea.Msg {
+			err := m.repo.StageFile(path)
+			return operationResultMsg{operation: "stage", err: err}
+		}
+	}
+	return m, func() tea.Msg {
+		err := m.repo.UnstageFile(path)
+		return operationResultMsg{operation: "unstage", err: err}
+	}
+}
+
+func (m Model) applyPatchCmd(operation, patch string, reverse bool) tea.Cmd {
+	return func() tea.Msg {
+		err := m.repo.ApplyPatch(patch, reverse)
+		return operationResultMsg{operation: operation, err: err}
+	}
+}
+
 func (m Model) handleBranchModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc", "b":
@@ -439,17 +1078,261 @@ func (m Model) handleBranchModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		branchName := branch.Name
 		m.branchModal.Hide()
 		m.recalcGraphSize()
+		// git won't check out a branch a second time; go to it instead.
+		if branch.Worktree != "" {
+			return m.switchWorktree(branch.Worktree)
+		}
 		m.actionBar.SetMessage("Checking out " + branchName + "...")
 		return m, m.checkoutCmd(branchName)
+	case "n", "r", "d", "u", "U", "m":
+		return m.handleBranchAction(msg.String())
+	case "l":
+		branch := m.branchModal.SelectedBranch()
+		if branch == nil {
+			return m, nil
+		}
+		m.branchModal.Hide()
+		m.recalcGraphSize()
+		return m, m.loadReflogCmd(branch.Name)
+	}
+	return m, nil
+}
+
+// handleInputModal routes keys to the shared input prompt and applies the
+// value according to inputPurpose on Enter.
+func (m Model) handleInputModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.inputModal.Hide()
+		m.inputPurpose = inputNone
+		m.inputTarget = ""
+		m.recalcGraphSize()
+		return m, nil
+	case "enter":
+		value := strings.TrimSpace(m.inputModal.Value())
+		if value == "" && m.inputPurpose != inputStashMessage && m.inputPurpose != inputTagMessage &&
+			m.inputPurpose != inputMergeMessage {
+			return m, nil
+		}
+		purpose, target := m.inputPurpose, m.inputTarget
+		m.inputModal.Hide()
+		m.inputPurpose = inputNone
+		m.inputTarget = ""
+		m.recalcGraphSize()
+
+		switch purpose {
+		case inputRebaseReword:
+			if item := m.rebaseModal.SelectedItem(); item != nil {
+				m.rebaseModal.SetReword(git.ReplaceSubject(item.Commit.Message, value))
+			}
+		case inputStashMessage:
+			opts := m.pendingStash
+			opts.Message = value
+			m.actionBar.SetMessage("Stashing...")
+			return m, m.stashPushCmd(opts)
+		case inputStashBranch:
+			stash := m.stashModal.SelectedStash()
+			if stash == nil {
+				return m, nil
+			}
+			name := stash.Name
+			m.hideStashes()
+			m.actionBar.SetMessage("Creating branch " + value + "...")
+			return m, m.stashOpCmd("stash branch", func() error { return m.repo.StashBranch(value, name) })
+		case inputNewBranch:
+			m.actionBar.SetMessage("Creating branch " + value + "...")
+			return m, m.branchCmd("create branch", value, func() error {
+				return m.repo.CreateBranch(value, target, true)
+			})
+		case inputRenameBranch:
+			if value == target {
+				return m, nil
+			}
+			return m, m.branchCmd("rename branch", value, func() error {
+				return m.repo.RenameBranch(target, value)
+			})
+		case inputTagName:
+			return m.handleTagName(value)
+		case inputTagMessage:
+			return m.createTag(value)
+		case inputSetUpstream:
+			return m, m.branchCmd("set upstream", target, func() error {
+				return m.repo.SetUpstream(target, value)
+			})
+		case inputRemoteName:
+			m.inputModal.Show("URL of "+value+":", "")
+			m.inputPurpose = inputRemoteURL
+			m.inputTarget = value
+			m.recalcGraphSize()
+		case inputRemoteURL:
+			return m, m.remoteCmd("add remote", target, func() error {
+				return m.repo.AddRemote(target, value)
+			})
+		case inputRenameRemote:
+			if value == target {
+				return m, nil
+			}
+			return m, m.remoteCmd("rename remote", value, func() error {
+				return m.repo.RenameRemote(target, value)
+			})
+		case inputRemoteEditURL:
+			return m, m.remoteCmd("set remote url", target, func() error {
+				return m.repo.SetRemoteURL(target, value)
+			})
+		case inputPushBranch:
+			return m.pushTo(value)
+		case inputMergeMessage:
+			return m.mergeWithMessage(value)
+		case inputBisectRun:
+			return m.runBisect(value)
+		case inputFileHistory:
+			return m.showFileHistory(value)
+		case inputFixupSubject:
+			return m.commitAmendFixup(target, value)
+		case inputWorktreePath:
+			return m.handleWorktreePath(value)
+		case inputWorktreeBranch:
+			return m.addWorktree(value)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.inputModal, cmd = m.inputModal.Update(msg)
+	return m, cmd
+}
+
+// handleMenuModal routes keys to the shared choice menu and acts on the
+// chosen item according to menuPurpose.
+func (m Model) handleMenuModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	choice := -1
+	switch msg.String() {
+	case "esc":
+		m.hideMenu()
+		return m, nil
+	case "j", "down":
+		m.menuModal.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.menuModal.MoveUp()
+		return m, nil
+	case "enter":
+		choice = m.menuModal.Selected()
+	default:
+		choice = m.menuModal.IndexForKey(msg.String())
+	}
+	if choice < 0 {
+		return m, nil
+	}
+
+	purpose, target := m.menuPurpose, m.menuTarget
+	m.hideMenu()
+
+	switch purpose {
+	case menuRevertParent:
+		mainline := choice + 1
+		m.actionBar.SetMessage("Reverting merge " + shortHash(target) + "...")
+		return m, m.sequencerCmd("revert", 1, func() error {
+			return m.repo.RevertMerge(target, mainline)
+		})
+	case menuReset:
+		return m.handleResetChoice(target, resetModes[choice])
+	case menuStash:
+		return m.handleStashChoice(stashPushOptions[choice])
+	case menuPushTo:
+		return m.handlePushToKind(pushToKinds[choice])
+	case menuDeleteBranch:
+		if branch := m.branchModal.SelectedBranch(); branch != nil && branch.Name == target {
+			scope := deleteScopes[choice]
+			return m.deleteBranch(branch, scope.local, scope.remote)
+		}
+	case menuNewTag:
+		return m.handleTagKind(choice)
+	case menuDeleteTag:
+		scope := deleteScopes[choice]
+		return m.deleteTag(target, scope.local, scope.remote)
+	case menuMerge:
+		return m.handleMergeKind(target, mergeKinds[choice])
+	case menuBisect:
+		return m.handleBisectAction(target, bisectActions[choice])
+	case menuFixup:
+		return m.handleFixupKind(target, fixupKinds[choice])
 	}
 	return m, nil
 }
 
+func (m *Model) hideMenu() {
+	m.menuModal.Hide()
+	m.menuPurpose = menuNone
+	m.menuTarget = ""
+	m.recalcGraphSize()
+}
+
+// handleConfirmModal asks for an explicit y before running the operation
+// recorded in confirmPurpose; any other key cancels it.
+func (m Model) handleConfirmModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	purpose, target := m.confirmPurpose, m.confirmTarget
+	m.confirmModal.Hide()
+	m.confirmPurpose = confirmNone
+	m.confirmTarget = ""
+	m.recalcGraphSize()
+
+	if msg.String() != "y" {
+		m.actionBar.SetMessage("Cancelled")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	switch purpose {
+	case confirmHardReset:
+		m.actionBar.SetMessage("Resetting...")
+		return m, m.resetCmd(target, git.ResetHard)
+	case confirmForceDeleteBranch:
+		return m.forceDeleteBranch(target)
+	case confirmRemoveRemote:
+		m.actionBar.SetMessage("Removing remote " + target + "...")
+		return m, m.remoteCmd("remove remote", target, func() error {
+			return m.repo.RemoveRemote(target)
+		})
+	case confirmStashDrop:
+		m.actionBar.SetMessage("Dropping " + target + "...")
+		return m, m.stashOpCmd("stash drop", func() error { return m.repo.StashDrop(target) })
+	case confirmAutosquash:
+		return m.autosquash()
+	case confirmRemoveWorktree, confirmForceRemoveWorktree:
+		return m.removeWorktree(target, purpose == confirmForceRemoveWorktree)
+	case confirmForcePush:
+		return m.forcePush()
+	}
+	return m, nil
+}
+
+func (m Model) handleOutputModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.String() == "esc" || keys.MatchesKey(msg, m.keyMap.Output):
+		m.outputModal.Hide()
+		m.recalcGraphSize()
+	case keys.MatchesKey(msg, m.keyMap.Down):
+		m.outputModal.ScrollDown()
+	case keys.MatchesKey(msg, m.keyMap.Up):
+		m.outputModal.ScrollUp()
+	}
+	return m, nil
+}
+
+// outputTitle formats the output panel title for a failed command.
+func outputTitle(e *git.GitError) string {
+	return fmt.Sprintf("%s (exit %d)", e.CommandLine(), e.ExitCode)
+}
+
 func (m Model) handleBranchesLoaded(msg branchesLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.branches == nil || len(msg.branches) == 0 {
 		m.actionBar.SetMessage("No branches found")
 		return m, m.clearMessageAfter(3 * time.Second)
 	}
+	if m.branchModal.IsVisible() {
+		m.branchModal.SetBranches(msg.branches)
+		return m, nil
+	}
 	m.branchModal.Show(msg.branches)
 	m.recalcGraphSize()
 	return m, nil
@@ -474,12 +1357,19 @@ func (m Model) checkoutCmd(branch string) tea.Cmd {
 
 type commitsLoadedMsg struct {
 	commits []*git.Commit
+	hasMore bool
+	state   git.RepoState
 	err     error
+
+	// history is the file whose history was loaded, "" for all commits,
+	// and scope the file each of its commits changed.
+	history string
+	scope   map[string]git.ChangedFile
 }
 
 // operationResultMsg is sent when a git operation (push/pull/fetch/commit) completes.
 type operationResultMsg struct {
-	operation string // "push", "pull", "fetch", "commit"
+	operation string // "push", "pull", "fetch", "commit", "stage", ...
 	err       error
 }
 
@@ -491,35 +1381,106 @@ type branchesLoadedMsg struct {
 	branches []*git.Branch
 }
 
-// gitRepoChangedMsg is sent when the .git directory changes (external operations).
-type gitRepoChangedMsg struct{}
+// branchInfoLoadedMsg carries the current branch of repo for the action
+// bar.
+type branchInfoLoadedMsg struct {
+	repo   *git.Repository
+	branch *git.Branch
+	err    error
+}
 
+// gitRepoChangedMsg is sent when the .git directory of the repository
+// opened as the given watchSession changes (external operations).
+type gitRepoChangedMsg struct {
+	session int
+}
+
+// loadCommitsCmd reloads the graph, or the file history it is filtered
+// to, along with the branch info on the action bar.
 func (m Model) loadCommitsCmd() tea.Cmd {
+	return tea.Batch(m.loadGraphCmd(), m.loadBranchInfoCmd())
+}
+
+func (m Model) loadGraphCmd() tea.Cmd {
+	if m.historyPath != "" {
+		return m.loadFileHistoryCmd(m.historyPath)
+	}
+	limit := m.commitLimit()
 	return func() tea.Msg {
-		commits, err := m.repo.GetCommits(m.config.Performance.MaxCommits)
+		commits, err := m.repo.GetCommits(0, limit)
 		if err != nil {
 			return commitsLoadedMsg{err: err}
 		}
-		commits = m.prependUncommitted(commits)
-		return commitsLoadedMsg{commits: commits}
+		hasMore := m.hasMoreCommits(git.HistoryLen(commits), limit)
+		state, _ := m.repo.State() // best-effort
+		commits = m.prependUncommitted(commits, state)
+		return commitsLoadedMsg{commits: commits, hasMore: hasMore, state: state}
+	}
+}
+
+// commitLimit returns how many commits a full reload fetches: one page, or
+// all history paged in so far so a refresh doesn't drop it, capped by
+// max_commits.
+func (m Model) commitLimit() int {
+	perf := m.config.Performance
+	limit := perf.PageSize
+	if loaded := m.graphPanel.LoadedCount(); loaded > limit {
+		limit = loaded
+	}
+	if perf.MaxCommits > 0 && limit > perf.MaxCommits {
+		limit = perf.MaxCommits
 	}
+	return limit
+}
+
+// hasMoreCommits reports whether history may continue past a load that
+// returned n of the limit commits asked for.
+func (m Model) hasMoreCommits(n, limit int) bool {
+	maxCommits := m.config.Performance.MaxCommits
+	return n == limit && (maxCommits == 0 || limit < maxCommits)
 }
 
 func (m Model) handleCommitsLoaded(msg commitsLoadedMsg) (tea.Model, tea.Cmd) {
 	if msg.err != nil {
+		if msg.history != "" && msg.history == m.historyPath {
+			m.historyPath = ""
+			m.graphPanel.SetFileScope(nil)
+		}
 		m.actionBar.SetMessage("Failed to load commits: " + msg.err.Error())
 		return m, m.clearMessageAfter(3 * time.Second)
 	}
+	if msg.history != m.historyPath {
+		return m, nil // the filter changed while loading
+	}
+	if msg.history != "" && len(msg.commits) == 0 {
+		return m.showNoFileHistory(msg.history)
+	}
 	if m.ready && msg.commits != nil {
-		m.graphPanel.SetCommits(msg.commits)
-		m.updateBranchInfo()
+		m.graphPanel.SetFileScope(msg.scope)
+		m.graphPanel.SetCommits(msg.commits, msg.hasMore)
+		m.setState(msg.state)
+		// The index or working tree may have changed under an expanded
+		// "Uncommitted changes" entry.
+		return m, m.graphPanel.RefreshUncommitted(m.repo)
 	}
 	return m, nil
 }
 
 func (m Model) handleOperationResult(msg operationResultMsg) (tea.Model, tea.Cmd) {
-	if msg.err != nil {
-		m.actionBar.SetMessage(fmt.Sprintf("%s failed: %s", msg.operation, msg.err.Error()))
+	var gitErr *git.GitError
+	if errors.As(msg.err, &gitErr) {
+		m.lastGitErr = gitErr
+		text := fmt.Sprintf("%s failed: %s", msg.operation, gitErr.Summary())
+		if hint := errorKindHint(gitErr.Kind()); hint != "" {
+			text += " — " + hint
+		}
+		m.actionBar.SetMessage(text + " (o: details)")
+	} else if msg.err != nil {
+		text := fmt.Sprintf("%s failed: %s", msg.operation, msg.err.Error())
+		if errors.Is(msg.err, git.ErrNothingStaged) {
+			text += " (use space to stage files)"
+		}
+		m.actionBar.SetMessage(text)
 	} else {
 		switch msg.operation {
 		case "push":
@@ -530,21 +1491,65 @@ func (m Model) handleOperationResult(msg operationResultMsg) (tea.Model, tea.Cmd
 			m.actionBar.SetMessage("Fetch completed successfully")
 		case "commit":
 			m.actionBar.SetMessage("Commit created successfully")
+		case "amend":
+			m.actionBar.SetMessage("Commit amended")
+		case "reword":
+			m.actionBar.SetMessage("Commit reworded")
+		case "fixup! commit", "amend! commit", "squash! commit":
+			m.actionBar.SetMessage("Created " + msg.operation + " — ctrl+f to squash it in")
+		case "stage", "stage hunk", "stage line":
+			m.actionBar.SetMessage("Staged")
+		case "unstage", "unstage hunk", "unstage line":
+			m.actionBar.SetMessage("Unstaged")
 		case "checkout":
 			m.actionBar.SetMessage("Checked out successfully")
-			m.updateBranchInfo()
+		case "stash":
+			m.actionBar.SetMessage("Changes stashed")
+		case "stash apply":
+			m.actionBar.SetMessage("Stash applied")
+		case "stash pop":
+			m.actionBar.SetMessage("Stash applied and dropped")
+		case "stash drop":
+			m.actionBar.SetMessage("Stash dropped")
+		case "stash branch":
+			m.actionBar.SetMessage("Stash applied on a new branch")
 		default:
 			m.actionBar.SetMessage(msg.operation + " completed")
 		}
 	}
 
-	// Reload commits after any git operation (they may have changed).
+	// Reload commits after any git operation (they may have changed),
+	// and open the conflict panel if it stopped on conflicts.
 	return m, tea.Batch(
 		m.clearMessageAfter(3*time.Second),
 		m.loadCommitsCmd(),
+		m.conflictsCmd(msg.err),
 	)
 }
 
+// errorKindHint suggests a next step for a classified git failure.
+func errorKindHint(kind git.ErrorKind) string {
+	switch kind {
+	case git.ErrorKindNonFastForward:
+		return "remote has new commits, pull first"
+	case git.ErrorKindNoUpstream:
+		return "branch has no upstream"
+	case git.ErrorKindAuthFailed:
+		return "check your credentials"
+	case git.ErrorKindMergeConflict:
+		return "resolve the conflicts"
+	case git.ErrorKindLockHeld:
+		return "another git process holds the lock"
+	case git.ErrorKindHookRejected:
+		return "rejected by a hook"
+	case git.ErrorKindBranchNotMerged:
+		return "the branch has unmerged commits"
+	case git.ErrorKindWorktreeDirty:
+		return "the worktree has uncommitted changes"
+	}
+	return ""
+}
+
 func (m Model) clearMessageAfter(d time.Duration) tea.Cmd {
 	return tea.Tick(d, func(time.Time) tea.Msg {
 		return clearMessageMsg{}
@@ -553,7 +1558,10 @@ func (m Model) clearMessageAfter(d time.Duration) tea.Cmd {
 
 func (m Model) pushCmd() tea.Cmd {
 	return func() tea.Msg {
-		err := m.repo.Push()
+		opts, err := m.push()
+		if rejected := m.rejectedPush(opts, err); rejected != nil {
+			return rejected
+		}
 		return operationResultMsg{operation: "push", err: err}
 	}
 }
@@ -584,22 +1592,33 @@ func (m Model) commitCmd(message string) tea.Cmd {
 // is detected (refs, HEAD, index), it returns a gitRepoChangedMsg after
 // a short debounce to coalesce rapid successive events.
 func (m Model) watchGitDirCmd() tea.Cmd {
-	repoPath := m.repo.Path()
+	repo, session := m.repo, m.watchSession
 	return func() tea.Msg {
+		// In a linked worktree .git is a file: HEAD and the index live in
+		// the worktree's own git dir, refs in the common one.
+		gitDir, err := repo.GitDir()
+		if err != nil {
+			return nil // silently degrade — no auto-refresh
+		}
+		commonDir, err := repo.CommonDir()
+		if err != nil {
+			return nil
+		}
+
 		watcher, err := fsnotify.NewWatcher()
 		if err != nil {
 			return nil // silently degrade — no auto-refresh
 		}
 		defer watcher.Close()
 
-		gitDir := filepath.Join(repoPath, ".git")
 		// Watch key subdirectories that change on git operations.
 		dirs := []string{
 			gitDir,
-			filepath.Join(gitDir, "refs"),
-			filepath.Join(gitDir, "refs", "heads"),
-			filepath.Join(gitDir, "refs", "tags"),
-			filepath.Join(gitDir, "refs", "remotes"),
+			commonDir,
+			filepath.Join(commonDir, "refs"),
+			filepath.Join(commonDir, "refs", "heads"),
+			filepath.Join(commonDir, "refs", "tags"),
+			filepath.Join(commonDir, "refs", "remotes"),
 		}
 		for _, d := range dirs {
 			watcher.Add(d) // ignore errors for dirs that may not exist
@@ -632,7 +1651,7 @@ func (m Model) watchGitDirCmd() tea.Cmd {
 
 			case <-timer.C:
 				// Debounce period elapsed — signal reload.
-				return gitRepoChangedMsg{}
+				return gitRepoChangedMsg{session: session}
 			}
 		}
 	}
@@ -0,0 +1,109 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// maxOverwrittenShown caps the remote commits listed before a force push.
+const maxOverwrittenShown = 10
+
+// pushRejectedMsg is sent when a push of opts is rejected because the
+// remote branch has commits the local one lacks. lost are those commits,
+// fetched just now, and tip is the remote branch's commit.
+type pushRejectedMsg struct {
+	opts git.PushOptions
+	tip  string
+	lost []*git.Commit
+	err  error
+}
+
+// rejectedPush returns a pushRejectedMsg if err is a non-fast-forward
+// rejection of a push of opts, or nil. A push to the upstream (no Remote)
+// is resolved to the current branch's upstream first.
+func (m Model) rejectedPush(opts git.PushOptions, err error) tea.Msg {
+	if !git.IsErrorKind(err, git.ErrorKindNonFastForward) {
+		return nil
+	}
+	if opts.Remote == "" {
+		branch, berr := m.repo.CurrentBranch()
+		if berr != nil || branch.Upstream == "" {
+			return nil
+		}
+		opts = git.PushOptions{
+			Remote:       branch.UpstreamRemote,
+			Branch:       branch.Name,
+			RemoteBranch: branch.UpstreamBranch(),
+		}
+	}
+	tip, lost, ferr := m.repo.PushOverwrites(opts)
+	if ferr != nil {
+		return nil // report the rejection itself
+	}
+	return pushRejectedMsg{opts: opts, tip: tip, lost: lost, err: err}
+}
+
+// handlePushRejected lists the remote commits a force push would
+// overwrite and asks to go ahead. With push_force_with_lease the force
+// push only overwrites the remote branch if it is still at the commit
+// shown.
+func (m Model) handlePushRejected(msg pushRejectedMsg) (tea.Model, tea.Cmd) {
+	var gitErr *git.GitError
+	if errors.As(msg.err, &gitErr) {
+		m.lastGitErr = gitErr
+	}
+	m.actionBar.ClearMessage()
+
+	opts := msg.opts
+	if m.config.Git.PushForceWithLease {
+		opts.ForceWithLease, opts.Expect = true, msg.tip
+	} else {
+		opts.Force = true
+	}
+	target := opts.Remote + "/" + remoteBranchName(opts)
+
+	var lines []string
+	if len(msg.lost) == 0 {
+		lines = append(lines, "No commits on "+target+" would be lost.")
+	} else {
+		lines = append(lines, "These commits on "+target+" would be overwritten:")
+		for i, c := range msg.lost {
+			if i == maxOverwrittenShown {
+				lines = append(lines, fmt.Sprintf("  ... and %s more", pluralize(len(msg.lost)-i, "commit")))
+				break
+			}
+			lines = append(lines, "  "+c.ShortHash+" "+c.Subject)
+		}
+	}
+	if opts.ForceWithLease {
+		lines = append(lines, "The push is refused if "+target+" has moved from "+shortHash(msg.tip)+" since.")
+	}
+
+	m.pendingPush = opts
+	m.confirmModal.Show(fmt.Sprintf("Push rejected: %s has commits %s lacks. Force push?", target, opts.Branch), lines)
+	m.confirmPurpose = confirmForcePush
+	m.recalcGraphSize()
+	// The fetch moved the remote-tracking branch.
+	return m, m.loadCommitsCmd()
+}
+
+// forcePush pushes the force push confirmed after a rejection.
+func (m Model) forcePush() (tea.Model, tea.Cmd) {
+	opts := m.pendingPush
+	m.pendingPush = git.PushOptions{}
+	detail := opts.Branch + " to " + opts.Remote + "/" + remoteBranchName(opts)
+	m.actionBar.SetMessage("Force pushing " + detail + "...")
+	return m, func() tea.Msg {
+		return remoteResultMsg{operation: "force push", remote: opts.Remote, detail: detail, err: m.repo.Push(opts)}
+	}
+}
+
+func remoteBranchName(opts git.PushOptions) string {
+	if opts.RemoteBranch != "" {
+		return opts.RemoteBranch
+	}
+	return opts.Branch
+}
@@ -0,0 +1,202 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// rebaseCommitsLoadedMsg carries the commits an interactive rebase from the
+// selected commit would rewrite, oldest first.
+type rebaseCommitsLoadedMsg struct {
+	commits []*git.Commit
+	err     error
+}
+
+// rebaseResultMsg is sent when a rebase command (start, continue, skip,
+// abort) returns. progress is non-nil if the rebase stopped partway.
+type rebaseResultMsg struct {
+	operation string
+	err       error
+	progress  *git.RebaseProgress
+}
+
+func (m Model) loadRebaseCommitsCmd(hash string) tea.Cmd {
+	return func() tea.Msg {
+		commits, err := m.repo.RebaseCommits(hash)
+		return rebaseCommitsLoadedMsg{commits: commits, err: err}
+	}
+}
+
+func (m Model) handleRebaseCommitsLoaded(msg rebaseCommitsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "rebase", err: msg.err})
+	}
+	if len(msg.commits) == 0 {
+		m.actionBar.SetMessage("Nothing to rebase")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	m.rebaseModal.Show(msg.commits)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleRebaseModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "i":
+		m.rebaseModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.rebaseModal.MoveDown()
+	case "k", "up":
+		m.rebaseModal.MoveUp()
+	case "J", "shift+down":
+		m.rebaseModal.MoveItemDown()
+	case "K", "shift+up":
+		m.rebaseModal.MoveItemUp()
+	case "p":
+		m.rebaseModal.SetAction(git.RebasePick)
+	case "e":
+		m.rebaseModal.SetAction(git.RebaseEdit)
+	case "s":
+		m.rebaseModal.SetAction(git.RebaseSquash)
+	case "f":
+		m.rebaseModal.SetAction(git.RebaseFixup)
+	case "d":
+		m.rebaseModal.SetAction(git.RebaseDrop)
+	case "r":
+		item := m.rebaseModal.SelectedItem()
+		if item == nil {
+			return m, nil
+		}
+		subject := item.Commit.Subject
+		if item.Action == git.RebaseReword && item.Message != "" {
+			subject = strings.SplitN(item.Message, "\n", 2)[0]
+		}
+		m.inputModal.Show("Reword:", subject)
+		m.inputPurpose = inputRebaseReword
+		m.recalcGraphSize()
+	case "enter":
+		base := m.rebaseModal.Base()
+		todo := m.rebaseModal.Todo()
+		m.rebaseModal.Hide()
+		m.recalcGraphSize()
+		if base == nil {
+			return m, nil
+		}
+		m.actionBar.SetMessage("Rebasing...")
+		return m, m.rebaseCmd(base.Hash, todo)
+	}
+	return m, nil
+}
+
+func (m Model) rebaseCmd(hash string, todo []git.RebaseTodoItem) tea.Cmd {
+	return m.rebaseStepCmd("rebase", func() error {
+		return m.repo.InteractiveRebase(hash, todo)
+	})
+}
+
+// rebaseStepCmd runs a rebase command and reports where the rebase stands
+// afterwards, so stops for edits and conflicts can be told apart from
+// failures.
+func (m Model) rebaseStepCmd(operation string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		err := run()
+		progress, _ := m.repo.RebaseProgress() // best-effort
+		return rebaseResultMsg{operation: operation, err: err, progress: progress}
+	}
+}
+
+// handleRebaseStep runs continue, skip or abort on the in-progress rebase.
+func (m Model) handleRebaseStep(step string) (tea.Model, tea.Cmd) {
+	if m.state.Rebase == nil {
+		m.actionBar.SetMessage("No rebase, cherry-pick, revert, merge or bisect in progress")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+
+	var run func() error
+	switch step {
+	case "continue":
+		m.actionBar.SetMessage("Continuing rebase...")
+		run = m.repo.RebaseContinue
+	case "skip":
+		m.actionBar.SetMessage("Skipping commit...")
+		run = m.repo.RebaseSkip
+	case "abort":
+		m.actionBar.SetMessage("Aborting rebase...")
+		run = m.repo.RebaseAbort
+	}
+	return m, m.rebaseStepCmd("rebase "+step, run)
+}
+
+func (m Model) handleRebaseResult(msg rebaseResultMsg) (tea.Model, tea.Cmd) {
+	m.setRebaseProgress(msg.progress)
+
+	p := msg.progress
+	if p == nil {
+		if msg.err != nil {
+			return m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		}
+		text := "Rebase completed"
+		switch msg.operation {
+		case "rebase abort":
+			text = "Rebase aborted"
+		case "autosquash":
+			text = "Fixups squashed"
+		}
+		m.actionBar.SetMessage(text)
+		return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd())
+	}
+
+	// Still rebasing: the rebase stopped for an edit, on conflicts, or
+	// because a step failed (e.g. a hook rejected a reworded message).
+	var gitErr *git.GitError
+	if errors.As(msg.err, &gitErr) {
+		m.lastGitErr = gitErr
+	}
+	stopped := shortHash(p.StoppedAt)
+	switch {
+	case p.Conflicts:
+		m.actionBar.SetMessage(fmt.Sprintf("Conflicts applying %s — resolve and stage them, then alt+c to continue", stopped))
+	case msg.err != nil:
+		text := fmt.Sprintf("%s stopped: %s", msg.operation, errorSummary(msg.err))
+		if gitErr != nil {
+			text += " (o: details)"
+		}
+		m.actionBar.SetMessage(text)
+	case stopped != "":
+		m.actionBar.SetMessage(fmt.Sprintf("Stopped at %s for editing — amend it, then alt+c to continue", stopped))
+	default:
+		m.actionBar.SetMessage("Rebase paused — alt+c to continue, alt+a to abort")
+	}
+	return m, tea.Batch(m.clearMessageAfter(5*time.Second), m.loadCommitsCmd(), m.conflictsCmd(msg.err))
+}
+
+// setRebaseProgress records the in-progress rebase and shows it as a
+// persistent action bar status.
+func (m *Model) setRebaseProgress(p *git.RebaseProgress) {
+	m.state.Rebase = p
+	m.updateStatus()
+}
+
+// shortHash abbreviates a full commit hash for messages.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// errorSummary returns the most useful one-line description of err.
+func errorSummary(err error) string {
+	var gitErr *git.GitError
+	if errors.As(err, &gitErr) {
+		return gitErr.Summary()
+	}
+	return err.Error()
+}
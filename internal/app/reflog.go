@@ -0,0 +1,138 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// reflogLimit caps how many entries the reflog panel loads.
+const reflogLimit = 200
+
+// reflogLoadedMsg carries the reflog of ref for the reflog panel.
+type reflogLoadedMsg struct {
+	ref     string
+	entries []git.ReflogEntry
+	err     error
+}
+
+// undoResultMsg is sent when an undo or redo returns, with the step it
+// took.
+type undoResultMsg struct {
+	step git.UndoStep
+	redo bool
+	err  error
+}
+
+func (m Model) loadReflogCmd(ref string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := m.repo.Reflog(ref, reflogLimit)
+		return reflogLoadedMsg{ref: ref, entries: entries, err: err}
+	}
+}
+
+func (m Model) handleReflogLoaded(msg reflogLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "reflog", err: msg.err})
+	}
+	m.reflogModal.Show(msg.ref, msg.entries)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleReflogModal checks out or resets to the selected entry's commit.
+// Both close the panel, whose entries they make stale.
+func (m Model) handleReflogModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "L":
+		m.reflogModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.reflogModal.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.reflogModal.MoveUp()
+		return m, nil
+	}
+
+	entry := m.reflogModal.SelectedEntry()
+	if entry == nil {
+		return m, nil
+	}
+	hash := entry.New
+
+	switch msg.String() {
+	case "enter":
+		m.reflogModal.Hide()
+		m.recalcGraphSize()
+		m.actionBar.SetMessage("Checking out " + shortHash(hash) + "...")
+		return m, m.checkoutCmd(hash)
+	case "X":
+		m.reflogModal.Hide()
+		return m.showResetMenu(hash)
+	}
+	return m, nil
+}
+
+// handleUndo reverses the latest operation lazygit-lite ran, or with redo
+// the latest undo. Nothing is asked first: the opposite key takes it back.
+func (m Model) handleUndo(redo bool) (tea.Model, tea.Cmd) {
+	if cmd, ok := m.checkNothingInProgress(); !ok {
+		return m, cmd
+	}
+	if redo {
+		m.actionBar.SetMessage("Redoing...")
+	} else {
+		m.actionBar.SetMessage("Undoing...")
+	}
+	return m, func() tea.Msg {
+		plan := m.repo.UndoPlan
+		if redo {
+			plan = m.repo.RedoPlan
+		}
+		step, err := plan()
+		if err == nil {
+			err = m.repo.ApplyUndo(step)
+		}
+		return undoResultMsg{step: step, redo: redo, err: err}
+	}
+}
+
+// handleUndoResult says what was undone or redone, and where HEAD went.
+func (m Model) handleUndoResult(msg undoResultMsg) (tea.Model, tea.Cmd) {
+	operation := "undo"
+	if msg.redo {
+		operation = "redo"
+	}
+	switch msg.err {
+	case git.ErrNothingToUndo:
+		m.actionBar.SetMessage("Nothing to undo")
+		return m, m.clearMessageAfter(3 * time.Second)
+	case git.ErrNothingToRedo:
+		m.actionBar.SetMessage("Nothing to redo (only right after an undo)")
+		return m, m.clearMessageAfter(3 * time.Second)
+	case git.ErrUndoExternal:
+		m.actionBar.SetMessage("Nothing to undo: HEAD was last moved outside lazygit-lite (L to browse the reflog)")
+		return m, m.clearMessageAfter(5 * time.Second)
+	}
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: operation, err: msg.err})
+	}
+
+	where := "HEAD is at " + shortHash(msg.step.Reset)
+	if msg.step.Checkout != "" {
+		where = "checked out " + msg.step.Checkout
+	} else if msg.step.Soft && !msg.redo {
+		where += ", its changes are staged"
+	}
+	if msg.redo {
+		m.actionBar.SetMessage(fmt.Sprintf("Redid the last undo — %s (z to undo)", where))
+	} else {
+		e := msg.step.Entry
+		m.actionBar.SetMessage(fmt.Sprintf("Undid %s: %s — %s (Z to redo)", e.Action, e.Message, where))
+	}
+	return m, tea.Batch(m.clearMessageAfter(5*time.Second), m.loadCommitsCmd())
+}
@@ -0,0 +1,217 @@
+package app
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// remotesLoadedMsg carries the remote list for the remote panel.
+type remotesLoadedMsg struct {
+	remotes []git.Remote
+	err     error
+}
+
+// remoteResultMsg is sent when a remote change, fetch or push returns.
+type remoteResultMsg struct {
+	operation string // "add remote", "rename remote", "fetch remote", "push", ...
+	remote    string
+	detail    string // what was pushed, for the success message
+	err       error
+}
+
+func (m Model) loadRemotesCmd() tea.Cmd {
+	return func() tea.Msg {
+		remotes, err := m.repo.GetRemotes()
+		return remotesLoadedMsg{remotes: remotes, err: err}
+	}
+}
+
+func (m Model) handleRemotesLoaded(msg remotesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "remote list", err: msg.err})
+	}
+	m.remoteModal.Show(msg.remotes)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleRemoteModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "M":
+		m.remoteModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.remoteModal.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.remoteModal.MoveUp()
+		return m, nil
+	case "a":
+		m.inputModal.Show("New remote name:", "")
+		m.inputPurpose = inputRemoteName
+		m.recalcGraphSize()
+		return m, nil
+	}
+
+	remote := m.remoteModal.SelectedRemote()
+	if remote == nil {
+		return m, nil
+	}
+	name := remote.Name
+
+	switch msg.String() {
+	case "f", "F":
+		prune := msg.String() == "F"
+		m.actionBar.SetMessage("Fetching " + name + "...")
+		return m, m.remoteCmd("fetch remote", name, func() error {
+			return m.repo.FetchRemote(name, prune)
+		})
+	case "p":
+		return m.handlePushTo(name)
+	case "r":
+		m.inputModal.Show("Rename remote "+name+" to:", name)
+		m.inputPurpose = inputRenameRemote
+		m.inputTarget = name
+		m.recalcGraphSize()
+	case "e":
+		m.inputModal.Show("URL of "+name+":", remote.FetchURL)
+		m.inputPurpose = inputRemoteEditURL
+		m.inputTarget = name
+		m.recalcGraphSize()
+	case "d":
+		m.confirmModal.Show("Remove remote "+name+"?", []string{
+			"Its remote-tracking branches and the upstreams that use it are removed too.",
+			"Nothing is deleted on the remote itself.",
+		})
+		m.confirmPurpose = confirmRemoveRemote
+		m.confirmTarget = name
+		m.recalcGraphSize()
+	}
+	return m, nil
+}
+
+// pushToKinds are the push-to menu choices, in menu order.
+var pushToKinds = []git.PushOptions{
+	{},
+	{Tags: true},
+	{NoVerify: true},
+}
+
+// handlePushTo asks how to push the current branch to remote; the remote
+// branch is prompted for next. A branch without an upstream gets the
+// pushed branch as its upstream.
+func (m Model) handlePushTo(remote string) (tea.Model, tea.Cmd) {
+	branch, err := m.repo.CurrentBranch()
+	if err != nil {
+		m.actionBar.SetMessage("push failed: " + err.Error())
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	// RemoteBranch holds the prompt's default until pushTo sets it:
+	// the upstream when that is on the same remote.
+	target := branch.Name
+	if branch.UpstreamRemote == remote {
+		target = branch.UpstreamBranch()
+	}
+	m.pendingPush = git.PushOptions{
+		Remote:       remote,
+		Branch:       branch.Name,
+		RemoteBranch: target,
+		SetUpstream:  branch.Upstream == "",
+	}
+	m.menuModal.Show("Push "+branch.Name+" to "+remote, []modals.MenuItem{
+		{Key: "p", Label: "push", Description: "the branch only"},
+		{Key: "t", Label: "with tags", Description: "also push all tags (--tags)"},
+		{Key: "n", Label: "skip hooks", Description: "don't run the pre-push hook (--no-verify)"},
+	})
+	m.menuPurpose = menuPushTo
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handlePushToKind prompts for the remote branch to push to.
+func (m Model) handlePushToKind(kind git.PushOptions) (tea.Model, tea.Cmd) {
+	m.pendingPush.Tags = kind.Tags
+	m.pendingPush.NoVerify = kind.NoVerify
+	opts := m.pendingPush
+	m.inputModal.Show("Push "+opts.Branch+" to "+opts.Remote+" as:", opts.RemoteBranch)
+	m.inputPurpose = inputPushBranch
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) pushTo(remoteBranch string) (tea.Model, tea.Cmd) {
+	opts := m.pendingPush
+	m.pendingPush = git.PushOptions{}
+	opts.RemoteBranch = remoteBranch
+	detail := opts.Branch + " to " + opts.Remote + "/" + remoteBranch
+	m.actionBar.SetMessage("Pushing " + detail + "...")
+	return m, func() tea.Msg {
+		err := m.repo.Push(opts)
+		if rejected := m.rejectedPush(opts, err); rejected != nil {
+			return rejected
+		}
+		return remoteResultMsg{operation: "push", remote: opts.Remote, detail: detail, err: err}
+	}
+}
+
+func (m Model) remoteCmd(operation, remote string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return remoteResultMsg{operation: operation, remote: remote, err: run()}
+	}
+}
+
+// handleRemoteResult reports a remote change and refreshes the graph and,
+// if it is open, the remote panel.
+func (m Model) handleRemoteResult(msg remoteResultMsg) (tea.Model, tea.Cmd) {
+	var reload tea.Cmd
+	if m.remoteModal.IsVisible() {
+		reload = m.loadRemotesCmd()
+	}
+	if msg.err != nil {
+		model, cmd := m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		return model, tea.Batch(cmd, reload)
+	}
+
+	switch msg.operation {
+	case "add remote":
+		m.actionBar.SetMessage("Added remote " + msg.remote)
+	case "rename remote":
+		m.actionBar.SetMessage("Renamed remote to " + msg.remote)
+	case "set remote url":
+		m.actionBar.SetMessage("URL of " + msg.remote + " updated")
+	case "remove remote":
+		m.actionBar.SetMessage("Removed remote " + msg.remote)
+	case "fetch remote":
+		m.actionBar.SetMessage("Fetched " + msg.remote)
+	case "push":
+		m.actionBar.SetMessage("Pushed " + msg.detail)
+	case "force push":
+		m.actionBar.SetMessage("Force pushed " + msg.detail)
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd(), reload)
+}
+
+// push pushes the current branch and returns the options it pushed with.
+// A branch without an upstream is pushed to the default remote under the
+// same name and starts tracking it.
+func (m Model) push() (git.PushOptions, error) {
+	var opts git.PushOptions
+	branch, err := m.repo.CurrentBranch()
+	if errors.Is(err, git.ErrDetachedHead) || (err == nil && branch.Upstream != "") {
+		return opts, m.repo.Push(opts)
+	}
+	if err != nil {
+		return opts, err
+	}
+	remote, err := m.repo.DefaultRemote()
+	if err != nil {
+		return opts, err
+	}
+	opts = git.PushOptions{Remote: remote, Branch: branch.Name, SetUpstream: true}
+	return opts, m.repo.Push(opts)
+}
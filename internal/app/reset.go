@@ -0,0 +1,112 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// resetModes are the reset menu choices, in menu order.
+var resetModes = []git.ResetMode{git.ResetSoft, git.ResetMixed, git.ResetHard}
+
+// resetFilesLoadedMsg carries the uncommitted files a hard reset to hash
+// would discard, for the confirmation prompt.
+type resetFilesLoadedMsg struct {
+	hash  string
+	files []git.ChangedFile
+	err   error
+}
+
+// resetResultMsg is sent when a reset returns. previous is the HEAD it
+// moved away from.
+type resetResultMsg struct {
+	hash     string
+	mode     git.ResetMode
+	previous string
+	err      error
+}
+
+// handleReset opens the reset mode menu for the selected commit.
+func (m Model) handleReset() (tea.Model, tea.Cmd) {
+	commit := m.graphPanel.SelectedCommit()
+	if commit == nil || commit.Hash == git.UncommittedHash {
+		m.actionBar.SetMessage("Select a commit to reset to")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	return m.showResetMenu(commit.Hash)
+}
+
+// showResetMenu opens the reset mode menu for hash.
+func (m Model) showResetMenu(hash string) (tea.Model, tea.Cmd) {
+	m.menuModal.Show("Reset HEAD to "+shortHash(hash), []modals.MenuItem{
+		{Key: "s", Label: "soft", Description: "keep the changes staged"},
+		{Key: "m", Label: "mixed", Description: "keep the changes, unstaged"},
+		{Key: "h", Label: "hard", Description: "discard all uncommitted changes"},
+	})
+	m.menuPurpose = menuReset
+	m.menuTarget = hash
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleResetChoice runs a soft or mixed reset straight away; a hard reset
+// first lists the files it would discard for confirmation.
+func (m Model) handleResetChoice(hash string, mode git.ResetMode) (tea.Model, tea.Cmd) {
+	if mode == git.ResetHard {
+		return m, m.loadResetFilesCmd(hash)
+	}
+	m.actionBar.SetMessage("Resetting...")
+	return m, m.resetCmd(hash, mode)
+}
+
+func (m Model) loadResetFilesCmd(hash string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := m.repo.GetWorkingTreeFiles()
+		return resetFilesLoadedMsg{hash: hash, files: files, err: err}
+	}
+}
+
+func (m Model) handleResetFilesLoaded(msg resetFilesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "reset", err: msg.err})
+	}
+
+	// reset --hard leaves untracked files alone.
+	var lines []string
+	for _, f := range msg.files {
+		if f.Status != "?" {
+			lines = append(lines, f.Status+" "+f.Path)
+		}
+	}
+	title := fmt.Sprintf("Hard reset to %s? These uncommitted changes will be lost:", shortHash(msg.hash))
+	if len(lines) == 0 {
+		title = fmt.Sprintf("Hard reset to %s?", shortHash(msg.hash))
+		lines = []string{"No uncommitted changes will be lost."}
+	}
+	m.confirmModal.Show(title, lines)
+	m.confirmPurpose = confirmHardReset
+	m.confirmTarget = msg.hash
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) resetCmd(hash string, mode git.ResetMode) tea.Cmd {
+	return func() tea.Msg {
+		previous, err := m.repo.Reset(hash, mode)
+		return resetResultMsg{hash: hash, mode: mode, previous: previous, err: err}
+	}
+}
+
+// handleResetResult reports the previous HEAD, which is all that's needed
+// to undo the reset.
+func (m Model) handleResetResult(msg resetResultMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "reset", err: msg.err})
+	}
+	m.actionBar.SetMessage(fmt.Sprintf("Reset (%s) to %s — previous HEAD was %s (z to undo)",
+		msg.mode, shortHash(msg.hash), shortHash(msg.previous)))
+	return m, tea.Batch(m.clearMessageAfter(10*time.Second), m.loadCommitsCmd())
+}
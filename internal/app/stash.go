@@ -0,0 +1,143 @@
+package app
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// stashesLoadedMsg carries the stash list for the stash panel.
+type stashesLoadedMsg struct {
+	stashes []git.Stash
+	err     error
+}
+
+// stashShownMsg carries the patch of a stash for the output panel.
+type stashShownMsg struct {
+	name  string
+	patch string
+	err   error
+}
+
+// stashPushOptions are the stash menu choices, in menu order.
+var stashPushOptions = []git.StashOptions{
+	{},
+	{IncludeUntracked: true},
+	{KeepIndex: true},
+}
+
+// handleStashMenu asks what to stash; the message is prompted for next.
+func (m Model) handleStashMenu() (tea.Model, tea.Cmd) {
+	m.menuModal.Show("Stash changes", []modals.MenuItem{
+		{Key: "s", Label: "tracked changes", Description: "staged and unstaged changes to tracked files"},
+		{Key: "u", Label: "including untracked", Description: "also stash and remove untracked files"},
+		{Key: "k", Label: "keep index", Description: "stash everything but leave staged changes in place"},
+	})
+	m.menuPurpose = menuStash
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleStashChoice prompts for an optional message for the chosen kind of
+// stash.
+func (m Model) handleStashChoice(opts git.StashOptions) (tea.Model, tea.Cmd) {
+	m.pendingStash = opts
+	m.inputModal.Show("Stash message:", "")
+	m.inputModal.SetPlaceholder("optional")
+	m.inputPurpose = inputStashMessage
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) stashPushCmd(opts git.StashOptions) tea.Cmd {
+	return func() tea.Msg {
+		err := m.repo.StashPush(opts)
+		return operationResultMsg{operation: "stash", err: err}
+	}
+}
+
+func (m Model) loadStashesCmd() tea.Cmd {
+	return func() tea.Msg {
+		stashes, err := m.repo.GetStashes()
+		return stashesLoadedMsg{stashes: stashes, err: err}
+	}
+}
+
+func (m Model) handleStashesLoaded(msg stashesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "stash list", err: msg.err})
+	}
+	m.stashModal.Show(msg.stashes)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleStashModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "S":
+		m.stashModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.stashModal.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.stashModal.MoveUp()
+		return m, nil
+	}
+
+	stash := m.stashModal.SelectedStash()
+	if stash == nil {
+		return m, nil
+	}
+	name := stash.Name
+
+	switch msg.String() {
+	case "enter":
+		return m, func() tea.Msg {
+			patch, err := m.repo.StashShow(name)
+			return stashShownMsg{name: name, patch: patch, err: err}
+		}
+	case "a":
+		m.hideStashes()
+		m.actionBar.SetMessage("Applying " + name + "...")
+		return m, m.stashOpCmd("stash apply", func() error { return m.repo.StashApply(name) })
+	case "p":
+		m.hideStashes()
+		m.actionBar.SetMessage("Popping " + name + "...")
+		return m, m.stashOpCmd("stash pop", func() error { return m.repo.StashPop(name) })
+	case "d":
+		m.hideStashes()
+		m.confirmModal.Show("Drop "+name+"? Its changes will be lost:", []string{stash.Message})
+		m.confirmPurpose = confirmStashDrop
+		m.confirmTarget = name
+		m.recalcGraphSize()
+	case "b":
+		m.inputModal.Show("New branch from "+name+":", "")
+		m.inputPurpose = inputStashBranch
+		m.recalcGraphSize()
+	}
+	return m, nil
+}
+
+func (m *Model) hideStashes() {
+	m.stashModal.Hide()
+	m.recalcGraphSize()
+}
+
+func (m Model) stashOpCmd(operation string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return operationResultMsg{operation: operation, err: run()}
+	}
+}
+
+// handleStashShown replaces the stash list with the stash's patch.
+func (m Model) handleStashShown(msg stashShownMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "stash show", err: msg.err})
+	}
+	m.hideStashes()
+	m.outputModal.Show("git stash show -p "+msg.name, msg.patch)
+	m.recalcGraphSize()
+	return m, nil
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// setState records the operation in progress and shows it as a persistent
+// action bar status.
+func (m *Model) setState(s git.RepoState) {
+	m.state = s
+	m.graphPanel.SetBisect(s.Bisect)
+	m.updateStatus()
+}
+
+// updateStatus shows the operation in progress, with its progress and the
+// steps it offers, as a persistent action bar status. Unmerged paths left
+// by anything else, such as a stash apply, are shown too.
+func (m *Model) updateStatus() {
+	verb, detail := describeState(m.state)
+	if verb == "" && detail == "" {
+		m.actionBar.SetStatus("")
+		return
+	}
+	status := strings.ToUpper(verb)
+	if detail != "" {
+		if status != "" {
+			status += " "
+		}
+		status += detail
+	}
+	if m.state.Conflicts > 0 {
+		status += " · x resolve"
+	}
+	switch m.state.Operation() {
+	case "rebase", "cherry-pick", "revert":
+		status += " · alt+c continue · alt+s skip · alt+a abort"
+	case "merge":
+		status += " · alt+c continue · alt+a abort"
+	case "bisect":
+		status += " · B mark · alt+s skip · alt+a reset"
+	}
+	m.actionBar.SetStatus(status)
+}
+
+// stateLabel describes the operation in progress and its progress, e.g.
+// "rebasing main 2/5 (1 conflict)", or "" if there is nothing to show.
+func stateLabel(s git.RepoState) string {
+	verb, detail := describeState(s)
+	if verb != "" && detail != "" {
+		return verb + " " + detail
+	}
+	return verb + detail
+}
+
+// describeState splits the description of the operation in progress into
+// its verb ("rebasing") and the progress and conflicts that follow it.
+func describeState(s git.RepoState) (verb, detail string) {
+	switch s.Operation() {
+	case "rebase":
+		verb = "rebasing"
+		if s.Rebase.HeadName != "" {
+			detail = s.Rebase.HeadName
+		}
+		if s.Rebase.Total > 0 {
+			detail = strings.TrimSpace(fmt.Sprintf("%s %d/%d", detail, s.Rebase.Step, s.Rebase.Total))
+		}
+	case "cherry-pick":
+		verb, detail = "cherry-picking", fmt.Sprintf("%d left", s.Remaining)
+	case "revert":
+		verb, detail = "reverting", fmt.Sprintf("%d left", s.Remaining)
+	case "merge":
+		verb = "merging"
+	case "bisect":
+		verb, detail = "bisecting", bisectProgress(s.Bisect)
+	}
+	if s.Conflicts > 0 {
+		conflicts := pluralize(s.Conflicts, "conflict")
+		if verb != "" {
+			conflicts = "(" + conflicts + ")"
+		}
+		detail = strings.TrimSpace(detail + " " + conflicts)
+	}
+	return verb, detail
+}
+
+// bisectProgress describes how far a bisect has got: which mark it still
+// needs, roughly how many steps are left, or the first bad commit.
+func bisectProgress(b *git.BisectStatus) string {
+	switch {
+	case b.FirstBad != "":
+		return "found " + shortHash(b.FirstBad)
+	case b.Bad == "" && len(b.Good) == 0:
+		return ""
+	case b.Bad == "":
+		return "needs a bad commit"
+	case len(b.Good) == 0:
+		return "needs a good commit"
+	case b.Steps >= 0:
+		return "about " + pluralize(b.Steps, "step") + " left"
+	}
+	return ""
+}
+
+// pluralize formats a count with a noun that takes a plain "s" plural.
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
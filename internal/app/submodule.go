@@ -0,0 +1,166 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// submodulesLoadedMsg carries the submodule list for the submodule panel.
+type submodulesLoadedMsg struct {
+	submodules []git.Submodule
+	err        error
+}
+
+// submoduleResultMsg is sent when a submodule init, update or sync returns.
+type submoduleResultMsg struct {
+	operation string // "init submodule", "update submodule", "sync submodule"
+	path      string
+	err       error
+}
+
+// parentRepo is a repository the app left to open one of its submodules,
+// returned to with backspace.
+type parentRepo struct {
+	repo *git.Repository
+	path string // the submodule's path in repo
+}
+
+func (m Model) loadSubmodulesCmd() tea.Cmd {
+	return func() tea.Msg {
+		submodules, err := m.repo.Submodules()
+		return submodulesLoadedMsg{submodules: submodules, err: err}
+	}
+}
+
+func (m Model) handleSubmodulesLoaded(msg submodulesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "submodule list", err: msg.err})
+	}
+	if len(msg.submodules) == 0 {
+		m.submoduleModal.Hide()
+		m.recalcGraphSize()
+		m.actionBar.SetMessage("No submodules in this repository")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	m.submoduleModal.Show(msg.submodules)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleSubmoduleModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "O":
+		m.submoduleModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.submoduleModal.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.submoduleModal.MoveUp()
+		return m, nil
+	}
+
+	sm := m.submoduleModal.SelectedSubmodule()
+	if sm == nil {
+		return m, nil
+	}
+	path := sm.Path
+
+	switch msg.String() {
+	case "enter":
+		if !sm.Initialized {
+			m.actionBar.SetMessage(path + " is not checked out (u to update it)")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		return m.enterSubmodule(path)
+	case "u":
+		m.actionBar.SetMessage("Updating submodule " + path + "...")
+		return m, m.submoduleCmd("update submodule", path, func() error {
+			return m.repo.UpdateSubmodule(path)
+		})
+	case "i":
+		m.actionBar.SetMessage("Initializing submodule " + path + "...")
+		return m, m.submoduleCmd("init submodule", path, func() error {
+			return m.repo.InitSubmodule(path)
+		})
+	case "s":
+		m.actionBar.SetMessage("Syncing submodule " + path + "...")
+		return m, m.submoduleCmd("sync submodule", path, func() error {
+			return m.repo.SyncSubmodule(path)
+		})
+	}
+	return m, nil
+}
+
+func (m Model) submoduleCmd(operation, path string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return submoduleResultMsg{operation: operation, path: path, err: run()}
+	}
+}
+
+// handleSubmoduleResult reports a submodule command and refreshes the
+// panel and the graph, whose uncommitted entry shows checkouts that differ
+// from the recorded commits.
+func (m Model) handleSubmoduleResult(msg submoduleResultMsg) (tea.Model, tea.Cmd) {
+	var reload tea.Cmd
+	if m.submoduleModal.IsVisible() {
+		reload = m.loadSubmodulesCmd()
+	}
+	if msg.err != nil {
+		model, cmd := m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		return model, tea.Batch(cmd, reload)
+	}
+
+	switch msg.operation {
+	case "update submodule":
+		m.actionBar.SetMessage("Updated submodule " + msg.path)
+	case "init submodule":
+		m.actionBar.SetMessage("Initialized submodule " + msg.path + " (u to check it out)")
+	case "sync submodule":
+		m.actionBar.SetMessage("Synced the URL of submodule " + msg.path)
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd(), reload)
+}
+
+// enterSubmodule opens the submodule at path as a nested session, keeping
+// the current repository to return to.
+func (m Model) enterSubmodule(path string) (tea.Model, tea.Cmd) {
+	repo, err := m.repo.OpenSubmodule(path)
+	if err != nil {
+		m.actionBar.SetMessage("Can't open submodule " + path + ": " + err.Error())
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	// Copy before appending so models sharing the stack are not affected.
+	m.parents = append(m.parents[:len(m.parents):len(m.parents)], parentRepo{repo: m.repo, path: path})
+	return m.openRepo(repo, "Opened submodule "+m.submoduleLabel()+" (backspace to return)")
+}
+
+// leaveSubmodule returns from a nested submodule session to its parent.
+func (m Model) leaveSubmodule() (tea.Model, tea.Cmd) {
+	if len(m.parents) == 0 {
+		m.actionBar.SetMessage("Not inside a submodule")
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	parent := m.parents[len(m.parents)-1]
+	m.parents = m.parents[:len(m.parents)-1]
+	message := "Back in " + filepath.Base(parent.repo.Path())
+	if label := m.submoduleLabel(); label != "" {
+		message = "Back in submodule " + label
+	}
+	return m.openRepo(parent.repo, message)
+}
+
+// submoduleLabel names the nested submodule session, e.g. "lib/a/vendor/b"
+// for a submodule of a submodule, or "" at the top.
+func (m Model) submoduleLabel() string {
+	paths := make([]string, len(m.parents))
+	for i, p := range m.parents {
+		paths[i] = p.path
+	}
+	return strings.Join(paths, "/")
+}
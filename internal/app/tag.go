@@ -0,0 +1,215 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/components/modals"
+)
+
+// tagsLoadedMsg carries the tag list for the tag panel.
+type tagsLoadedMsg struct {
+	tags []git.Tag
+	err  error
+}
+
+// tagResultMsg is sent when a tag create, delete or push returns.
+type tagResultMsg struct {
+	operation string // "create tag", "delete tag", "push tag", "push tags"
+	tag       string
+	err       error
+}
+
+// tagKinds are the new tag menu choices, in menu order.
+var tagKinds = []struct{ annotated, sign bool }{
+	{},
+	{annotated: true},
+	{annotated: true, sign: true},
+}
+
+// pendingTag is a tag being created while its name and message are
+// prompted for.
+type pendingTag struct {
+	target    string
+	label     string
+	annotated bool
+	sign      bool
+	name      string
+}
+
+func (m Model) loadTagsCmd() tea.Cmd {
+	return func() tea.Msg {
+		tags, err := m.repo.GetTags()
+		return tagsLoadedMsg{tags: tags, err: err}
+	}
+}
+
+func (m Model) handleTagsLoaded(msg tagsLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "tag list", err: msg.err})
+	}
+	m.tagModal.Show(msg.tags)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleTagModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "t":
+		m.tagModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.tagModal.MoveDown()
+		m.recalcGraphSize()
+		return m, nil
+	case "k", "up":
+		m.tagModal.MoveUp()
+		m.recalcGraphSize()
+		return m, nil
+	case "n":
+		return m.handleNewTag()
+	case "P":
+		return m.pushTags("push tags", "")
+	}
+
+	tag := m.tagModal.SelectedTag()
+	if tag == nil {
+		return m, nil
+	}
+	name := tag.Name
+
+	switch msg.String() {
+	case "d":
+		m.menuModal.Show("Delete tag "+name, []modals.MenuItem{
+			{Key: "l", Label: "local tag"},
+			{Key: "r", Label: "remote tag"},
+			{Key: "b", Label: "both"},
+		})
+		m.menuPurpose = menuDeleteTag
+		m.menuTarget = name
+		m.recalcGraphSize()
+	case "p":
+		return m.pushTags("push tag", name)
+	}
+	return m, nil
+}
+
+// handleNewTag asks what kind of tag to create on the commit selected in
+// the graph, or on HEAD for the uncommitted changes entry.
+func (m Model) handleNewTag() (tea.Model, tea.Cmd) {
+	target, label := "HEAD", "HEAD"
+	if commit := m.graphPanel.SelectedCommit(); commit != nil && commit.Hash != git.UncommittedHash {
+		target, label = commit.Hash, commit.ShortHash
+	}
+	m.pendingTag = pendingTag{target: target, label: label}
+	m.menuModal.Show("New tag on "+label, []modals.MenuItem{
+		{Key: "l", Label: "lightweight", Description: "just a name for the commit"},
+		{Key: "a", Label: "annotated", Description: "with a message, tagger and date"},
+		{Key: "s", Label: "signed", Description: "annotated and GPG-signed"},
+	})
+	m.menuPurpose = menuNewTag
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleTagKind prompts for the new tag's name once its kind is chosen.
+func (m Model) handleTagKind(choice int) (tea.Model, tea.Cmd) {
+	kind := tagKinds[choice]
+	m.pendingTag.annotated = kind.annotated
+	m.pendingTag.sign = kind.sign
+	m.inputModal.Show("Tag "+m.pendingTag.label+" as:", "")
+	m.inputPurpose = inputTagName
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleTagName creates a lightweight tag, or prompts for the annotation.
+func (m Model) handleTagName(name string) (tea.Model, tea.Cmd) {
+	m.pendingTag.name = name
+	if m.pendingTag.annotated {
+		m.inputModal.Show("Message for "+name+":", "")
+		m.inputPurpose = inputTagMessage
+		m.recalcGraphSize()
+		return m, nil
+	}
+	return m.createTag("")
+}
+
+func (m Model) createTag(message string) (tea.Model, tea.Cmd) {
+	p := m.pendingTag
+	m.pendingTag = pendingTag{}
+	opts := git.TagOptions{Message: message, Sign: p.sign}
+	m.actionBar.SetMessage("Tagging " + p.label + " as " + p.name + "...")
+	return m, m.tagCmd("create tag", p.name, func() error {
+		return m.repo.CreateTag(p.name, p.target, opts)
+	})
+}
+
+// deleteTag deletes a tag locally and/or on the default remote.
+func (m Model) deleteTag(name string, local, remote bool) (tea.Model, tea.Cmd) {
+	m.actionBar.SetMessage("Deleting tag " + name + "...")
+	return m, m.tagCmd("delete tag", name, func() error {
+		if remote {
+			origin, err := m.repo.DefaultRemote()
+			if err != nil {
+				return err
+			}
+			if err := m.repo.DeleteRemoteTag(origin, name); err != nil {
+				return err
+			}
+		}
+		if local {
+			return m.repo.DeleteTag(name)
+		}
+		return nil
+	})
+}
+
+// pushTags pushes one tag, or all tags if name is empty, to the default
+// remote.
+func (m Model) pushTags(operation, name string) (tea.Model, tea.Cmd) {
+	m.actionBar.SetMessage("Pushing tags...")
+	return m, m.tagCmd(operation, name, func() error {
+		origin, err := m.repo.DefaultRemote()
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return m.repo.PushTags(origin)
+		}
+		return m.repo.PushTag(origin, name)
+	})
+}
+
+func (m Model) tagCmd(operation, tag string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return tagResultMsg{operation: operation, tag: tag, err: run()}
+	}
+}
+
+// handleTagResult reports a tag change and refreshes the graph and, if it
+// is open, the tag panel.
+func (m Model) handleTagResult(msg tagResultMsg) (tea.Model, tea.Cmd) {
+	var reload tea.Cmd
+	if m.tagModal.IsVisible() {
+		reload = m.loadTagsCmd()
+	}
+	if msg.err != nil {
+		model, cmd := m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		return model, tea.Batch(cmd, reload)
+	}
+
+	switch msg.operation {
+	case "create tag":
+		m.actionBar.SetMessage("Created tag " + msg.tag)
+	case "delete tag":
+		m.actionBar.SetMessage("Deleted tag " + msg.tag)
+	case "push tag":
+		m.actionBar.SetMessage("Pushed tag " + msg.tag)
+	case "push tags":
+		m.actionBar.SetMessage("Pushed all tags")
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd(), reload)
+}
@@ -0,0 +1,196 @@
+package app
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// worktreesLoadedMsg carries the worktree list for the worktree panel.
+type worktreesLoadedMsg struct {
+	worktrees []git.Worktree
+	err       error
+}
+
+// worktreeResultMsg is sent when a worktree add, remove or prune returns.
+type worktreeResultMsg struct {
+	operation string // "add worktree", "remove worktree", "prune worktrees"
+	path      string
+	err       error
+}
+
+// pendingWorktree is a worktree being added while its branch is prompted
+// for.
+type pendingWorktree struct {
+	path  string
+	start string // commit a new branch starts at
+	label string // start as shown to the user
+}
+
+func (m Model) loadWorktreesCmd() tea.Cmd {
+	return func() tea.Msg {
+		worktrees, err := m.repo.Worktrees()
+		return worktreesLoadedMsg{worktrees: worktrees, err: err}
+	}
+}
+
+func (m Model) handleWorktreesLoaded(msg worktreesLoadedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.handleOperationResult(operationResultMsg{operation: "worktree list", err: msg.err})
+	}
+	m.worktreeModal.Show(msg.worktrees)
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) handleWorktreeModal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "W":
+		m.worktreeModal.Hide()
+		m.recalcGraphSize()
+		return m, nil
+	case "j", "down":
+		m.worktreeModal.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.worktreeModal.MoveUp()
+		return m, nil
+	case "n":
+		return m.handleNewWorktree()
+	case "P":
+		m.actionBar.SetMessage("Pruning worktrees...")
+		return m, m.worktreeCmd("prune worktrees", "", m.repo.PruneWorktrees)
+	}
+
+	w := m.worktreeModal.SelectedWorktree()
+	if w == nil {
+		return m, nil
+	}
+	path := w.Path
+
+	switch msg.String() {
+	case "enter":
+		switch {
+		case w.IsCurrent:
+			m.worktreeModal.Hide()
+			m.recalcGraphSize()
+		case w.Bare:
+			m.actionBar.SetMessage("A bare repository has no working tree to switch to")
+			return m, m.clearMessageAfter(3 * time.Second)
+		case w.Prunable:
+			m.actionBar.SetMessage(path + " no longer exists (P to prune it)")
+			return m, m.clearMessageAfter(3 * time.Second)
+		default:
+			return m.switchWorktree(path)
+		}
+	case "d":
+		if w.IsMain || w.IsCurrent {
+			m.actionBar.SetMessage("Can't remove the main or the current worktree")
+			return m, m.clearMessageAfter(3 * time.Second)
+		}
+		lines := []string{"Its directory is deleted."}
+		if w.Branch != "" {
+			lines = append(lines, "The branch "+w.Branch+" is kept.")
+		}
+		m.confirmModal.Show("Remove worktree "+path+"?", lines)
+		m.confirmPurpose = confirmRemoveWorktree
+		m.confirmTarget = path
+		m.recalcGraphSize()
+	}
+	return m, nil
+}
+
+// handleNewWorktree prompts for the path of a new worktree, then for the
+// branch to check out in it. A new branch starts at the commit selected in
+// the graph, or at HEAD.
+func (m Model) handleNewWorktree() (tea.Model, tea.Cmd) {
+	m.pendingWorktree = pendingWorktree{start: "HEAD", label: "HEAD"}
+	if commit := m.graphPanel.SelectedCommit(); commit != nil && commit.Hash != git.UncommittedHash {
+		m.pendingWorktree.start, m.pendingWorktree.label = commit.Hash, commit.ShortHash
+	}
+	m.inputModal.Show("New worktree path:", "")
+	m.inputModal.SetPlaceholder("relative to the repository root, e.g. ../" + filepath.Base(m.repo.Path()) + "-feature")
+	m.inputPurpose = inputWorktreePath
+	m.recalcGraphSize()
+	return m, nil
+}
+
+// handleWorktreePath asks which branch the new worktree at path checks
+// out, suggesting one named after its directory.
+func (m Model) handleWorktreePath(path string) (tea.Model, tea.Cmd) {
+	m.pendingWorktree.path = path
+	m.inputModal.Show("Branch (new ones start at "+m.pendingWorktree.label+"):", filepath.Base(path))
+	m.inputPurpose = inputWorktreeBranch
+	m.recalcGraphSize()
+	return m, nil
+}
+
+func (m Model) addWorktree(branch string) (tea.Model, tea.Cmd) {
+	p := m.pendingWorktree
+	m.pendingWorktree = pendingWorktree{}
+	m.actionBar.SetMessage("Adding worktree " + p.path + "...")
+	return m, m.worktreeCmd("add worktree", p.path, func() error {
+		return m.repo.AddWorktree(p.path, branch, p.start)
+	})
+}
+
+func (m Model) removeWorktree(path string, force bool) (tea.Model, tea.Cmd) {
+	m.actionBar.SetMessage("Removing worktree " + path + "...")
+	return m, m.worktreeCmd("remove worktree", path, func() error {
+		return m.repo.RemoveWorktree(path, force)
+	})
+}
+
+func (m Model) worktreeCmd(operation, path string, run func() error) tea.Cmd {
+	return func() tea.Msg {
+		return worktreeResultMsg{operation: operation, path: path, err: run()}
+	}
+}
+
+// handleWorktreeResult reports a worktree change and refreshes the panel,
+// if it is open, and the graph, whose branches mark the worktrees they are
+// checked out in. Removing a worktree with changes asks to force it.
+func (m Model) handleWorktreeResult(msg worktreeResultMsg) (tea.Model, tea.Cmd) {
+	if msg.operation == "remove worktree" && git.IsErrorKind(msg.err, git.ErrorKindWorktreeDirty) {
+		m.actionBar.ClearMessage()
+		m.confirmModal.Show(fmt.Sprintf("Force remove worktree %s?", msg.path), []string{
+			"It has uncommitted changes or untracked files, which will be lost.",
+		})
+		m.confirmPurpose = confirmForceRemoveWorktree
+		m.confirmTarget = msg.path
+		m.recalcGraphSize()
+		return m, nil
+	}
+
+	var reload tea.Cmd
+	if m.worktreeModal.IsVisible() {
+		reload = m.loadWorktreesCmd()
+	}
+	if msg.err != nil {
+		model, cmd := m.handleOperationResult(operationResultMsg{operation: msg.operation, err: msg.err})
+		return model, tea.Batch(cmd, reload)
+	}
+
+	switch msg.operation {
+	case "add worktree":
+		m.actionBar.SetMessage("Added worktree " + msg.path + " (Enter in the worktree panel to switch)")
+	case "remove worktree":
+		m.actionBar.SetMessage("Removed worktree " + msg.path)
+	case "prune worktrees":
+		m.actionBar.SetMessage("Pruned missing worktrees")
+	}
+	return m, tea.Batch(m.clearMessageAfter(3*time.Second), m.loadCommitsCmd(), reload)
+}
+
+// switchWorktree reopens the app in the worktree at path.
+func (m Model) switchWorktree(path string) (tea.Model, tea.Cmd) {
+	repo, err := git.OpenRepository(path)
+	if err != nil {
+		m.actionBar.SetMessage("Can't open worktree " + path + ": " + err.Error())
+		return m, m.clearMessageAfter(3 * time.Second)
+	}
+	return m.openRepo(repo, "Switched to worktree "+path)
+}
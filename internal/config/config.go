@@ -54,6 +54,7 @@ type CommitConfig struct {
 }
 
 type PerformanceConfig struct {
-	MaxCommits        int `yaml:"max_commits"`
-	LazyLoadThreshold int `yaml:"lazy_load_threshold"`
+	PageSize          int `yaml:"page_size"`           // commits loaded per page
+	MaxCommits        int `yaml:"max_commits"`         // cap on loaded commits, 0 = no limit
+	LazyLoadThreshold int `yaml:"lazy_load_threshold"` // rows from the end that trigger the next page
 }
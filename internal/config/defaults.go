@@ -50,7 +50,8 @@ func DefaultConfig() *Config {
 			Template:     "",
 		},
 		Performance: PerformanceConfig{
-			MaxCommits:        1000,
+			PageSize:          300,
+			MaxCommits:        0,
 			LazyLoadThreshold: 100,
 		},
 	}
@@ -1,216 +1,207 @@
 package git
 
 import (
-	"os/exec"
 	"strconv"
 	"strings"
 )
 
-func (r *Repository) Push() error {
-	cmd := exec.Command("git", "push")
-	cmd.Dir = r.path
-	return cmd.Run()
-}
-
 func (r *Repository) Pull(rebase bool) error {
 	args := []string{"pull"}
 	if rebase {
 		args = append(args, "--rebase")
 	}
-	cmd := exec.Command("git", args...)
-	cmd.Dir = r.path
-	return cmd.Run()
+	_, err := r.runWith(reflogEnv(strings.Join(args, " ")), nil, args...)
+	return err
 }
 
 func (r *Repository) Fetch() error {
-	cmd := exec.Command("git", "fetch", "--all")
-	cmd.Dir = r.path
-	return cmd.Run()
+	_, err := r.run("fetch", "--all")
+	return err
 }
 
 func (r *Repository) Checkout(branch string) error {
-	cmd := exec.Command("git", "checkout", branch)
-	cmd.Dir = r.path
-	return cmd.Run()
+	_, err := r.runWith(r.checkoutReflogEnv(reflogMarker, branch), nil, "checkout", branch)
+	return err
 }
 
+// Commit records the currently staged changes. Unstaged and untracked files
+// are left untouched; ErrNothingStaged is returned if the index matches HEAD.
 func (r *Repository) Commit(message string) error {
-	// Stage all changes (tracked + untracked) before committing, since
-	// there is no staging UI yet.
-	stageCmd := exec.Command("git", "add", "-A")
-	stageCmd.Dir = r.path
-	if err := stageCmd.Run(); err != nil {
-		return err
+	if !r.HasStagedChanges() {
+		return ErrNothingStaged
 	}
 
-	cmd := exec.Command("git", "commit", "-m", message)
-	cmd.Dir = r.path
-	return cmd.Run()
+	_, err := r.runWith(reflogEnv("commit"), nil, "commit", "-m", message)
+	return err
 }
 
 func (r *Repository) GetDiff(hash string) (string, error) {
-	cmd := exec.Command("git", "show", "--no-color", "--format=", hash)
-	cmd.Dir = r.path
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return string(output), nil
+	return r.run("show", "--no-color", "--format=", hash)
 }
 
 func (r *Repository) GetChangedFiles(hash string) ([]ChangedFile, error) {
-	// Get file status (A/M/D/R) via --name-status.
-	statusCmd := exec.Command("git", "diff-tree", "--no-commit-id", "--name-status", "-r", hash)
-	statusCmd.Dir = r.path
-	statusOut, err := statusCmd.Output()
+	// Get file status (A/M/D/R) via --name-status. -z keeps paths unquoted.
+	statusOut, err := r.run("diff-tree", "--no-commit-id", "--name-status", "-r", "-z", hash)
 	if err != nil {
 		return nil, err
 	}
 
 	// Get per-file line additions/deletions via --numstat.
-	numstatCmd := exec.Command("git", "diff-tree", "--no-commit-id", "--numstat", "-r", hash)
-	numstatCmd.Dir = r.path
-	numstatOut, _ := numstatCmd.Output() // best-effort; ignore errors
-
-	// Build a map of path -> (additions, deletions) from numstat output.
-	stats := make(map[string][2]int)
-	for _, line := range strings.Split(strings.TrimSpace(string(numstatOut)), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 3)
-		if len(parts) == 3 {
-			adds, _ := strconv.Atoi(parts[0]) // "-" for binary -> 0
-			dels, _ := strconv.Atoi(parts[1])
-			stats[parts[2]] = [2]int{adds, dels}
-		}
-	}
-
-	var files []ChangedFile
-	for _, line := range strings.Split(strings.TrimSpace(string(statusOut)), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 2)
-		if len(parts) == 2 {
-			s := stats[parts[1]]
-			files = append(files, ChangedFile{
-				Status:    parts[0],
-				Path:      parts[1],
-				Additions: s[0],
-				Deletions: s[1],
-			})
-		}
+	numstatOut, _ := r.run("diff-tree", "--no-commit-id", "--numstat", "-r", "-z", hash) // best-effort; ignore errors
+	stats := parseNumstat(numstatOut)
+
+	files := parseNameStatus(statusOut)
+	for i := range files {
+		s := stats[files[i].Path]
+		files[i].Additions = s[0]
+		files[i].Deletions = s[1]
 	}
+	r.markSubmodules(hash, files)
 	return files, nil
 }
 
 func (r *Repository) GetFileDiff(hash, filePath string) (string, error) {
-	cmd := exec.Command("git", "show", "--no-color", "--format=", hash, "--", filePath)
-	cmd.Dir = r.path
-	output, err := cmd.Output()
-	if err != nil {
-		return "", err
-	}
-	return string(output), nil
+	return r.run("show", "--no-color", "--format=", hash, "--", filePath)
+}
+
+// GetRenameDiff returns the diff of a file the commit renamed from oldPath,
+// shown as a rename with any changes rather than a deletion and an addition.
+func (r *Repository) GetRenameDiff(hash, oldPath, path string) (string, error) {
+	return r.run("show", "--no-color", "--format=", "-M", hash, "--", oldPath, path)
 }
 
 // GetWorkingTreeFiles returns all staged and unstaged changed files in the
 // working tree using `git status --porcelain`, with per-file line stats
 // from `git diff --numstat HEAD`.
 func (r *Repository) GetWorkingTreeFiles() ([]ChangedFile, error) {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = r.path
-	output, err := cmd.Output()
+	output, err := r.run("status", "--porcelain", "-z")
 	if err != nil {
 		return nil, err
 	}
 
 	// Get line stats for all working tree changes vs HEAD.
-	numstatCmd := exec.Command("git", "diff", "--numstat", "HEAD")
-	numstatCmd.Dir = r.path
-	numstatOut, _ := numstatCmd.Output() // best-effort
-
-	stats := make(map[string][2]int)
-	for _, line := range strings.Split(strings.TrimSpace(string(numstatOut)), "\n") {
-		if line == "" {
-			continue
-		}
-		parts := strings.SplitN(line, "\t", 3)
-		if len(parts) == 3 {
-			adds, _ := strconv.Atoi(parts[0])
-			dels, _ := strconv.Atoi(parts[1])
-			stats[parts[2]] = [2]int{adds, dels}
-		}
+	numstatOut, _ := r.run("diff", "--numstat", "-z", "HEAD") // best-effort
+	stats := parseNumstat(numstatOut)
+
+	files := parseStatusPorcelain(output)
+	for i := range files {
+		s := stats[files[i].Path]
+		files[i].Additions = s[0]
+		files[i].Deletions = s[1]
 	}
+	r.markWorkingTreeSubmodules(files)
+	return files, nil
+}
 
+// parseStatusPorcelain parses `git status --porcelain -z` output. Each entry
+// is "XY <path>" where X is the index status and Y the worktree status;
+// renames and copies are followed by an extra entry holding the old path.
+func parseStatusPorcelain(output string) []ChangedFile {
 	var files []ChangedFile
-	for _, line := range strings.Split(string(output), "\n") {
-		if len(line) < 4 {
+	entries := strings.Split(output, "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
 			continue
 		}
-		// Porcelain format: XY <path>
-		// X = index status, Y = worktree status
-		xy := line[:2]
-		path := line[3:]
+		xy := entry[:2]
+		path := entry[3:]
 
 		status := "M" // default
+		conflict := ""
 		switch {
+		case isUnmerged(xy):
+			status = "U"
+			conflict = xy
 		case xy[0] == '?' || xy[1] == '?':
 			status = "?"
 		case xy[0] == 'A' || xy[1] == 'A':
 			status = "A"
 		case xy[0] == 'D' || xy[1] == 'D':
 			status = "D"
-		case xy[0] == 'R' || xy[1] == 'R':
+		case xy[0] == 'R' || xy[1] == 'R' || xy[0] == 'C' || xy[1] == 'C':
 			status = "R"
 		case xy[0] == 'M' || xy[1] == 'M':
 			status = "M"
 		}
 
-		s := stats[path]
+		var oldPath string
+		if strings.ContainsAny(xy, "RC") && i+1 < len(entries) {
+			i++
+			oldPath = entries[i]
+		}
+
 		files = append(files, ChangedFile{
-			Status:    status,
-			Path:      path,
-			Additions: s[0],
-			Deletions: s[1],
+			Status:   status,
+			Path:     path,
+			OldPath:  oldPath,
+			Staged:   xy[0] != ' ' && xy[0] != '?' && conflict == "",
+			Unstaged: xy[1] != ' ',
+			Conflict: conflict,
 		})
 	}
-	return files, nil
+	return files
+}
+
+// isUnmerged reports whether a porcelain XY code is one of the unmerged
+// states: DD, AU, UD, UA, DU, AA or UU.
+func isUnmerged(xy string) bool {
+	switch xy {
+	case "DD", "AU", "UD", "UA", "DU", "AA", "UU":
+		return true
+	}
+	return false
 }
 
-// GetWorkingTreeFileDiff returns the combined (staged + unstaged) diff for a
-// single file in the working tree.
-func (r *Repository) GetWorkingTreeFileDiff(filePath string) (string, error) {
-	// Get unstaged changes.
-	cmd := exec.Command("git", "diff", "--no-color", "--", filePath)
-	cmd.Dir = r.path
-	unstaged, _ := cmd.Output()
-
-	// Get staged changes.
-	cmd2 := exec.Command("git", "diff", "--cached", "--no-color", "--", filePath)
-	cmd2.Dir = r.path
-	staged, _ := cmd2.Output()
-
-	// For untracked files, show the whole file as an add.
-	if len(unstaged) == 0 && len(staged) == 0 {
-		cmd3 := exec.Command("git", "diff", "--no-color", "--no-index", "/dev/null", filePath)
-		cmd3.Dir = r.path
-		untracked, _ := cmd3.Output()
-		return string(untracked), nil
+// parseNameStatus parses `--name-status -z` output: a status token followed
+// by the path, or by the old and new path for renames and copies. Rename
+// and copy scores ("R100") are reduced to their letter.
+func parseNameStatus(output string) []ChangedFile {
+	var files []ChangedFile
+	tokens := strings.Split(output, "\x00")
+	for i := 0; i+1 < len(tokens); i += 2 {
+		status := tokens[i]
+		if status == "" {
+			continue
+		}
+		file := ChangedFile{Status: status[:1], Path: tokens[i+1]}
+		if (status[0] == 'R' || status[0] == 'C') && i+2 < len(tokens) {
+			file.Status = "R"
+			file.OldPath = tokens[i+1]
+			file.Path = tokens[i+2]
+			i++
+		}
+		files = append(files, file)
 	}
+	return files
+}
 
-	// Prefer staged if present, otherwise unstaged.
-	if len(staged) > 0 {
-		return string(staged), nil
+// parseNumstat parses `--numstat -z` output into a map of path ->
+// (additions, deletions), keyed by the new path for renames. Binary files
+// report "-" and are counted as 0.
+func parseNumstat(output string) map[string][2]int {
+	stats := make(map[string][2]int)
+	tokens := strings.Split(output, "\x00")
+	for i := 0; i < len(tokens); i++ {
+		parts := strings.SplitN(tokens[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		adds, _ := strconv.Atoi(parts[0])
+		dels, _ := strconv.Atoi(parts[1])
+		path := parts[2]
+		if path == "" && i+2 < len(tokens) {
+			// Rename: the old and new paths follow as separate tokens.
+			path = tokens[i+2]
+			i += 2
+		}
+		stats[path] = [2]int{adds, dels}
 	}
-	return string(unstaged), nil
+	return stats
 }
 
 // HasWorkingTreeChanges returns true if there are any uncommitted changes.
 func (r *Repository) HasWorkingTreeChanges() bool {
-	cmd := exec.Command("git", "status", "--porcelain")
-	cmd.Dir = r.path
-	output, _ := cmd.Output()
-	return len(strings.TrimSpace(string(output))) > 0
+	output, _ := r.run("status", "--porcelain")
+	return len(strings.TrimSpace(output)) > 0
 }
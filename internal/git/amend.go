@@ -0,0 +1,91 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrRewordPastMerge is returned when rewording a commit would rebase a
+// merge commit after it, which would flatten the merge.
+var ErrRewordPastMerge = errors.New("cannot reword a commit before a merge")
+
+// CommitMessage returns the full message of rev.
+func (r *Repository) CommitMessage(rev string) (string, error) {
+	out, err := r.run("log", "-1", "--format=%B", rev)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(out, "\n"), nil
+}
+
+// Amend folds the staged changes into the HEAD commit and replaces its
+// message, or keeps the message if message is empty. Keeping the message
+// with nothing staged would change nothing, so ErrNothingStaged is
+// returned instead.
+func (r *Repository) Amend(message string) error {
+	if message == "" {
+		if !r.HasStagedChanges() {
+			return ErrNothingStaged
+		}
+		_, err := r.runWith(reflogEnv("commit (amend)"), nil, "commit", "--amend", "--no-edit")
+		return err
+	}
+	_, err := r.runWith(reflogEnv("commit (amend)"), nil, "commit", "--amend", "-m", message)
+	return err
+}
+
+// Reword replaces the message of hash, which must be on the current
+// branch. HEAD is amended directly, leaving staged changes out; an older
+// commit is rewritten by an interactive rebase that picks everything after
+// it unchanged.
+func (r *Repository) Reword(hash, message string) error {
+	head, err := r.HeadHash()
+	if err != nil {
+		return err
+	}
+	if hash == head {
+		_, err := r.runWith(reflogEnv("commit (amend)"), nil, "commit", "--amend", "--only", "-m", message)
+		return err
+	}
+
+	commits, err := r.RebaseCommits(hash)
+	if err != nil {
+		return err
+	}
+	merges, err := r.run("rev-list", "--merges", hash+"..HEAD")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(merges) != "" {
+		return ErrRewordPastMerge
+	}
+
+	todo := make([]RebaseTodoItem, len(commits))
+	for i, c := range commits {
+		todo[i] = RebaseTodoItem{Action: RebasePick, Commit: c}
+		if c.Hash == hash {
+			todo[i] = RebaseTodoItem{Action: RebaseReword, Commit: c, Message: message}
+		}
+	}
+	return r.InteractiveRebase(hash, todo)
+}
+
+// PushedTo returns the upstream of the current branch if it already
+// contains hash, so rewriting hash would need a force push, or "" if it
+// does not or there is no upstream.
+func (r *Repository) PushedTo(hash string) (string, error) {
+	out, err := r.run("rev-parse", "--abbrev-ref", "--verify", "--quiet", "@{upstream}")
+	if err != nil {
+		return "", nil // no upstream
+	}
+	upstream := strings.TrimSpace(out)
+
+	_, err = r.run("merge-base", "--is-ancestor", hash, "@{upstream}")
+	if exitCode(err) == 1 {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return upstream, nil
+}
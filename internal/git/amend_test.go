@@ -0,0 +1,37 @@
+package git_test
+
+import "testing"
+
+func TestRewordOlderCommit(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	f.Git("commit", "--amend", "-m", "four\n\nbody of four")
+	two := f.Git("rev-parse", "HEAD~2")
+
+	if err := repo.Reword(two, "second"); err != nil {
+		t.Fatalf("Reword: %v", err)
+	}
+	if got, want := subjects(f), "four,three,second,one,base"; got != want {
+		t.Errorf("subjects = %q, want %q", got, want)
+	}
+	if got := f.Git("log", "-1", "--format=%b"); got != "body of four" {
+		t.Errorf("HEAD body = %q, want it kept", got)
+	}
+}
+
+func TestPushedTo(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	pushed := f.Git("rev-parse", "HEAD~1")
+	f.Git("remote", "add", "origin", f.Dir)
+	f.Git("update-ref", "refs/remotes/origin/main", pushed)
+	f.Git("branch", "--set-upstream-to=origin/main")
+
+	for rev, want := range map[string]string{"HEAD": "", "HEAD~1": "origin/main", "HEAD~3": "origin/main"} {
+		got, err := repo.PushedTo(f.Git("rev-parse", rev))
+		if err != nil {
+			t.Fatalf("PushedTo(%s): %v", rev, err)
+		}
+		if got != want {
+			t.Errorf("PushedTo(%s) = %q, want %q", rev, got, want)
+		}
+	}
+}
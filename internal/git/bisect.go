@@ -0,0 +1,143 @@
+package git
+
+import (
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// BisectStatus describes a bisect in progress, read from the refs/bisect/
+// refs and BISECT_LOG.
+type BisectStatus struct {
+	Bad      string   // commit marked bad, "" until one is
+	Good     []string // commits marked good
+	Skipped  []string // commits skipped as untestable
+	Current  string   // HEAD, the commit under test
+	FirstBad string   // the first bad commit, once git has found it
+	Steps    int      // roughly how many more commits need testing, -1 if unknown
+}
+
+// BisectStart starts a bisect. Commits are then marked good or bad.
+func (r *Repository) BisectStart() error {
+	_, err := r.run("bisect", "start")
+	return err
+}
+
+// BisectGood marks rev as good: it predates the change being looked for.
+func (r *Repository) BisectGood(rev string) error {
+	_, err := r.run("bisect", "good", rev)
+	return err
+}
+
+// BisectBad marks rev as bad: it has the change being looked for.
+func (r *Repository) BisectBad(rev string) error {
+	_, err := r.run("bisect", "bad", rev)
+	return err
+}
+
+// BisectSkip skips rev, or the commit under test if rev is empty, letting
+// git pick a nearby one.
+func (r *Repository) BisectSkip(rev string) error {
+	args := []string{"bisect", "skip"}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	_, err := r.run(args...)
+	return err
+}
+
+// BisectReset ends the bisect and checks out the commit it started from.
+func (r *Repository) BisectReset() error {
+	_, err := r.run("bisect", "reset")
+	return err
+}
+
+// BisectRun lets git test commits with a shell command until it finds the
+// first bad one: exit 0 means good, 125 untestable and 1-127 bad. The
+// command's output and git's progress are copied to out as they appear.
+func (r *Repository) BisectRun(command string, out io.Writer) error {
+	_, err := r.runStreaming(out, "bisect", "run", "sh", "-c", command)
+	return err
+}
+
+// BisectStatus returns the marks of the bisect in progress, or nil if
+// there is none.
+func (r *Repository) BisectStatus() (*BisectStatus, error) {
+	gitDir, err := r.GitDir()
+	if err != nil {
+		return nil, err
+	}
+	logPath := filepath.Join(gitDir, "BISECT_LOG")
+	if !fileExists(logPath) {
+		return nil, nil
+	}
+
+	refs, err := r.run("for-each-ref", "--format=%(refname) %(objectname)", "refs/bisect/")
+	if err != nil {
+		return nil, err
+	}
+	s := parseBisectRefs(refs)
+	s.FirstBad = parseFirstBad(readTrimmedFile(logPath))
+	s.Steps = -1
+	if s.Current, err = r.HeadHash(); err != nil {
+		return nil, err
+	}
+
+	if s.Bad != "" && len(s.Good) > 0 && s.FirstBad == "" {
+		args := append([]string{"rev-list", "--bisect-vars", s.Bad, "--not"}, s.Good...)
+		if vars, err := r.run(args...); err == nil {
+			s.Steps = parseBisectSteps(vars)
+		}
+	}
+	return s, nil
+}
+
+// parseBisectRefs reads the marks from `for-each-ref` lines of the form
+// "refs/bisect/good-<hash> <hash>".
+func parseBisectRefs(out string) *BisectStatus {
+	s := &BisectStatus{}
+	for _, line := range strings.Split(out, "\n") {
+		name, hash, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		switch name = strings.TrimPrefix(name, "refs/bisect/"); {
+		case name == "bad":
+			s.Bad = hash
+		case strings.HasPrefix(name, "good-"):
+			s.Good = append(s.Good, hash)
+		case strings.HasPrefix(name, "skip-"):
+			s.Skipped = append(s.Skipped, hash)
+		}
+	}
+	return s
+}
+
+// parseFirstBad finds the "# first bad commit: [<hash>] <subject>" line git
+// logs once the bisect is done.
+func parseFirstBad(log string) string {
+	for _, line := range strings.Split(log, "\n") {
+		rest, ok := strings.CutPrefix(line, "# first bad commit: [")
+		if !ok {
+			continue
+		}
+		if hash, _, ok := strings.Cut(rest, "]"); ok {
+			return hash
+		}
+	}
+	return ""
+}
+
+// parseBisectSteps reads bisect_steps from `rev-list --bisect-vars`
+// output, returning -1 if it is missing.
+func parseBisectSteps(vars string) int {
+	for _, line := range strings.Split(vars, "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(line), "bisect_steps="); ok {
+			if n, err := strconv.Atoi(v); err == nil {
+				return n
+			}
+		}
+	}
+	return -1
+}
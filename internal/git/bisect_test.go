@@ -0,0 +1,56 @@
+package git_test
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestBisectFindsFirstBadCommit(t *testing.T) {
+	f := gittest.NewFixture(t)
+	var hashes []string
+	for i := 1; i <= 8; i++ {
+		f.WriteFile("n.txt", strconv.Itoa(i)+"\n")
+		hashes = append(hashes, f.Commit("commit "+strconv.Itoa(i)))
+	}
+	repo := openFixture(t, f)
+
+	if err := repo.BisectStart(); err != nil {
+		t.Fatalf("BisectStart: %v", err)
+	}
+	if err := repo.BisectBad(hashes[7]); err != nil {
+		t.Fatalf("BisectBad: %v", err)
+	}
+	if err := repo.BisectGood(hashes[0]); err != nil {
+		t.Fatalf("BisectGood: %v", err)
+	}
+	s, err := repo.BisectStatus()
+	if err != nil {
+		t.Fatalf("BisectStatus: %v", err)
+	}
+	if s == nil || s.Bad != hashes[7] || len(s.Good) != 1 || s.Good[0] != hashes[0] ||
+		s.Current != f.Git("rev-parse", "HEAD") || s.Steps != 2 || s.FirstBad != "" {
+		t.Fatalf("BisectStatus = %+v, want bad %s, good %s and 2 steps left", s, hashes[7], hashes[0])
+	}
+
+	var out bytes.Buffer
+	if err := repo.BisectRun(`test "$(cat n.txt)" -lt 5`, &out); err != nil {
+		t.Fatalf("BisectRun: %v", err)
+	}
+	if !strings.Contains(out.String(), hashes[4]+" is the first bad commit") {
+		t.Errorf("BisectRun output = %q, want the first bad commit reported", out.String())
+	}
+	if s, err = repo.BisectStatus(); err != nil || s == nil || s.FirstBad != hashes[4] {
+		t.Errorf("BisectStatus after run = %+v, %v, want first bad %s", s, err, hashes[4])
+	}
+
+	if err := repo.BisectReset(); err != nil {
+		t.Fatalf("BisectReset: %v", err)
+	}
+	if s, err := repo.BisectStatus(); err != nil || s != nil {
+		t.Errorf("BisectStatus after reset = %+v, %v, want nil", s, err)
+	}
+}
@@ -0,0 +1,110 @@
+package git
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// blameIgnoreRevsFile lists commits blame looks past, such as reformatting
+// sweeps, by the convention GitHub and GitLab also follow.
+const blameIgnoreRevsFile = ".git-blame-ignore-revs"
+
+// BlameLine is one line of a file and the commit that last changed it.
+type BlameLine struct {
+	Hash       string // UncommittedHash for a line changed in the working tree
+	Author     string
+	AuthorTime time.Time
+	Summary    string // subject of the commit
+	Path       string // path of the file in that commit
+
+	// Previous and PreviousPath name the commit's parent and the file's
+	// path there, to blame further back; Previous is "" if the file did
+	// not exist before the commit.
+	Previous     string
+	PreviousPath string
+
+	Line int // 1-based line number in the blamed file
+	Text string
+}
+
+// Blame attributes each line of path at rev, or in the working tree if rev
+// is empty, to the commit that last changed it. Commits listed in
+// .git-blame-ignore-revs are looked past.
+func (r *Repository) Blame(rev, path string) ([]BlameLine, error) {
+	args := []string{"blame", "--porcelain"}
+	if ignore := filepath.Join(r.path, blameIgnoreRevsFile); fileExists(ignore) {
+		args = append(args, "--ignore-revs-file", ignore)
+	}
+	if rev != "" {
+		args = append(args, rev)
+	}
+	args = append(args, "--", path)
+
+	out, err := r.run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlame(out), nil
+}
+
+// parseBlame parses `git blame --porcelain` output. Each line of the file
+// comes after a "<hash> <orig-line> <final-line>[ <count>]" header and a
+// tab; the commit's details follow its header only the first time it
+// appears.
+func parseBlame(out string) []BlameLine {
+	var lines []BlameLine
+	commits := make(map[string]*BlameLine)
+	var info *BlameLine
+	lineNo := 0
+
+	for _, line := range strings.Split(out, "\n") {
+		if text, ok := strings.CutPrefix(line, "\t"); ok {
+			if info != nil {
+				l := *info
+				l.Line, l.Text = lineNo, text
+				lines = append(lines, l)
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		if fields := strings.Fields(value); len(key) == 40 && isHex(key) && len(fields) >= 2 {
+			if info = commits[key]; info == nil {
+				info = &BlameLine{Hash: key}
+				commits[key] = info
+			}
+			lineNo, _ = strconv.Atoi(fields[1])
+			continue
+		}
+		if info == nil {
+			continue
+		}
+		switch key {
+		case "author":
+			info.Author = value
+		case "author-time":
+			if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+				info.AuthorTime = time.Unix(ts, 0)
+			}
+		case "summary":
+			info.Summary = value
+		case "filename":
+			info.Path = value
+		case "previous":
+			info.Previous, info.PreviousPath, _ = strings.Cut(value, " ")
+		}
+	}
+	return lines
+}
+
+// isHex reports whether s is made only of lowercase hex digits.
+func isHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,35 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestBlameHonoursIgnoreRevs(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("code.txt", "a=1\nb=2\n")
+	base := f.Commit("add code")
+	f.WriteFile("code.txt", "a = 1\nb = 2\n")
+	reformat := f.Commit("reformat")
+	repo := openFixture(t, f)
+
+	lines, err := repo.Blame("HEAD", "code.txt")
+	if err != nil {
+		t.Fatalf("Blame: %v", err)
+	}
+	if len(lines) != 2 || lines[0].Hash != reformat || lines[0].Previous != base || lines[1].Text != "b = 2" {
+		t.Fatalf("Blame = %+v, want both lines from the reformat", lines)
+	}
+
+	f.WriteFile(".git-blame-ignore-revs", "# formatting\n"+reformat+"\n")
+	lines, err = repo.Blame("HEAD", "code.txt")
+	if err != nil {
+		t.Fatalf("Blame: %v", err)
+	}
+	for _, l := range lines {
+		if l.Hash != base || l.Summary != "add code" {
+			t.Errorf("line %d blamed on %s %q, want the ignored reformat looked past", l.Line, l.Hash, l.Summary)
+		}
+	}
+}
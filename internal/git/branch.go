@@ -0,0 +1,141 @@
+package git
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrNoUpstream is returned by DeleteRemoteBranch for a branch that does
+// not track a remote branch, including one that tracks a local branch.
+var ErrNoUpstream = errors.New("branch has no upstream")
+
+// HasRemoteUpstream reports whether the branch tracks a branch on a
+// remote. A branch made with `git branch --track x main` tracks the local
+// main through the remote ".", which is no remote to push to.
+func (b *Branch) HasRemoteUpstream() bool {
+	return b.Upstream != "" && b.UpstreamRemote != "."
+}
+
+// UpstreamBranch returns the name of the upstream branch on its remote,
+// e.g. "main" for an upstream of "origin/main".
+func (b *Branch) UpstreamBranch() string {
+	return strings.TrimPrefix(b.Upstream, b.UpstreamRemote+"/")
+}
+
+// CreateBranch creates branch name at startPoint (a commit, branch or
+// "HEAD"), checking it out if checkout is set.
+func (r *Repository) CreateBranch(name, startPoint string, checkout bool) error {
+	if checkout {
+		_, err := r.runWith(r.checkoutReflogEnv(reflogMarker, name), nil, "checkout", "-b", name, startPoint)
+		return err
+	}
+	_, err := r.run("branch", name, startPoint)
+	return err
+}
+
+// RenameBranch renames a local branch, carrying over its upstream and
+// reflog.
+func (r *Repository) RenameBranch(oldName, newName string) error {
+	_, err := r.run("branch", "-m", oldName, newName)
+	return err
+}
+
+// DeleteBranch deletes a local branch. Without force, git refuses to delete
+// a branch with commits that aren't merged into HEAD or its upstream; the
+// error is then of kind ErrorKindBranchNotMerged.
+func (r *Repository) DeleteBranch(name string, force bool) error {
+	flag := "-d"
+	if force {
+		flag = "-D"
+	}
+	_, err := r.run("branch", flag, name)
+	return err
+}
+
+// DeleteRemoteBranch deletes the branch's upstream on its remote. The ref
+// is pushed fully qualified so a tag of the same name can't match.
+func (r *Repository) DeleteRemoteBranch(b *Branch) error {
+	if !b.HasRemoteUpstream() {
+		return ErrNoUpstream
+	}
+	ref := b.UpstreamRef
+	if ref == "" {
+		ref = "refs/heads/" + b.UpstreamBranch()
+	}
+	_, err := r.run("push", b.UpstreamRemote, "--delete", ref)
+	return err
+}
+
+// SetUpstream makes branch track upstream, a remote-tracking branch such as
+// "origin/main".
+func (r *Repository) SetUpstream(branch, upstream string) error {
+	_, err := r.run("branch", "--set-upstream-to="+upstream, branch)
+	return err
+}
+
+// UnsetUpstream removes the branch's upstream configuration.
+func (r *Repository) UnsetUpstream(branch string) error {
+	_, err := r.run("branch", "--unset-upstream", branch)
+	return err
+}
+
+// branchTracking is the ahead/behind state of one branch against its
+// upstream.
+type branchTracking struct {
+	ahead, behind int
+	gone          bool
+}
+
+// applyTracking fills in the ahead/behind counts of branches that have an
+// upstream. go-git has no equivalent of upstream:track, so this asks git.
+// It is best-effort: if git fails, the counts are left at zero.
+func (r *Repository) applyTracking(branches []*Branch) {
+	hasUpstream := false
+	for _, b := range branches {
+		if b.Upstream != "" {
+			hasUpstream = true
+			break
+		}
+	}
+	if !hasUpstream {
+		return
+	}
+
+	out, err := r.run("for-each-ref", "--format=%(refname:short)%00%(upstream:track,nobracket)", "refs/heads")
+	if err != nil {
+		return
+	}
+	tracking := parseTracking(out)
+	for _, b := range branches {
+		if t, ok := tracking[b.Name]; ok && b.Upstream != "" {
+			b.Ahead, b.Behind, b.UpstreamGone = t.ahead, t.behind, t.gone
+		}
+	}
+}
+
+// parseTracking parses "name\x00track" lines, where track is empty, "gone"
+// or a comma-separated "ahead N" / "behind N" pair.
+func parseTracking(out string) map[string]branchTracking {
+	tracking := make(map[string]branchTracking)
+	for _, line := range strings.Split(out, "\n") {
+		name, track, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		var t branchTracking
+		for _, part := range strings.Split(track, ",") {
+			field, count, _ := strings.Cut(strings.TrimSpace(part), " ")
+			switch field {
+			case "gone":
+				t.gone = true
+			case "ahead":
+				t.ahead, _ = strconv.Atoi(count)
+			case "behind":
+				t.behind, _ = strconv.Atoi(count)
+			}
+		}
+		tracking[name] = t
+	}
+	return tracking
+}
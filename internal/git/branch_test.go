@@ -0,0 +1,155 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func findBranch(t *testing.T, repo *git.Repository, name string) *git.Branch {
+	t.Helper()
+	branches, err := repo.GetBranches()
+	if err != nil {
+		t.Fatalf("GetBranches: %v", err)
+	}
+	for _, b := range branches {
+		if b.Name == name {
+			return b
+		}
+	}
+	return nil
+}
+
+func TestBranchLifecycle(t *testing.T) {
+	f := gittest.NewFixture(t)
+	first := f.Commit("first")
+	f.Commit("second")
+	repo := openFixture(t, f)
+
+	if err := repo.CreateBranch("topic", first, false); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if b := findBranch(t, repo, "topic"); b == nil || b.Hash != first || b.IsCurrent {
+		t.Fatalf("topic = %+v, want it at %s and not checked out", b, first)
+	}
+
+	if err := repo.RenameBranch("topic", "renamed"); err != nil {
+		t.Fatalf("RenameBranch: %v", err)
+	}
+	if findBranch(t, repo, "topic") != nil || findBranch(t, repo, "renamed") == nil {
+		t.Fatal("rename did not move the branch")
+	}
+
+	// "renamed" is behind main, so it is merged and deletes without force.
+	if err := repo.DeleteBranch("renamed", false); err != nil {
+		t.Fatalf("DeleteBranch: %v", err)
+	}
+
+	if err := repo.CreateBranch("unmerged", "HEAD", true); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+	if b := findBranch(t, repo, "unmerged"); b == nil || !b.IsCurrent {
+		t.Fatalf("unmerged = %+v, want it checked out", b)
+	}
+	f.Commit("only on unmerged")
+	f.Git("checkout", "-q", "main")
+
+	err := repo.DeleteBranch("unmerged", false)
+	if !git.IsErrorKind(err, git.ErrorKindBranchNotMerged) {
+		t.Fatalf("expected a not-merged error, got %v", err)
+	}
+	if err := repo.DeleteBranch("unmerged", true); err != nil {
+		t.Fatalf("force DeleteBranch: %v", err)
+	}
+	if findBranch(t, repo, "unmerged") != nil {
+		t.Error("unmerged branch still exists")
+	}
+}
+
+func TestBranchUpstream(t *testing.T) {
+	remote := gittest.NewFixture(t)
+	remote.Commit("initial")
+	remote.Git("branch", "feature")
+
+	f := gittest.NewFixture(t)
+	f.Git("remote", "add", "origin", remote.Dir)
+	f.Git("fetch", "-q", "origin")
+	f.Git("checkout", "-q", "-b", "feature", "origin/feature")
+	f.Git("branch", "--unset-upstream")
+	repo := openFixture(t, f)
+
+	if err := repo.SetUpstream("feature", "origin/feature"); err != nil {
+		t.Fatalf("SetUpstream: %v", err)
+	}
+	b := findBranch(t, repo, "feature")
+	if b == nil || b.Upstream != "origin/feature" || b.UpstreamRemote != "origin" || b.UpstreamBranch() != "feature" {
+		t.Fatalf("feature = %+v, want upstream origin/feature", b)
+	}
+
+	// A tag of the same name on the remote is left alone.
+	remote.Git("tag", "feature")
+	if err := repo.DeleteRemoteBranch(b); err != nil {
+		t.Fatalf("DeleteRemoteBranch: %v", err)
+	}
+	if out := remote.Git("branch", "--list", "feature"); out != "" {
+		t.Errorf("remote branch still exists: %q", out)
+	}
+	if out := remote.Git("tag", "--list", "feature"); out != "feature" {
+		t.Errorf("remote tag = %q, want it kept", out)
+	}
+
+	if err := repo.UnsetUpstream("feature"); err != nil {
+		t.Fatalf("UnsetUpstream: %v", err)
+	}
+	if b := findBranch(t, repo, "feature"); b.Upstream != "" {
+		t.Errorf("upstream = %q after unset", b.Upstream)
+	}
+	if err := repo.DeleteRemoteBranch(findBranch(t, repo, "feature")); err != git.ErrNoUpstream {
+		t.Errorf("expected ErrNoUpstream, got %v", err)
+	}
+}
+
+func TestDeleteRemoteBranchOfLocalUpstream(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.Commit("initial")
+	f.Git("branch", "--track", "feature", "main")
+	f.Git("checkout", "-q", "-b", "other")
+	repo := openFixture(t, f)
+
+	b := findBranch(t, repo, "feature")
+	if b == nil || b.UpstreamRemote != "." || b.HasRemoteUpstream() {
+		t.Fatalf("feature = %+v, want a local upstream", b)
+	}
+	if err := repo.DeleteRemoteBranch(b); err != git.ErrNoUpstream {
+		t.Errorf("DeleteRemoteBranch = %v, want ErrNoUpstream", err)
+	}
+	if findBranch(t, repo, "main") == nil {
+		t.Error("main was deleted")
+	}
+}
+
+func TestBranchTracking(t *testing.T) {
+	upstream := gittest.NewFixture(t)
+	upstream.Commit("initial")
+	upstream.Git("branch", "old")
+
+	f := gittest.NewFixture(t)
+	f.Git("remote", "add", "origin", upstream.Dir)
+	f.Git("fetch", "-q", "origin")
+	f.Git("checkout", "-q", "-b", "main", "--track", "origin/main")
+	f.Git("branch", "--track", "old", "origin/old")
+	f.Commit("local 1")
+	f.Commit("local 2")
+	upstream.Commit("remote 1")
+	upstream.Git("branch", "-D", "old")
+	f.Git("fetch", "-q", "--prune", "origin")
+	repo := openFixture(t, f)
+
+	if b := findBranch(t, repo, "main"); b == nil || b.Ahead != 2 || b.Behind != 1 || b.UpstreamGone {
+		t.Errorf("main = %+v, want 2 ahead and 1 behind origin/main", b)
+	}
+	if b := findBranch(t, repo, "old"); b == nil || !b.UpstreamGone || b.Upstream != "origin/old" {
+		t.Errorf("old = %+v, want its upstream origin/old gone", b)
+	}
+}
@@ -0,0 +1,93 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Sequencer identifies a multi-commit cherry-pick or revert, which git runs
+// one commit at a time and can stop partway through on conflicts, or a
+// merge stopped on conflicts. A merge can be continued or aborted but not
+// skipped.
+type Sequencer string
+
+const (
+	SequencerNone       Sequencer = ""
+	SequencerCherryPick Sequencer = "cherry-pick"
+	SequencerRevert     Sequencer = "revert"
+	SequencerMerge      Sequencer = "merge"
+)
+
+// ErrMergeNeedsMainline is returned when a merge commit is reverted together
+// with other commits, since each merge needs its own parent choice.
+var ErrMergeNeedsMainline = errors.New("revert merge commits on their own to choose a parent")
+
+// CherryPick applies the changes of the given commits on top of HEAD, in the
+// order given (oldest first for a range of history). It stops on the first
+// conflict; SequencerInProgress then reports SequencerCherryPick.
+func (r *Repository) CherryPick(hashes ...string) error {
+	args := append([]string{"cherry-pick"}, hashes...)
+	_, err := r.runWith(reflogEnv("cherry-pick"), nil, args...)
+	return err
+}
+
+// Revert creates commits undoing the given commits, in the order given
+// (newest first to unwind history). Merge commits must be reverted with
+// RevertMerge.
+func (r *Repository) Revert(hashes ...string) error {
+	args := append([]string{"revert", "--no-edit"}, hashes...)
+	_, err := r.runWith(reflogEnv("revert"), nil, args...)
+	return err
+}
+
+// RevertMerge reverts a merge commit relative to its mainline parent
+// (1-based, as in git revert -m): the changes brought in by the other
+// parents are undone.
+func (r *Repository) RevertMerge(hash string, mainline int) error {
+	_, err := r.runWith(reflogEnv("revert"), nil, "revert", "--no-edit", "-m", strconv.Itoa(mainline), hash)
+	return err
+}
+
+// SequencerInProgress reports which cherry-pick, revert or merge, if any,
+// is stopped waiting for conflicts to be resolved.
+func (r *Repository) SequencerInProgress() (Sequencer, error) {
+	gitDir, err := r.GitDir()
+	if err != nil {
+		return SequencerNone, err
+	}
+	switch {
+	case fileExists(filepath.Join(gitDir, "CHERRY_PICK_HEAD")):
+		return SequencerCherryPick, nil
+	case fileExists(filepath.Join(gitDir, "REVERT_HEAD")):
+		return SequencerRevert, nil
+	case fileExists(filepath.Join(gitDir, "MERGE_HEAD")):
+		return SequencerMerge, nil
+	}
+	return SequencerNone, nil
+}
+
+// SequencerContinue commits the resolved commit and carries on with the
+// rest of the sequence, keeping git's default message.
+func (r *Repository) SequencerContinue(s Sequencer) error {
+	_, err := r.runWith(append(noEditorEnv(), reflogEnv(string(s))...), nil, string(s), "--continue")
+	return err
+}
+
+// SequencerSkip drops the commit the sequence stopped on and carries on.
+func (r *Repository) SequencerSkip(s Sequencer) error {
+	_, err := r.runWith(append(noEditorEnv(), reflogEnv(string(s))...), nil, string(s), "--skip")
+	return err
+}
+
+// SequencerAbort cancels the sequence and returns to the pre-sequence HEAD.
+func (r *Repository) SequencerAbort(s Sequencer) error {
+	_, err := r.run(string(s), "--abort")
+	return err
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,116 @@
+package git_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+// branchFixture creates main with a base commit and a topic branch adding
+// one.txt and two.txt in separate commits, then returns to main.
+func branchFixture(t *testing.T) (*gittest.Fixture, *git.Repository, []string) {
+	t.Helper()
+	f := gittest.NewFixture(t)
+	f.WriteFile("base.txt", "base\n")
+	f.Commit("base")
+	f.Git("checkout", "-q", "-b", "topic")
+	f.WriteFile("one.txt", "one\n")
+	one := f.Commit("one")
+	f.WriteFile("two.txt", "two\n")
+	two := f.Commit("two")
+	f.Git("checkout", "-q", "main")
+	return f, openFixture(t, f), []string{one, two}
+}
+
+func TestCherryPick(t *testing.T) {
+	f, repo, topic := branchFixture(t)
+
+	if err := repo.CherryPick(topic...); err != nil {
+		t.Fatalf("CherryPick: %v", err)
+	}
+	if got, want := subjects(f), "two,one,base"; got != want {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+}
+
+func TestRevert(t *testing.T) {
+	f, repo, topic := branchFixture(t)
+	f.Git("merge", "-q", "--ff-only", "topic")
+
+	if err := repo.Revert(topic[1], topic[0]); err != nil {
+		t.Fatalf("Revert: %v", err)
+	}
+	if got, want := subjects(f), `Revert "one",Revert "two",two,one,base`; got != want {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+	if files := f.Git("ls-files"); files != "base.txt" {
+		t.Errorf("files after revert = %q, want only base.txt", files)
+	}
+}
+
+func TestRevertMerge(t *testing.T) {
+	f, repo, _ := branchFixture(t)
+	f.WriteFile("main.txt", "main\n")
+	f.Commit("main")
+	f.Git("merge", "-q", "--no-ff", "-m", "merge topic", "topic")
+
+	if err := repo.Revert("HEAD"); err == nil {
+		t.Fatal("expected reverting a merge without a mainline to fail")
+	}
+	if err := repo.RevertMerge(f.Git("rev-parse", "HEAD"), 1); err != nil {
+		t.Fatalf("RevertMerge: %v", err)
+	}
+	if files := f.Git("ls-files"); files != "base.txt\nmain.txt" {
+		t.Errorf("files after reverting the merge = %q, want base.txt and main.txt", files)
+	}
+}
+
+func TestCherryPickConflict(t *testing.T) {
+	f, repo, topic := branchFixture(t)
+	f.WriteFile("one.txt", "conflicting\n")
+	f.Commit("conflicting one")
+
+	err := repo.CherryPick(topic...)
+	if !git.IsErrorKind(err, git.ErrorKindMergeConflict) {
+		t.Fatalf("expected a merge conflict, got %v", err)
+	}
+	state, err := repo.SequencerInProgress()
+	if err != nil || state != git.SequencerCherryPick {
+		t.Fatalf("SequencerInProgress = %q, %v; want %q", state, err, git.SequencerCherryPick)
+	}
+
+	// Resolving and continuing applies the rest of the sequence.
+	f.WriteFile("one.txt", "resolved\n")
+	f.Git("add", "one.txt")
+	if err := repo.SequencerContinue(state); err != nil {
+		t.Fatalf("SequencerContinue: %v", err)
+	}
+	if got, want := subjects(f), "two,one,conflicting one,base"; got != want {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+	if state, _ := repo.SequencerInProgress(); state != git.SequencerNone {
+		t.Errorf("expected the sequence to be finished, got %q", state)
+	}
+}
+
+func TestCherryPickAbort(t *testing.T) {
+	f, repo, topic := branchFixture(t)
+	f.WriteFile("one.txt", "conflicting\n")
+	head := f.Commit("conflicting one")
+
+	var gitErr *git.GitError
+	if err := repo.CherryPick(topic...); !errors.As(err, &gitErr) {
+		t.Fatalf("expected a GitError, got %v", err)
+	}
+	if err := repo.SequencerAbort(git.SequencerCherryPick); err != nil {
+		t.Fatalf("SequencerAbort: %v", err)
+	}
+	if got := f.Git("rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD = %s after abort, want %s", got, head)
+	}
+	if state, _ := repo.SequencerInProgress(); state != git.SequencerNone {
+		t.Errorf("expected no sequence after abort, got %q", state)
+	}
+}
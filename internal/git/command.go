@@ -0,0 +1,65 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// run executes git with args in the repository root and returns its stdout.
+// A non-zero exit is reported as a *GitError carrying the captured output.
+func (r *Repository) run(args ...string) (string, error) {
+	return r.runWith(nil, nil, args...)
+}
+
+// runWith is like run but appends env (KEY=VALUE entries) to the inherited
+// environment and feeds stdin to the command if it is non-nil.
+func (r *Repository) runWith(env []string, stdin io.Reader, args ...string) (string, error) {
+	return r.runCommand(Command{Args: args, Env: env, Stdin: stdin})
+}
+
+// runStreaming is like run but also copies the command's output to out as
+// it is written.
+func (r *Repository) runStreaming(out io.Writer, args ...string) (string, error) {
+	return r.runCommand(Command{Args: args, Output: out})
+}
+
+// runCommand runs cmd in the repository root. The helpers above fill in
+// the common cases.
+func (r *Repository) runCommand(cmd Command) (string, error) {
+	cmd.Dir = r.path
+	args := cmd.Args
+	res, err := r.runner.Run(context.Background(), cmd)
+	stdout := string(res.Stdout)
+	if err != nil {
+		return stdout, &GitError{
+			Args:     args,
+			ExitCode: -1,
+			Stdout:   stdout,
+			Stderr:   string(res.Stderr),
+			Err:      err,
+		}
+	}
+	if res.ExitCode != 0 {
+		return stdout, &GitError{
+			Args:     args,
+			ExitCode: res.ExitCode,
+			Stdout:   stdout,
+			Stderr:   string(res.Stderr),
+			Err:      fmt.Errorf("exit status %d", res.ExitCode),
+		}
+	}
+	return stdout, nil
+}
+
+// exitCode returns the exit code carried by a *GitError, or -1 for any other
+// error. Used by commands like `diff --quiet` that signal results through
+// their exit status.
+func exitCode(err error) int {
+	var gitErr *GitError
+	if errors.As(err, &gitErr) {
+		return gitErr.ExitCode
+	}
+	return -1
+}
@@ -0,0 +1,254 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConflictChoice selects how a conflict hunk or file is resolved.
+type ConflictChoice int
+
+const (
+	ChooseNone   ConflictChoice = iota // leave the conflict markers in place
+	ChooseOurs                         // keep the version of HEAD
+	ChooseTheirs                       // keep the version being merged in
+	ChooseBoth                         // keep ours followed by theirs
+)
+
+// ErrNoConflictMarkers is returned when a hunk is resolved in a file that
+// has no conflict markers left.
+var ErrNoConflictMarkers = errors.New("no conflict markers in file")
+
+// ConflictHunk is one <<<<<<< ... >>>>>>> block of a conflicted file. The
+// line slices keep their line endings.
+type ConflictHunk struct {
+	Line        int // 1-based line of the <<<<<<< marker
+	OursLabel   string
+	TheirsLabel string
+	Ours        []string
+	Base        []string // only with merge.conflictStyle diff3 or zdiff3
+	Theirs      []string
+	HasBase     bool
+}
+
+// ConflictedContent is a file split into its conflict hunks and the text
+// around them.
+type ConflictedContent struct {
+	Hunks []ConflictHunk
+
+	// text[i] precedes Hunks[i]; the last entry follows the last hunk.
+	text [][]string
+	// raw holds the marker block of each hunk verbatim, for hunks that
+	// stay unresolved.
+	raw [][]string
+}
+
+// ParseConflicts splits file content at its conflict markers. A block that
+// is not terminated is treated as ordinary text.
+func ParseConflicts(content string) *ConflictedContent {
+	c := &ConflictedContent{}
+	var text []string
+	lines := strings.SplitAfter(content, "\n")
+	for i := 0; i < len(lines); i++ {
+		if !isMarker(lines[i], "<<<<<<<") {
+			text = append(text, lines[i])
+			continue
+		}
+		hunk, end, ok := parseHunk(lines, i)
+		if !ok {
+			text = append(text, lines[i])
+			continue
+		}
+		c.Hunks = append(c.Hunks, hunk)
+		c.text = append(c.text, text)
+		c.raw = append(c.raw, lines[i:end+1])
+		text = nil
+		i = end
+	}
+	c.text = append(c.text, text)
+	return c
+}
+
+// parseHunk parses the block starting at the <<<<<<< marker lines[start],
+// returning the index of its >>>>>>> marker.
+func parseHunk(lines []string, start int) (ConflictHunk, int, bool) {
+	hunk := ConflictHunk{Line: start + 1, OursLabel: markerLabel(lines[start])}
+	section := &hunk.Ours
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		switch {
+		case isMarker(line, "<<<<<<<"):
+			return hunk, 0, false
+		case isMarker(line, "|||||||") && section == &hunk.Ours:
+			hunk.HasBase = true
+			section = &hunk.Base
+		case isMarker(line, "=======") && section != &hunk.Theirs:
+			section = &hunk.Theirs
+		case isMarker(line, ">>>>>>>") && section == &hunk.Theirs:
+			hunk.TheirsLabel = markerLabel(line)
+			return hunk, i, true
+		default:
+			*section = append(*section, line)
+		}
+	}
+	return hunk, 0, false
+}
+
+// isMarker reports whether line is a conflict marker of the given kind:
+// the seven characters alone or followed by a space and a label.
+func isMarker(line, marker string) bool {
+	line = strings.TrimRight(line, "\r\n")
+	return line == marker || strings.HasPrefix(line, marker+" ")
+}
+
+func markerLabel(line string) string {
+	return strings.TrimSpace(strings.TrimRight(line, "\r\n")[7:])
+}
+
+// Resolve rebuilds the content with choices[i] applied to Hunks[i]. Hunks
+// without a choice, or with ChooseNone, keep their markers.
+func (c *ConflictedContent) Resolve(choices []ConflictChoice) string {
+	var b strings.Builder
+	for i, hunk := range c.Hunks {
+		writeLines(&b, c.text[i])
+		choice := ChooseNone
+		if i < len(choices) {
+			choice = choices[i]
+		}
+		switch choice {
+		case ChooseOurs:
+			writeLines(&b, hunk.Ours)
+		case ChooseTheirs:
+			writeLines(&b, hunk.Theirs)
+		case ChooseBoth:
+			writeLines(&b, hunk.Ours)
+			writeLines(&b, hunk.Theirs)
+		default:
+			writeLines(&b, c.raw[i])
+		}
+	}
+	writeLines(&b, c.text[len(c.text)-1])
+	return b.String()
+}
+
+func writeLines(b *strings.Builder, lines []string) {
+	for _, line := range lines {
+		b.WriteString(line)
+	}
+}
+
+// ConflictedFiles returns the unmerged paths of the working tree.
+func (r *Repository) ConflictedFiles() ([]ChangedFile, error) {
+	files, err := r.GetWorkingTreeFiles()
+	if err != nil {
+		return nil, err
+	}
+	var conflicted []ChangedFile
+	for _, f := range files {
+		if f.Conflict != "" {
+			conflicted = append(conflicted, f)
+		}
+	}
+	return conflicted, nil
+}
+
+// ReadConflicts parses the conflict markers of a working tree file. A file
+// that was deleted on one side may not exist; it then has no hunks.
+func (r *Repository) ReadConflicts(path string) (*ConflictedContent, error) {
+	data, err := os.ReadFile(filepath.Join(r.path, path))
+	if errors.Is(err, os.ErrNotExist) {
+		return ParseConflicts(""), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseConflicts(string(data)), nil
+}
+
+// ResolveHunk resolves one conflict hunk of a working tree file in place
+// and returns how many hunks are left. The file is not staged.
+func (r *Repository) ResolveHunk(path string, hunk int, choice ConflictChoice) (int, error) {
+	c, err := r.ReadConflicts(path)
+	if err != nil {
+		return 0, err
+	}
+	if hunk < 0 || hunk >= len(c.Hunks) {
+		return len(c.Hunks), ErrNoConflictMarkers
+	}
+	choices := make([]ConflictChoice, len(c.Hunks))
+	choices[hunk] = choice
+	if err := r.writeWorkingFile(path, c.Resolve(choices)); err != nil {
+		return 0, err
+	}
+	return len(c.Hunks) - 1, nil
+}
+
+// ResolveFile resolves every conflict of a path the same way and stages
+// it. Ours and theirs take that side's whole version, or delete the path
+// if that side deleted it; both keeps ours then theirs for each hunk.
+func (r *Repository) ResolveFile(path string, choice ConflictChoice) error {
+	switch choice {
+	case ChooseOurs, ChooseTheirs:
+		stages, err := r.conflictStages(path)
+		if err != nil {
+			return err
+		}
+		stage, flag := 2, "--ours"
+		if choice == ChooseTheirs {
+			stage, flag = 3, "--theirs"
+		}
+		if !stages[stage] {
+			_, err := r.run("rm", "-q", "--", path)
+			return err
+		}
+		if _, err := r.run("checkout", flag, "--", path); err != nil {
+			return err
+		}
+	case ChooseBoth:
+		c, err := r.ReadConflicts(path)
+		if err != nil {
+			return err
+		}
+		if len(c.Hunks) > 0 {
+			choices := make([]ConflictChoice, len(c.Hunks))
+			for i := range choices {
+				choices[i] = ChooseBoth
+			}
+			if err := r.writeWorkingFile(path, c.Resolve(choices)); err != nil {
+				return err
+			}
+		}
+	}
+	return r.MarkResolved(path)
+}
+
+// MarkResolved stages a conflicted path as it is in the working tree,
+// including its deletion.
+func (r *Repository) MarkResolved(path string) error {
+	_, err := r.run("add", "-A", "--", path)
+	return err
+}
+
+// conflictStages reports which index stages (1 base, 2 ours, 3 theirs)
+// exist for an unmerged path.
+func (r *Repository) conflictStages(path string) (map[int]bool, error) {
+	out, err := r.run("ls-files", "-u", "-z", "--", path)
+	if err != nil {
+		return nil, err
+	}
+	stages := make(map[int]bool)
+	for _, entry := range strings.Split(out, "\x00") {
+		// "<mode> <object> <stage>\t<path>"
+		info, _, ok := strings.Cut(entry, "\t")
+		if fields := strings.Fields(info); ok && len(fields) == 3 && len(fields[2]) == 1 {
+			stages[int(fields[2][0]-'0')] = true
+		}
+	}
+	return stages, nil
+}
+
+func (r *Repository) writeWorkingFile(path, content string) error {
+	return os.WriteFile(filepath.Join(r.path, path), []byte(content), 0o644)
+}
@@ -0,0 +1,149 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+const conflicted = `top
+<<<<<<< HEAD
+ours 1
+=======
+theirs 1
+>>>>>>> topic
+middle
+<<<<<<< HEAD
+ours 2
+||||||| base
+base 2
+=======
+theirs 2
+>>>>>>> topic
+bottom
+`
+
+func TestParseConflicts(t *testing.T) {
+	c := git.ParseConflicts(conflicted)
+	want := []git.ConflictHunk{
+		{Line: 2, OursLabel: "HEAD", TheirsLabel: "topic", Ours: []string{"ours 1\n"}, Theirs: []string{"theirs 1\n"}},
+		{Line: 8, OursLabel: "HEAD", TheirsLabel: "topic", Ours: []string{"ours 2\n"}, Base: []string{"base 2\n"},
+			Theirs: []string{"theirs 2\n"}, HasBase: true},
+	}
+	if !reflect.DeepEqual(c.Hunks, want) {
+		t.Fatalf("hunks:\n got %+v\nwant %+v", c.Hunks, want)
+	}
+
+	if got := c.Resolve(nil); got != conflicted {
+		t.Errorf("Resolve(nil) changed the content:\n%s", got)
+	}
+	got := c.Resolve([]git.ConflictChoice{git.ChooseBoth, git.ChooseTheirs})
+	if want := "top\nours 1\ntheirs 1\nmiddle\ntheirs 2\nbottom\n"; got != want {
+		t.Errorf("Resolve = %q, want %q", got, want)
+	}
+	got = c.Resolve([]git.ConflictChoice{git.ChooseOurs})
+	if want := "top\nours 1\nmiddle\n" + conflicted[len("top\n<<<<<<< HEAD\nours 1\n=======\ntheirs 1\n>>>>>>> topic\nmiddle\n"):]; got != want {
+		t.Errorf("Resolve = %q, want %q", got, want)
+	}
+}
+
+func TestParseConflictsUnterminated(t *testing.T) {
+	content := "a\n<<<<<<< HEAD\nb\n"
+	c := git.ParseConflicts(content)
+	if len(c.Hunks) != 0 {
+		t.Fatalf("hunks = %+v, want none", c.Hunks)
+	}
+	if got := c.Resolve(nil); got != content {
+		t.Errorf("Resolve = %q, want the content unchanged", got)
+	}
+}
+
+// conflictFixture merges topic into main with both sides changing two
+// separate lines of file.txt and main deleting gone.txt, which topic
+// modifies.
+func conflictFixture(t *testing.T) (*gittest.Fixture, *git.Repository) {
+	t.Helper()
+	f := gittest.NewFixture(t)
+	f.WriteFile("file.txt", "1\n2\n3\n4\n5\n6\n7\n8\n")
+	f.WriteFile("gone.txt", "gone\n")
+	f.Commit("base")
+	f.Git("checkout", "-q", "-b", "topic")
+	f.WriteFile("file.txt", "1 topic\n2\n3\n4\n5\n6\n7\n8 topic\n")
+	f.WriteFile("gone.txt", "changed\n")
+	f.Commit("topic")
+	f.Git("checkout", "-q", "main")
+	f.WriteFile("file.txt", "1 main\n2\n3\n4\n5\n6\n7\n8 main\n")
+	f.Remove("gone.txt")
+	f.Commit("main")
+	repo := openFixture(t, f)
+	if _, err := repo.Merge("topic", git.MergeOptions{}); err == nil {
+		t.Fatal("expected the merge to conflict")
+	}
+	return f, repo
+}
+
+func TestResolveConflicts(t *testing.T) {
+	f, repo := conflictFixture(t)
+
+	files, err := repo.ConflictedFiles()
+	if err != nil {
+		t.Fatalf("ConflictedFiles: %v", err)
+	}
+	codes := map[string]string{}
+	for _, file := range files {
+		codes[file.Path] = file.Conflict
+	}
+	if want := map[string]string{"file.txt": "UU", "gone.txt": "DU"}; !reflect.DeepEqual(codes, want) {
+		t.Fatalf("conflicts = %v, want %v", codes, want)
+	}
+
+	remaining, err := repo.ResolveHunk("file.txt", 1, git.ChooseTheirs)
+	if err != nil || remaining != 1 {
+		t.Fatalf("ResolveHunk = %d, %v; want 1 hunk left", remaining, err)
+	}
+	remaining, err = repo.ResolveHunk("file.txt", 0, git.ChooseBoth)
+	if err != nil || remaining != 0 {
+		t.Fatalf("ResolveHunk = %d, %v; want no hunks left", remaining, err)
+	}
+	data, _ := os.ReadFile(filepath.Join(f.Dir, "file.txt"))
+	if want := "1 main\n1 topic\n2\n3\n4\n5\n6\n7\n8 topic\n"; string(data) != want {
+		t.Errorf("file.txt = %q, want %q", data, want)
+	}
+	if err := repo.MarkResolved("file.txt"); err != nil {
+		t.Fatalf("MarkResolved: %v", err)
+	}
+
+	// Ours deleted gone.txt, so taking our side deletes it.
+	if err := repo.ResolveFile("gone.txt", git.ChooseOurs); err != nil {
+		t.Fatalf("ResolveFile: %v", err)
+	}
+	if files, _ := repo.ConflictedFiles(); len(files) != 0 {
+		t.Fatalf("still conflicted: %+v", files)
+	}
+	if err := repo.SequencerContinue(git.SequencerMerge); err != nil {
+		t.Fatalf("continue: %v", err)
+	}
+	if got := f.Git("ls-files"); got != "file.txt" {
+		t.Errorf("files after merge = %q, want only file.txt", got)
+	}
+}
+
+func TestResolveFileTheirs(t *testing.T) {
+	f, repo := conflictFixture(t)
+
+	for _, path := range []string{"file.txt", "gone.txt"} {
+		if err := repo.ResolveFile(path, git.ChooseTheirs); err != nil {
+			t.Fatalf("ResolveFile(%s): %v", path, err)
+		}
+	}
+	if got := f.Git("show", ":file.txt"); got != "1 topic\n2\n3\n4\n5\n6\n7\n8 topic" {
+		t.Errorf("staged file.txt = %q, want topic's version", got)
+	}
+	if got := f.Git("show", ":gone.txt"); got != "changed" {
+		t.Errorf("staged gone.txt = %q, want topic's version", got)
+	}
+}
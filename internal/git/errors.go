@@ -0,0 +1,168 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorKind classifies a failed git command by what went wrong, so the UI
+// can explain the failure and suggest a next step.
+type ErrorKind int
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	ErrorKindNonFastForward
+	ErrorKindNoUpstream
+	ErrorKindAuthFailed
+	ErrorKindMergeConflict
+	ErrorKindLockHeld
+	ErrorKindHookRejected
+	ErrorKindBranchNotMerged
+	ErrorKindWorktreeDirty
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNonFastForward:
+		return "non-fast-forward"
+	case ErrorKindNoUpstream:
+		return "no upstream"
+	case ErrorKindAuthFailed:
+		return "authentication failed"
+	case ErrorKindMergeConflict:
+		return "merge conflict"
+	case ErrorKindLockHeld:
+		return "lock held"
+	case ErrorKindHookRejected:
+		return "hook rejected"
+	case ErrorKindBranchNotMerged:
+		return "branch not merged"
+	case ErrorKindWorktreeDirty:
+		return "worktree dirty"
+	default:
+		return "unknown"
+	}
+}
+
+// GitError describes a git invocation that exited unsuccessfully. It keeps
+// the full command line and captured output so the failure can be explained
+// instead of surfacing a bare "exit status 1".
+type GitError struct {
+	Args     []string // arguments passed to git, without the leading "git"
+	ExitCode int      // -1 if git could not be started
+	Stdout   string
+	Stderr   string
+	Err      error // underlying error from os/exec
+}
+
+func (e *GitError) Error() string {
+	return fmt.Sprintf("%s: %s", e.CommandLine(), e.Summary())
+}
+
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// CommandLine returns the command as it would be typed in a shell.
+func (e *GitError) CommandLine() string {
+	parts := []string{"git"}
+	for _, a := range e.Args {
+		if a == "" || strings.ContainsAny(a, " \t\n'\"") {
+			a = "'" + strings.ReplaceAll(a, "'", `'\''`) + "'"
+		}
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
+
+// Output returns the combined stderr and stdout of the command.
+func (e *GitError) Output() string {
+	out := strings.TrimRight(e.Stderr, "\n")
+	if stdout := strings.TrimRight(e.Stdout, "\n"); stdout != "" {
+		if out != "" {
+			out += "\n"
+		}
+		out += stdout
+	}
+	return out
+}
+
+// Summary returns the most informative single line of the command's output:
+// the first "fatal:"/"error:"/conflict line if there is one, otherwise the
+// first line that is not a hint. Falls back to the exec error.
+func (e *GitError) Summary() string {
+	lines := strings.Split(e.Output(), "\n")
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "fatal: "):
+			return strings.TrimPrefix(line, "fatal: ")
+		case strings.HasPrefix(line, "error: "):
+			return strings.TrimPrefix(line, "error: ")
+		case strings.HasPrefix(line, "CONFLICT"), strings.HasPrefix(line, "! [rejected]"),
+			strings.HasPrefix(line, "! [remote rejected]"):
+			return line
+		}
+	}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "hint:") {
+			return line
+		}
+	}
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("exit status %d", e.ExitCode)
+}
+
+// Kind classifies the failure from the command's output.
+func (e *GitError) Kind() ErrorKind {
+	out := strings.ToLower(e.Output())
+	containsAny := func(patterns ...string) bool {
+		for _, p := range patterns {
+			if strings.Contains(out, p) {
+				return true
+			}
+		}
+		return false
+	}
+
+	switch {
+	case containsAny(".lock': file exists", "another git process seems to be running",
+		"cannot lock ref", "unable to create '"):
+		return ErrorKindLockHeld
+	// A hook's own output can mention conflicts, e.g. a check for
+	// leftover conflict markers, so hooks are recognised first.
+	case containsAny("hook declined", "hook failed", "hook exited", "- hook id:",
+		"pre-commit hook", "commit-msg hook", "pre-push hook", "pre-receive hook"):
+		return ErrorKindHookRejected
+	case containsAny("conflict (", "automatic merge failed", "could not apply",
+		"fix conflicts", "resolve all conflicts", "you have unmerged files",
+		"needs merge", "after resolving the conflicts"):
+		return ErrorKindMergeConflict
+	case containsAny("is not fully merged"):
+		return ErrorKindBranchNotMerged
+	case containsAny("contains modified or untracked files"):
+		return ErrorKindWorktreeDirty
+	case containsAny("has no upstream branch", "no tracking information",
+		"no upstream configured"):
+		return ErrorKindNoUpstream
+	case containsAny("non-fast-forward", "(fetch first)", "updates were rejected",
+		"tip of your current branch is behind", "(stale info)"):
+		return ErrorKindNonFastForward
+	case containsAny("authentication failed", "permission denied (publickey",
+		"could not read username", "could not read password",
+		"terminal prompts disabled", "returned error: 401", "returned error: 403",
+		"host key verification failed"):
+		return ErrorKindAuthFailed
+	}
+	return ErrorKindUnknown
+}
+
+// IsErrorKind reports whether err is (or wraps) a *GitError of the given kind.
+func IsErrorKind(err error, kind ErrorKind) bool {
+	var gitErr *GitError
+	return errors.As(err, &gitErr) && gitErr.Kind() == kind
+}
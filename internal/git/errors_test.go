@@ -0,0 +1,73 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+func TestGitErrorKind(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   git.ErrorKind
+	}{
+		{"index lock", "fatal: Unable to create '/repo/.git/index.lock': File exists.\n\n" +
+			"Another git process seems to be running in this repository, e.g.\n" +
+			"an editor opened by 'git commit'.", git.ErrorKindLockHeld},
+		{"ref lock", "error: cannot lock ref 'refs/heads/main': is at 1a2b3c4 but expected 5d6e7f8", git.ErrorKindLockHeld},
+		{"lock during a conflicted rebase", "error: could not apply 1a2b3c4... two\n" +
+			"fatal: Unable to create '/repo/.git/index.lock': File exists.", git.ErrorKindLockHeld},
+
+		{"pre-commit framework hook about conflicts", "check for merge conflicts................................Failed\n" +
+			"- hook id: check-merge-conflict\n- exit code: 1\n\n" +
+			"a.txt:3: Merge conflict string '<<<<<<<' found", git.ErrorKindHookRejected},
+		{"hook running a conflicting merge", "husky - pre-commit hook exited with code 1 (error)\n" +
+			"CONFLICT (content): Merge conflict in a.txt", git.ErrorKindHookRejected},
+		{"pre-receive", "To origin\n ! [remote rejected] main -> main (pre-receive hook declined)\n" +
+			"error: failed to push some refs to 'origin'", git.ErrorKindHookRejected},
+		{"pre-push", "error: failed to push some refs to 'origin'\n" +
+			"error: failed to run pre-push hook", git.ErrorKindHookRejected},
+
+		{"merge conflict", "Auto-merging a.txt\nCONFLICT (content): Merge conflict in a.txt\n" +
+			"Automatic merge failed; fix conflicts and then commit the result.", git.ErrorKindMergeConflict},
+		{"rebase conflict", "error: could not apply 1a2b3c4... two\n" +
+			"hint: Resolve all conflicts manually, mark them as resolved with\n" +
+			"hint: \"git add/rm <conflicted_files>\", then run \"git rebase --continue\".", git.ErrorKindMergeConflict},
+		{"unmerged files", "error: Committing is not possible because you have unmerged files.", git.ErrorKindMergeConflict},
+
+		{"branch not merged", "error: The branch 'feature' is not fully merged.\n" +
+			"If you are sure you want to delete it, run 'git branch -D feature'.", git.ErrorKindBranchNotMerged},
+		{"worktree dirty", "fatal: '../wt' contains modified or untracked files, use --force to delete it", git.ErrorKindWorktreeDirty},
+
+		{"push without upstream", "fatal: The current branch feature has no upstream branch.\n" +
+			"To push the current branch and set the remote as upstream, use\n\n" +
+			"    git push --set-upstream origin feature\n", git.ErrorKindNoUpstream},
+		{"pull without upstream", "There is no tracking information for the current branch.\n" +
+			"Please specify which branch you want to merge with.", git.ErrorKindNoUpstream},
+
+		{"non-fast-forward", "To origin\n ! [rejected]        main -> main (non-fast-forward)\n" +
+			"error: failed to push some refs to 'origin'\n" +
+			"hint: Updates were rejected because the tip of your current branch is behind", git.ErrorKindNonFastForward},
+		{"fetch first", "To origin\n ! [rejected]        main -> main (fetch first)\n" +
+			"error: failed to push some refs to 'origin'", git.ErrorKindNonFastForward},
+		{"stale lease", "To /tmp/upstream\n ! [rejected]        main -> main (stale info)\n" +
+			"error: failed to push some refs to '/tmp/upstream'", git.ErrorKindNonFastForward},
+
+		{"https auth", "remote: Invalid username or password.\n" +
+			"fatal: Authentication failed for 'https://example.com/o/r.git/'", git.ErrorKindAuthFailed},
+		{"ssh key", "git@example.com: Permission denied (publickey).\n" +
+			"fatal: Could not read from remote repository.", git.ErrorKindAuthFailed},
+		{"no prompt", "fatal: could not read Username for 'https://example.com': terminal prompts disabled", git.ErrorKindAuthFailed},
+
+		{"unknown", "fatal: not a git repository (or any of the parent directories): .git", git.ErrorKindUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &git.GitError{Args: []string{"test"}, ExitCode: 1, Stderr: tt.stderr}
+			if got := err.Kind(); got != tt.want {
+				t.Errorf("Kind() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
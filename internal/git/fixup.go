@@ -0,0 +1,215 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FixupKind is the kind of commit CommitFixup creates for a target commit,
+// named after the subject prefix that tells rebase --autosquash what to do
+// with it.
+type FixupKind string
+
+const (
+	// FixupPlain ("fixup!") folds the changes into the target, keeping
+	// the target's message.
+	FixupPlain FixupKind = "fixup"
+	// FixupAmend ("amend!") folds the changes into the target and
+	// replaces its message.
+	FixupAmend FixupKind = "amend"
+	// FixupSquash ("squash!") folds the changes into the target and
+	// combines the messages.
+	FixupSquash FixupKind = "squash"
+)
+
+// ErrNoFixups is returned by PlanAutosquash when no fixup!, squash! or
+// amend! commit on the branch has a target to fold into.
+var ErrNoFixups = errors.New("no fixup commits to squash")
+
+// ErrAutosquashStale is returned by Autosquash when HEAD has moved since
+// the plan was made.
+var ErrAutosquashStale = errors.New("the branch changed since the autosquash preview")
+
+// autosquashScanLimit caps how far back PlanAutosquash looks for fixup
+// commits and their targets.
+const autosquashScanLimit = 1000
+
+// AutosquashPlan is the rebase that rebase --autosquash would run to fold
+// the pending fixups on the current branch into their targets.
+type AutosquashPlan struct {
+	// Base is the oldest commit the rebase rewrites: the first fixup
+	// target. The commits before it are left alone.
+	Base string
+	// Todo lists the commits from Base to HEAD, oldest first, with each
+	// fixup moved after its target, as they appear in the todo list.
+	Todo []RebaseTodoItem
+
+	head string // HEAD the plan was made for
+	text string // git's todo list from Base on, run as is
+}
+
+// CommitFixup commits the staged changes as a fixup of hash. message is
+// the target's new message for FixupAmend and ignored otherwise.
+func (r *Repository) CommitFixup(hash string, kind FixupKind, message string) error {
+	if !r.HasStagedChanges() {
+		return ErrNothingStaged
+	}
+
+	var err error
+	switch kind {
+	case FixupAmend:
+		// git commit --fixup=amend: always opens an editor for the new
+		// message, so the amend! commit is written out by hand.
+		var subject string
+		if subject, err = r.run("log", "-1", "--format=%s", hash); err == nil {
+			_, err = r.runWith(reflogEnv("commit"), nil, "commit", "-m", "amend! "+strings.TrimSpace(subject)+"\n\n"+message)
+		}
+	case FixupSquash:
+		_, err = r.runWith(append(noEditorEnv(), reflogEnv("commit")...), nil, "commit", "--squash="+hash)
+	default:
+		_, err = r.runWith(reflogEnv("commit"), nil, "commit", "--fixup="+hash)
+	}
+	return err
+}
+
+// PlanAutosquash returns the todo list git rebase --autosquash makes for
+// the commits since the last merge on the current branch, without running
+// it: git writes the list and the sequence editor copies it out and then
+// fails, which makes git give up before touching anything.
+func (r *Repository) PlanAutosquash() (*AutosquashPlan, error) {
+	out, err := r.run("log", "--first-parent", fmt.Sprintf("-%d", autosquashScanLimit), "--format="+logFormat, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	commits := parseLog(out, nil)
+	if len(commits) == 0 {
+		return nil, ErrNoFixups
+	}
+	upstream := ""
+	for i, c := range commits {
+		if len(c.Parents) > 1 {
+			upstream, commits = c.Hash, commits[:i]
+			break
+		}
+	}
+	if len(commits) == 0 {
+		return nil, ErrNoFixups
+	}
+	if oldest := commits[len(commits)-1]; upstream == "" && len(oldest.Parents) > 0 {
+		upstream = oldest.Parents[0]
+	}
+
+	gitDir, stateDir, err := r.prepareRebaseState()
+	if err != nil {
+		return nil, err
+	}
+	defer r.cleanupRebaseState(gitDir)
+	todoPath := filepath.Join(stateDir, "autosquash-todo")
+
+	args := []string{"-c", "rebase.abbreviateCommands=false", "-c", "core.commentChar=#",
+		"rebase", "--interactive", "--autosquash"}
+	if upstream != "" {
+		args = append(args, upstream)
+	} else {
+		args = append(args, "--root")
+	}
+	// git appends the todo path as "$@", which false ignores.
+	env := []string{"GIT_SEQUENCE_EDITOR=cp \"$1\" " + shellQuote(todoPath) + " && false"}
+	_, runErr := r.runWith(env, nil, args...)
+	text, err := os.ReadFile(todoPath)
+	if err != nil {
+		// git stopped before asking for the todo list, e.g. because
+		// of local changes.
+		if runErr != nil {
+			return nil, runErr
+		}
+		return nil, err
+	}
+
+	plan := parseAutosquashTodo(string(text), commits)
+	if plan == nil {
+		return nil, ErrNoFixups
+	}
+	plan.head = commits[0].Hash
+	return plan, nil
+}
+
+// Autosquash runs the rebase of plan with exactly the todo list that was
+// previewed. Like InteractiveRebase, it may stop on conflicts.
+func (r *Repository) Autosquash(plan *AutosquashPlan) error {
+	head, err := r.run("rev-parse", "HEAD")
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(head) != plan.head {
+		return ErrAutosquashStale
+	}
+	gitDir, stateDir, err := r.prepareRebaseState()
+	if err != nil {
+		return err
+	}
+	return r.runRebaseTodo(plan.Base, gitDir, stateDir, plan.text)
+}
+
+// parseAutosquashTodo turns the todo list git wrote for rebase --autosquash
+// into a plan starting at the first fixup target, or returns nil if no
+// commit is fixed up. commits are the commits the list was made for, to
+// resolve its abbreviated hashes.
+func parseAutosquashTodo(text string, commits []*Commit) *AutosquashPlan {
+	var lines []string
+	var todo []RebaseTodoItem
+	var todoLine []int // index in lines of each todo item
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+
+		fields := strings.Fields(line)
+		action := RebaseAction(fields[0])
+		if len(fields) > 2 && action == RebaseFixup && strings.HasPrefix(fields[1], "-") {
+			action = RebaseAction(fields[0] + " " + fields[1])
+			fields = fields[1:]
+		}
+		if len(fields) < 2 {
+			continue // e.g. "break"
+		}
+		switch action {
+		case RebasePick, RebaseFixup, RebaseSquash, RebaseFixupMessage:
+		default:
+			continue // e.g. update-ref, kept as is
+		}
+		if c := findCommitByPrefix(commits, fields[1]); c != nil {
+			todo = append(todo, RebaseTodoItem{Action: action, Commit: c})
+			todoLine = append(todoLine, len(lines)-1)
+		}
+	}
+
+	// Leave out the picks before the first target, which git would not
+	// rewrite either.
+	for i := 1; i < len(todo); i++ {
+		if todo[i].Action != RebasePick {
+			return &AutosquashPlan{
+				Base: todo[i-1].Commit.Hash,
+				Todo: todo[i-1:],
+				text: strings.Join(lines[todoLine[i-1]:], "\n") + "\n",
+			}
+		}
+	}
+	return nil
+}
+
+// findCommitByPrefix returns the commit whose hash starts with prefix, or
+// nil.
+func findCommitByPrefix(commits []*Commit, prefix string) *Commit {
+	for _, c := range commits {
+		if strings.HasPrefix(c.Hash, prefix) {
+			return c
+		}
+	}
+	return nil
+}
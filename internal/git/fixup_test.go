@@ -0,0 +1,77 @@
+package git_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+func TestFixupAndAutosquash(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	two := f.Git("rev-parse", "HEAD~2")
+	one := f.Git("rev-parse", "HEAD~3")
+
+	f.WriteFile("two.txt", "two, fixed\n")
+	f.Git("add", "two.txt")
+	if err := repo.CommitFixup(two, git.FixupPlain, ""); err != nil {
+		t.Fatalf("CommitFixup(fixup): %v", err)
+	}
+	f.WriteFile("one.txt", "one, fixed\n")
+	f.Git("add", "one.txt")
+	if err := repo.CommitFixup(one, git.FixupAmend, "first\n\nwith a body"); err != nil {
+		t.Fatalf("CommitFixup(amend): %v", err)
+	}
+	if err := repo.CommitFixup(one, git.FixupPlain, ""); err != git.ErrNothingStaged {
+		t.Errorf("CommitFixup with nothing staged = %v, want ErrNothingStaged", err)
+	}
+
+	head := f.Git("rev-parse", "HEAD")
+	reflog := f.Git("reflog")
+	plan, err := repo.PlanAutosquash()
+	if err != nil {
+		t.Fatalf("PlanAutosquash: %v", err)
+	}
+	if plan.Base != one {
+		t.Errorf("Base = %s, want the oldest target %s", plan.Base, one)
+	}
+	var got []string
+	for _, item := range plan.Todo {
+		got = append(got, string(item.Action)+" "+item.Commit.Subject)
+	}
+	want := []string{
+		"pick one", "fixup -C amend! one",
+		"pick two", "fixup fixup! two",
+		"pick three", "pick four",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Todo:\n got %q\nwant %q", got, want)
+	}
+	// Asking git for the plan leaves the repository as it was.
+	if f.Git("rev-parse", "HEAD") != head || f.Git("reflog") != reflog {
+		t.Errorf("PlanAutosquash moved HEAD or wrote the reflog")
+	}
+	if state, err := repo.State(); err != nil || state.Operation() != "" {
+		t.Errorf("State after PlanAutosquash = %+v, %v", state, err)
+	}
+
+	if err := repo.Autosquash(plan); err != nil {
+		t.Fatalf("Autosquash: %v", err)
+	}
+
+	if got, want := subjects(f), "four,three,two,first,base"; got != want {
+		t.Errorf("subjects = %q, want %q", got, want)
+	}
+	if got := f.Git("log", "-1", "--format=%b", "HEAD~3"); got != "with a body" {
+		t.Errorf("amended body = %q", got)
+	}
+	if got := f.Git("show", "HEAD~2:two.txt"); got != "two, fixed" {
+		t.Errorf("two.txt in two = %q, want the fixup folded in", got)
+	}
+	if _, err := repo.PlanAutosquash(); err != git.ErrNoFixups {
+		t.Errorf("PlanAutosquash after squashing = %v, want ErrNoFixups", err)
+	}
+	if err := repo.Autosquash(plan); err != git.ErrAutosquashStale {
+		t.Errorf("Autosquash of a stale plan = %v, want ErrAutosquashStale", err)
+	}
+}
@@ -0,0 +1,93 @@
+package gittest
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Fixture is a throwaway git repository in a temporary directory, removed
+// when the test ends. Commits get fixed identities and increasing
+// timestamps so hashes are stable between runs.
+type Fixture struct {
+	t    testing.TB
+	Dir  string
+	tick int
+}
+
+// NewFixture initialises an empty repository on branch main.
+func NewFixture(t testing.TB) *Fixture {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found on PATH")
+	}
+	f := &Fixture{t: t, Dir: t.TempDir()}
+	f.Git("init", "-q", "-b", "main")
+	f.Git("config", "user.name", "Test User")
+	f.Git("config", "user.email", "test@example.com")
+	f.Git("config", "commit.gpgsign", "false")
+	f.Git("config", "tag.gpgsign", "false")
+	return f
+}
+
+// Git runs git in the fixture and returns its trimmed stdout, failing the
+// test if the command fails.
+func (f *Fixture) Git(args ...string) string {
+	f.t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = f.Dir
+	cmd.Env = append(os.Environ(), f.env()...)
+	out, err := cmd.Output()
+	if err != nil {
+		stderr := ""
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			stderr = string(exitErr.Stderr)
+		}
+		f.t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, stderr)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// WriteFile writes content to a path relative to the repository root,
+// creating parent directories as needed.
+func (f *Fixture) WriteFile(path, content string) {
+	f.t.Helper()
+	full := filepath.Join(f.Dir, path)
+	if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+		f.t.Fatal(err)
+	}
+	if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// Remove deletes a path relative to the repository root.
+func (f *Fixture) Remove(path string) {
+	f.t.Helper()
+	if err := os.Remove(filepath.Join(f.Dir, path)); err != nil {
+		f.t.Fatal(err)
+	}
+}
+
+// Commit stages everything and commits it with message, returning the new
+// commit hash.
+func (f *Fixture) Commit(message string) string {
+	f.t.Helper()
+	f.tick++
+	f.Git("add", "-A")
+	f.Git("commit", "-q", "--allow-empty", "-m", message)
+	return f.Git("rev-parse", "HEAD")
+}
+
+// env pins author and committer dates so fixture hashes are reproducible.
+func (f *Fixture) env() []string {
+	date := fmt.Sprintf("%d +0000", 1700000000+f.tick*60)
+	return []string{
+		"GIT_AUTHOR_DATE=" + date,
+		"GIT_COMMITTER_DATE=" + date,
+		"GIT_CONFIG_NOSYSTEM=1",
+	}
+}
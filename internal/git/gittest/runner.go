@@ -0,0 +1,156 @@
+// Package gittest provides test helpers for code that talks to git: a fake
+// git.Runner that records and replays commands, and throwaway fixture
+// repositories.
+package gittest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+// Call is one git invocation seen by a FakeRunner, with its result.
+type Call struct {
+	Args   []string
+	Env    []string
+	Stdin  string
+	Result git.Result
+}
+
+type stub struct {
+	args   []string
+	prefix bool
+	result git.Result
+}
+
+// FakeRunner is a git.Runner that answers commands from stubs and records
+// every call. When Delegate is set, commands without a matching stub are
+// passed through to it, so a session against a real repository can be
+// recorded and later replayed with Replay.
+type FakeRunner struct {
+	Delegate git.Runner
+
+	mu    sync.Mutex
+	stubs []stub
+	calls []Call
+}
+
+// NewFakeRunner returns a runner that only answers stubbed commands.
+func NewFakeRunner() *FakeRunner {
+	return &FakeRunner{}
+}
+
+// NewRecordingRunner returns a runner that passes every unstubbed command to
+// delegate and records the results.
+func NewRecordingRunner(delegate git.Runner) *FakeRunner {
+	return &FakeRunner{Delegate: delegate}
+}
+
+// Stub answers any command whose arguments equal args with result.
+func (f *FakeRunner) Stub(result git.Result, args ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stubs = append(f.stubs, stub{args: args, result: result})
+}
+
+// StubPrefix answers any command whose arguments start with args.
+func (f *FakeRunner) StubPrefix(result git.Result, args ...string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stubs = append(f.stubs, stub{args: args, prefix: true, result: result})
+}
+
+// StubOutput is a shorthand for a successful command printing stdout.
+func (f *FakeRunner) StubOutput(stdout string, args ...string) {
+	f.Stub(git.Result{Stdout: []byte(stdout)}, args...)
+}
+
+// Replay stubs every call of a previous recording, so the same session can
+// run again without git.
+func (f *FakeRunner) Replay(calls []Call) {
+	for _, c := range calls {
+		f.Stub(c.Result, c.Args...)
+	}
+}
+
+// Calls returns the commands run so far, in order.
+func (f *FakeRunner) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+// Ran reports whether a command starting with args has been run.
+func (f *FakeRunner) Ran(args ...string) bool {
+	for _, c := range f.Calls() {
+		if hasPrefix(c.Args, args) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeRunner) Run(ctx context.Context, cmd git.Command) (git.Result, error) {
+	var stdin string
+	if cmd.Stdin != nil {
+		b, err := io.ReadAll(cmd.Stdin)
+		if err != nil {
+			return git.Result{}, err
+		}
+		stdin = string(b)
+	}
+
+	result, ok := f.match(cmd.Args)
+	if !ok {
+		if f.Delegate == nil {
+			return git.Result{}, fmt.Errorf("gittest: no stub for git %s", strings.Join(cmd.Args, " "))
+		}
+		cmd.Stdin = strings.NewReader(stdin)
+		var err error
+		result, err = f.Delegate.Run(ctx, cmd)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if ok && cmd.Output != nil {
+		cmd.Output.Write(result.Stdout)
+		cmd.Output.Write(result.Stderr)
+	}
+
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Args: cmd.Args, Env: cmd.Env, Stdin: stdin, Result: result})
+	f.mu.Unlock()
+	return result, nil
+}
+
+func (f *FakeRunner) match(args []string) (git.Result, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, s := range f.stubs {
+		if s.prefix && hasPrefix(args, s.args) || !s.prefix && equal(args, s.args) {
+			return s.result, true
+		}
+	}
+	return git.Result{}, false
+}
+
+func hasPrefix(args, prefix []string) bool {
+	return len(args) >= len(prefix) && equal(args[:len(prefix)], prefix)
+}
+
+func equal(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
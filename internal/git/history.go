@@ -0,0 +1,56 @@
+package git
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileHistory lists the commits that changed path, newest first, following
+// it across renames, with up to limit commits (0 for all). files maps each
+// commit's hash to the file as that commit changed it, whose Path (and
+// OldPath, for the commit that renamed it) may differ from path.
+//
+// The commits' parents are rewritten to link each one to the next older
+// commit in the history, so the graph draws it as a single line.
+func (r *Repository) FileHistory(path string, limit int) (commits []*Commit, files map[string]ChangedFile, err error) {
+	args := []string{"log", "--follow"}
+	if limit > 0 {
+		args = append(args, fmt.Sprintf("-%d", limit))
+	}
+
+	out, err := r.run(append(args, "--format="+logFormat, "--", path)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	commits = parseLog(out, r.buildRefMap(nil))
+
+	out, err = r.run(append(args, "--format=%x1e%H", "--name-status", "-z", "--", path)...)
+	if err != nil {
+		return nil, nil, err
+	}
+	files = parseHistoryFiles(out)
+
+	for i, c := range commits {
+		c.Parents = nil
+		if i+1 < len(commits) {
+			c.Parents = []string{commits[i+1].Hash}
+		}
+	}
+	return commits, files, nil
+}
+
+// parseHistoryFiles parses `log --format=%x1e%H --name-status -z` output
+// for a single path into the file each commit changed, keyed by hash.
+func parseHistoryFiles(out string) map[string]ChangedFile {
+	files := make(map[string]ChangedFile)
+	for _, record := range strings.Split(out, "\x1e") {
+		hash, rest, ok := strings.Cut(record, "\x00")
+		if !ok {
+			continue
+		}
+		if changed := parseNameStatus(strings.TrimLeft(rest, "\n")); len(changed) > 0 {
+			files[hash] = changed[0]
+		}
+	}
+	return files
+}
@@ -0,0 +1,47 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestFileHistoryFollowsRenames(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("old.txt", "one\ntwo\nthree\nfour\n")
+	added := f.Commit("add old.txt")
+	f.WriteFile("other.txt", "unrelated\n")
+	f.Commit("unrelated")
+	f.Git("mv", "old.txt", "new.txt")
+	renamed := f.Commit("rename")
+	f.WriteFile("new.txt", "one\ntwo\nthree\nfour\nfive\n")
+	edited := f.Commit("edit new.txt")
+	repo := openFixture(t, f)
+
+	commits, files, err := repo.FileHistory("new.txt", 0)
+	if err != nil {
+		t.Fatalf("FileHistory: %v", err)
+	}
+	want := []string{edited, renamed, added}
+	if len(commits) != len(want) {
+		t.Fatalf("FileHistory returned %d commits, want %d", len(commits), len(want))
+	}
+	for i, c := range commits {
+		if c.Hash != want[i] {
+			t.Errorf("commit %d = %s %q, want %s", i, c.ShortHash, c.Subject, want[i])
+		}
+	}
+	if p := commits[1].Parents; len(p) != 1 || p[0] != added {
+		t.Errorf("rename parents = %v, want the next commit in the history", p)
+	}
+	if len(commits[2].Parents) != 0 {
+		t.Errorf("oldest commit parents = %v, want none", commits[2].Parents)
+	}
+
+	if got := files[renamed]; got.Status != "R" || got.OldPath != "old.txt" || got.Path != "new.txt" {
+		t.Errorf("rename file = %+v, want old.txt renamed to new.txt", got)
+	}
+	if got := files[added]; got.Status != "A" || got.Path != "old.txt" {
+		t.Errorf("first file = %+v, want old.txt added", got)
+	}
+}
@@ -0,0 +1,95 @@
+package git
+
+import "strings"
+
+// MergeOptions selects how Merge combines a ref into the current branch.
+// FFOnly, NoFF and Squash are mutually exclusive; with none set git
+// fast-forwards when it can and creates a merge commit otherwise.
+type MergeOptions struct {
+	FFOnly  bool // refuse to merge unless HEAD can be fast-forwarded
+	NoFF    bool // always create a merge commit
+	Squash  bool // commit the combined changes as one ordinary commit
+	Message string
+}
+
+// MergeResult reports what Merge did.
+type MergeResult int
+
+const (
+	MergeUpToDate MergeResult = iota
+	MergeFastForward
+	MergeCommitted
+	MergeSquashed
+	MergeConflicted
+)
+
+// Merge merges ref into the current branch. On conflicts it returns
+// MergeConflicted with the git error; a regular merge is then left in
+// progress (SequencerMerge), while a squash merge only leaves the
+// conflicted files for the user to resolve and commit.
+func (r *Repository) Merge(ref string, opts MergeOptions) (MergeResult, error) {
+	before, err := r.HeadHash()
+	if err != nil {
+		return MergeUpToDate, err
+	}
+
+	args := []string{"merge", "--no-edit"}
+	switch {
+	case opts.FFOnly:
+		args = append(args, "--ff-only")
+	case opts.NoFF:
+		args = append(args, "--no-ff")
+	case opts.Squash:
+		args = append(args, "--squash")
+	}
+	if opts.Message != "" && !opts.Squash {
+		args = append(args, "-m", opts.Message)
+	}
+	args = append(args, ref)
+	if _, err := r.runWith(reflogEnv("merge "+ref), nil, args...); err != nil {
+		if IsErrorKind(err, ErrorKindMergeConflict) {
+			return MergeConflicted, err
+		}
+		return MergeUpToDate, err
+	}
+
+	if opts.Squash {
+		return r.commitSquash(opts.Message)
+	}
+
+	after, err := r.HeadHash()
+	if err != nil {
+		return MergeUpToDate, err
+	}
+	if after == before {
+		return MergeUpToDate, nil
+	}
+	out, err := r.run("rev-list", "--parents", "-n", "1", "HEAD")
+	if err != nil {
+		return MergeUpToDate, err
+	}
+	if len(strings.Fields(out)) > 2 {
+		return MergeCommitted, nil
+	}
+	return MergeFastForward, nil
+}
+
+// commitSquash commits the changes staged by merge --squash, with message
+// or else git's prepared squash message.
+func (r *Repository) commitSquash(message string) (MergeResult, error) {
+	staged, err := r.run("diff", "--cached", "--name-only")
+	if err != nil {
+		return MergeUpToDate, err
+	}
+	if strings.TrimSpace(staged) == "" {
+		return MergeUpToDate, nil
+	}
+	args := []string{"commit", "--no-edit"}
+	if message != "" {
+		args = append(args, "-m", message)
+	}
+	if _, err := r.runWith(append(noEditorEnv(), reflogEnv("commit")...), nil, args...); err != nil {
+		return MergeUpToDate, err
+	}
+	return MergeSquashed, nil
+}
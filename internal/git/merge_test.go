@@ -0,0 +1,80 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		diverge  bool
+		opts     git.MergeOptions
+		want     git.MergeResult
+		subjects string
+	}{
+		{"fast-forward", false, git.MergeOptions{}, git.MergeFastForward, "two,one,base"},
+		{"ff-only", false, git.MergeOptions{FFOnly: true}, git.MergeFastForward, "two,one,base"},
+		{"no-ff", false, git.MergeOptions{NoFF: true, Message: "bring in topic"}, git.MergeCommitted, "bring in topic,two,one,base"},
+		{"diverged", true, git.MergeOptions{}, git.MergeCommitted, "Merge branch 'topic',main,two,one,base"},
+		{"squash", true, git.MergeOptions{Squash: true, Message: "topic squashed"}, git.MergeSquashed, "topic squashed,main,base"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, repo, _ := branchFixture(t)
+			if tt.diverge {
+				f.WriteFile("main.txt", "main\n")
+				f.Commit("main")
+			}
+
+			got, err := repo.Merge("topic", tt.opts)
+			if err != nil {
+				t.Fatalf("Merge: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("result = %v, want %v", got, tt.want)
+			}
+			if s := subjects(f); s != tt.subjects {
+				t.Errorf("history = %q, want %q", s, tt.subjects)
+			}
+
+			if got, err := repo.Merge("topic", tt.opts); err != nil || got != git.MergeUpToDate {
+				t.Errorf("second Merge = %v, %v; want up to date", got, err)
+			}
+		})
+	}
+}
+
+func TestMergeFFOnlyRefusesDivergedBranch(t *testing.T) {
+	f, repo, _ := branchFixture(t)
+	f.WriteFile("main.txt", "main\n")
+	head := f.Commit("main")
+
+	if _, err := repo.Merge("topic", git.MergeOptions{FFOnly: true}); err == nil {
+		t.Fatal("expected a fast-forward-only merge of a diverged branch to fail")
+	}
+	if got := f.Git("rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD moved to %s", got)
+	}
+}
+
+func TestMergeConflict(t *testing.T) {
+	f, repo, _ := branchFixture(t)
+	f.WriteFile("one.txt", "main's one\n")
+	f.Commit("main")
+
+	got, err := repo.Merge("topic", git.MergeOptions{})
+	if got != git.MergeConflicted || !git.IsErrorKind(err, git.ErrorKindMergeConflict) {
+		t.Fatalf("Merge = %v, %v; want a conflict", got, err)
+	}
+	if s, _ := repo.SequencerInProgress(); s != git.SequencerMerge {
+		t.Fatalf("SequencerInProgress = %q, want merge", s)
+	}
+	if err := repo.SequencerAbort(git.SequencerMerge); err != nil {
+		t.Fatalf("abort: %v", err)
+	}
+	if s, _ := repo.SequencerInProgress(); s != git.SequencerNone {
+		t.Errorf("SequencerInProgress after abort = %q", s)
+	}
+}
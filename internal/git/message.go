@@ -0,0 +1,80 @@
+package git
+
+import "strings"
+
+// Trailer is a "Key: value" line from the trailer block at the end of a
+// commit message, e.g. Signed-off-by or Co-authored-by.
+type Trailer struct {
+	Key   string
+	Value string
+}
+
+// TrailerValues returns the values of all trailers with the given key,
+// compared case-insensitively, in message order.
+func (c *Commit) TrailerValues(key string) []string {
+	var values []string
+	for _, t := range c.Trailers {
+		if strings.EqualFold(t.Key, key) {
+			values = append(values, t.Value)
+		}
+	}
+	return values
+}
+
+// parseTrailers parses the output of %(trailers:only,unfold): one
+// "Key: value" trailer per line.
+func parseTrailers(s string) []Trailer {
+	var trailers []Trailer
+	for _, line := range strings.Split(s, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok || key == "" {
+			continue
+		}
+		trailers = append(trailers, Trailer{Key: key, Value: strings.TrimSpace(value)})
+	}
+	return trailers
+}
+
+// messageBody returns the part of a commit message after the subject
+// paragraph. When the commit has trailers, the trailing paragraph holding
+// them is dropped so they are not shown twice.
+func messageBody(message string, hasTrailers bool) string {
+	_, body, ok := strings.Cut(message, "\n\n")
+	if !ok {
+		return ""
+	}
+	body = strings.Trim(body, "\n")
+
+	if hasTrailers {
+		paragraphs := strings.Split(body, "\n\n")
+		if isTrailerBlock(paragraphs[len(paragraphs)-1]) {
+			body = strings.Join(paragraphs[:len(paragraphs)-1], "\n\n")
+		}
+	}
+	return strings.TrimRight(body, "\n ")
+}
+
+// isTrailerBlock reports whether every line of a paragraph is a
+// "Token: value" trailer or an indented continuation of one.
+func isTrailerBlock(paragraph string) bool {
+	for i, line := range strings.Split(paragraph, "\n") {
+		if i > 0 && (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) {
+			continue
+		}
+		key, _, ok := strings.Cut(line, ":")
+		if !ok || key == "" || strings.ContainsAny(key, " \t") {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplaceSubject returns message with its subject paragraph replaced by
+// subject, keeping the body and trailers.
+func ReplaceSubject(message, subject string) string {
+	_, rest, ok := strings.Cut(message, "\n\n")
+	if !ok {
+		return subject
+	}
+	return subject + "\n\n" + rest
+}
@@ -0,0 +1,259 @@
+package git
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseStatusPorcelain(t *testing.T) {
+	output := "M  staged.go\x00" +
+		" M unstaged.go\x00" +
+		"MM both.go\x00" +
+		"A  added.go\x00" +
+		" D deleted.go\x00" +
+		"?? new file.txt\x00" +
+		"R  renamed.go\x00original.go\x00"
+
+	got := parseStatusPorcelain(output)
+	want := []ChangedFile{
+		{Status: "M", Path: "staged.go", Staged: true},
+		{Status: "M", Path: "unstaged.go", Unstaged: true},
+		{Status: "M", Path: "both.go", Staged: true, Unstaged: true},
+		{Status: "A", Path: "added.go", Staged: true},
+		{Status: "D", Path: "deleted.go", Unstaged: true},
+		{Status: "?", Path: "new file.txt", Unstaged: true},
+		{Status: "R", Path: "renamed.go", OldPath: "original.go", Staged: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseStatusPorcelain:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseStatusPorcelainEmpty(t *testing.T) {
+	if got := parseStatusPorcelain(""); len(got) != 0 {
+		t.Fatalf("expected no files, got %+v", got)
+	}
+}
+
+func TestParseNameStatus(t *testing.T) {
+	output := "M\x00main.go\x00A\x00dir/new.go\x00D\x00old.go\x00R087\x00from.go\x00to.go\x00"
+
+	got := parseNameStatus(output)
+	want := []ChangedFile{
+		{Status: "M", Path: "main.go"},
+		{Status: "A", Path: "dir/new.go"},
+		{Status: "D", Path: "old.go"},
+		{Status: "R", Path: "to.go", OldPath: "from.go"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseNameStatus:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseNumstat(t *testing.T) {
+	output := "3\t1\tmain.go\x00" +
+		"-\t-\timage.png\x00" +
+		"2\t2\t\x00from.go\x00to.go\x00" +
+		"0\t5\tgone.go\x00"
+
+	got := parseNumstat(output)
+	want := map[string][2]int{
+		"main.go":   {3, 1},
+		"image.png": {0, 0},
+		"to.go":     {2, 2},
+		"gone.go":   {0, 5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseNumstat:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestParseTrailers(t *testing.T) {
+	got := parseTrailers("Signed-off-by: Ada <ada@example.com>\nCo-authored-by: Bob <bob@example.com>\nFixes: #12\n")
+	want := []Trailer{
+		{Key: "Signed-off-by", Value: "Ada <ada@example.com>"},
+		{Key: "Co-authored-by", Value: "Bob <bob@example.com>"},
+		{Key: "Fixes", Value: "#12"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseTrailers:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestMessageBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		message     string
+		hasTrailers bool
+		want        string
+	}{
+		{"subject only", "Fix the thing", false, ""},
+		{"body", "Fix the thing\n\nIt was broken.\n\nNow it is not.", false, "It was broken.\n\nNow it is not."},
+		{"body and trailers", "Fix\n\nDetails here.\n\nSigned-off-by: Ada <a@x>\nFixes: #3", true, "Details here."},
+		{"trailers only", "Fix\n\nReviewed-by: Bob <b@x>", true, ""},
+		{"last paragraph is prose", "Fix\n\nSee: the docs for why this is needed.\n\nNote this is prose.", true,
+			"See: the docs for why this is needed.\n\nNote this is prose."},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := messageBody(tt.message, tt.hasTrailers); got != tt.want {
+				t.Errorf("messageBody = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTracking(t *testing.T) {
+	output := "main\x00ahead 2, behind 5\n" +
+		"feature\x00ahead 1\n" +
+		"old\x00gone\n" +
+		"local\x00"
+
+	got := parseTracking(output)
+	want := map[string]branchTracking{
+		"main":    {ahead: 2, behind: 5},
+		"feature": {ahead: 1},
+		"old":     {gone: true},
+		"local":   {},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseTracking:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseStatusPorcelainUnmerged(t *testing.T) {
+	output := "UU both.go\x00DU deleted-by-us.go\x00AA added.go\x00M  clean.go\x00"
+
+	got := parseStatusPorcelain(output)
+	want := []ChangedFile{
+		{Status: "U", Path: "both.go", Unstaged: true, Conflict: "UU"},
+		{Status: "U", Path: "deleted-by-us.go", Unstaged: true, Conflict: "DU"},
+		{Status: "U", Path: "added.go", Unstaged: true, Conflict: "AA"},
+		{Status: "M", Path: "clean.go", Staged: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseStatusPorcelain:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseBlame(t *testing.T) {
+	a := "1111111111111111111111111111111111111111"
+	b := "2222222222222222222222222222222222222222"
+	output := a + " 1 1 2\n" +
+		"author Ann\n" +
+		"author-time 1700000000\n" +
+		"summary Add greeting\n" +
+		"boundary\n" +
+		"filename hello.txt\n" +
+		"\thello\n" +
+		b + " 2 2 1\n" +
+		"author Bob\n" +
+		"author-time 1700000100\n" +
+		"summary Rename and edit\n" +
+		"previous " + a + " old.txt\n" +
+		"filename hello.txt\n" +
+		"\tworld\n" +
+		a + " 2 3\n" +
+		"filename hello.txt\n" +
+		"\t!\n"
+
+	got := parseBlame(output)
+	want := []BlameLine{
+		{Hash: a, Author: "Ann", AuthorTime: time.Unix(1700000000, 0), Summary: "Add greeting", Path: "hello.txt", Line: 1, Text: "hello"},
+		{Hash: b, Author: "Bob", AuthorTime: time.Unix(1700000100, 0), Summary: "Rename and edit", Path: "hello.txt",
+			Previous: a, PreviousPath: "old.txt", Line: 2, Text: "world"},
+		{Hash: a, Author: "Ann", AuthorTime: time.Unix(1700000000, 0), Summary: "Add greeting", Path: "hello.txt", Line: 3, Text: "!"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseBlame:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestParseAutosquashTodo(t *testing.T) {
+	var commits []*Commit
+	for i, subject := range []string{"unrelated", "add parser", "fixup! add parser", "add printer", "amend! add printer"} {
+		hash := strings.Repeat(string(rune('a'+i)), 40)
+		commits = append(commits, &Commit{Hash: hash, Subject: subject})
+	}
+
+	todo := "pick aaaaaaa unrelated\n" +
+		"pick bbbbbbb add parser\n" +
+		"fixup ccccccc fixup! add parser\n" +
+		"update-ref refs/heads/topic\n" +
+		"\n" +
+		"pick ddddddd add printer\n" +
+		"fixup -C eeeeeee amend! add printer\n" +
+		"\n" +
+		"# Rebase 1234567..eeeeeee onto 1234567 (5 commands)\n"
+	plan := parseAutosquashTodo(todo, commits)
+	if plan == nil {
+		t.Fatal("parseAutosquashTodo = nil")
+	}
+	if plan.Base != commits[1].Hash {
+		t.Errorf("Base = %s, want the first target", plan.Base)
+	}
+	var got []string
+	for _, item := range plan.Todo {
+		got = append(got, string(item.Action)+" "+item.Commit.Subject)
+	}
+	want := []string{"pick add parser", "fixup fixup! add parser", "pick add printer", "fixup -C amend! add printer"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Todo:\n got %q\nwant %q", got, want)
+	}
+	// The list is run as git wrote it, from the base on.
+	wantText := "pick bbbbbbb add parser\n" +
+		"fixup ccccccc fixup! add parser\n" +
+		"update-ref refs/heads/topic\n" +
+		"pick ddddddd add printer\n" +
+		"fixup -C eeeeeee amend! add printer\n"
+	if plan.text != wantText {
+		t.Errorf("text = %q, want %q", plan.text, wantText)
+	}
+
+	if plan := parseAutosquashTodo("pick aaaaaaa unrelated\npick ccccccc fixup! no such commit\n", commits); plan != nil {
+		t.Errorf("parseAutosquashTodo without fixups = %+v, want nil", plan)
+	}
+}
+
+func TestParseGitlinks(t *testing.T) {
+	a, b := strings.Repeat("a", 40), strings.Repeat("b", 40)
+	zero := strings.Repeat("0", 40)
+	output := ":100644 100644 " + a + " " + b + " M\x00main.go\x00" +
+		":160000 160000 " + a + " " + b + " M\x00lib/dep\x00" +
+		":000000 160000 " + zero + " " + b + " A\x00vendor/new\x00" +
+		":160000 000000 " + a + " " + zero + " D\x00old\x00" +
+		":160000 160000 " + a + " " + a + " R100\x00from\x00to\x00"
+
+	got := parseGitlinks(output)
+	want := map[string][2]string{
+		"lib/dep":    {a, b},
+		"vendor/new": {"", b},
+		"old":        {a, ""},
+		"to":         {a, a},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseGitlinks:\n got %v\nwant %v", got, want)
+	}
+}
+
+func TestParseSubmoduleStatus(t *testing.T) {
+	a, b := strings.Repeat("a", 40), strings.Repeat("b", 40)
+	zero := strings.Repeat("0", 40)
+	output := " " + a + " lib/dep (v1.2.0)\n" +
+		"-" + b + " not yet\n" +
+		"+" + a + " ahead (heads/main)\n" +
+		"U" + zero + " conflicted\n"
+
+	got := parseSubmoduleStatus(output)
+	want := []Submodule{
+		{Path: "lib/dep", Hash: a, Initialized: true},
+		{Path: "not yet", Hash: b},
+		{Path: "ahead", Hash: a, Initialized: true, OutOfDate: true},
+		{Path: "conflicted", Hash: zero, Initialized: true, Conflict: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseSubmoduleStatus:\n got %+v\nwant %+v", got, want)
+	}
+}
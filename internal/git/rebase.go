@@ -0,0 +1,290 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// RebaseAction is what an interactive rebase does with one commit.
+type RebaseAction string
+
+const (
+	RebasePick   RebaseAction = "pick"
+	RebaseReword RebaseAction = "reword"
+	RebaseEdit   RebaseAction = "edit"
+	RebaseSquash RebaseAction = "squash"
+	RebaseFixup  RebaseAction = "fixup"
+	RebaseDrop   RebaseAction = "drop"
+
+	// RebaseFixupMessage is a fixup that replaces the message of the
+	// commit it melds into with its own, as for an amend! commit.
+	RebaseFixupMessage RebaseAction = "fixup -C"
+)
+
+// RebaseTodoItem is one line of an interactive rebase plan.
+type RebaseTodoItem struct {
+	Action RebaseAction
+	Commit *Commit
+
+	// Message replaces the commit message when Action is RebaseReword.
+	Message string
+}
+
+// RebaseProgress describes a rebase that is stopped partway through.
+type RebaseProgress struct {
+	Step      int    // number of the todo item being applied, 1-based
+	Total     int    // number of todo items
+	StoppedAt string // hash of the commit the rebase stopped on, if known
+	HeadName  string // branch being rebased, e.g. "main"
+	Conflicts bool   // whether there are unmerged paths to resolve
+}
+
+var (
+	// ErrNotOnCurrentBranch is returned when an interactive rebase is
+	// requested from a commit that HEAD does not contain.
+	ErrNotOnCurrentBranch = errors.New("commit is not on the current branch")
+
+	// ErrSquashFirst is returned when the oldest commit of a rebase plan
+	// is squashed or fixed up, since there is nothing to meld it into.
+	ErrSquashFirst = errors.New("cannot squash or fixup the first commit of the rebase")
+)
+
+// rebaseStateDir is created inside the git directory to hold reworded
+// messages that must outlive a rebase stopped for edits or conflicts.
+const rebaseStateDir = "lazygit-lite-rebase"
+
+// RebaseCommits returns the commits an interactive rebase starting at hash
+// would rewrite, oldest first: hash itself and everything after it up to
+// HEAD, without merges.
+func (r *Repository) RebaseCommits(hash string) ([]*Commit, error) {
+	if err := r.checkAncestorOfHead(hash); err != nil {
+		return nil, err
+	}
+
+	args := []string{"log", "--reverse", "--topo-order", "--no-merges", "--format=" + logFormat}
+	if r.hasParent(hash) {
+		args = append(args, hash+"^..HEAD")
+	} else {
+		args = append(args, "HEAD")
+	}
+	out, err := r.run(args...)
+	if err != nil {
+		return nil, err
+	}
+	return parseLog(out, r.buildRefMap(nil)), nil
+}
+
+// InteractiveRebase rewrites the commits from hash to HEAD according to
+// todo, which lists them oldest first. The plan is handed to git through
+// GIT_SEQUENCE_EDITOR, so no editor is opened. The rebase may stop for an
+// edit item or on conflicts; RebaseProgress reports where.
+func (r *Repository) InteractiveRebase(hash string, todo []RebaseTodoItem) error {
+	if len(todo) == 0 {
+		return errors.New("empty rebase plan")
+	}
+	if a := firstKeptAction(todo); a == RebaseSquash || a == RebaseFixup || a == RebaseFixupMessage {
+		return ErrSquashFirst
+	}
+	if err := r.checkAncestorOfHead(hash); err != nil {
+		return err
+	}
+
+	gitDir, stateDir, err := r.prepareRebaseState()
+	if err != nil {
+		return err
+	}
+	todoText, err := buildRebaseTodo(todo, stateDir)
+	if err != nil {
+		return err
+	}
+	return r.runRebaseTodo(hash, gitDir, stateDir, todoText)
+}
+
+// prepareRebaseState empties the directory in the git directory that holds
+// the todo list and reworded messages of a rebase about to start.
+func (r *Repository) prepareRebaseState() (gitDir, stateDir string, err error) {
+	if gitDir, err = r.GitDir(); err != nil {
+		return "", "", err
+	}
+	stateDir = filepath.Join(gitDir, rebaseStateDir)
+	if err := os.RemoveAll(stateDir); err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(stateDir, 0o755); err != nil {
+		return "", "", err
+	}
+	return gitDir, stateDir, nil
+}
+
+// runRebaseTodo rebases the commits from hash to HEAD with todoText as the
+// todo list, handed to git through GIT_SEQUENCE_EDITOR.
+func (r *Repository) runRebaseTodo(hash, gitDir, stateDir, todoText string) error {
+	todoPath := filepath.Join(stateDir, "git-rebase-todo")
+	if err := os.WriteFile(todoPath, []byte(todoText), 0o644); err != nil {
+		return err
+	}
+
+	args := []string{"rebase", "--interactive"}
+	if r.hasParent(hash) {
+		args = append(args, hash+"^")
+	} else {
+		args = append(args, "--root")
+	}
+
+	env := append(noEditorEnv(), "GIT_SEQUENCE_EDITOR=cp "+shellQuote(todoPath))
+	env = append(env, reflogEnv("rebase")...)
+	_, err := r.runWith(env, nil, args...)
+	r.cleanupRebaseState(gitDir)
+	return err
+}
+
+// RebaseContinue resumes a stopped rebase after an edit or once conflicts
+// are resolved and staged.
+func (r *Repository) RebaseContinue() error {
+	_, err := r.runWith(append(noEditorEnv(), reflogEnv("rebase")...), nil, "rebase", "--continue")
+	r.cleanupRebaseState("")
+	return err
+}
+
+// RebaseSkip drops the commit the rebase stopped on and carries on.
+func (r *Repository) RebaseSkip() error {
+	_, err := r.runWith(append(noEditorEnv(), reflogEnv("rebase")...), nil, "rebase", "--skip")
+	r.cleanupRebaseState("")
+	return err
+}
+
+// RebaseAbort abandons the rebase and restores the original branch.
+func (r *Repository) RebaseAbort() error {
+	_, err := r.runWith(reflogEnv("rebase"), nil, "rebase", "--abort")
+	r.cleanupRebaseState("")
+	return err
+}
+
+// RebaseProgress returns the state of an in-progress rebase, or nil if no
+// rebase is running.
+func (r *Repository) RebaseProgress() (*RebaseProgress, error) {
+	gitDir, err := r.GitDir()
+	if err != nil {
+		return nil, err
+	}
+
+	var p RebaseProgress
+	if dir := filepath.Join(gitDir, "rebase-merge"); isDir(dir) {
+		p.Step = readIntFile(filepath.Join(dir, "msgnum"))
+		p.Total = readIntFile(filepath.Join(dir, "end"))
+		p.StoppedAt = readTrimmedFile(filepath.Join(dir, "stopped-sha"))
+		p.HeadName = readTrimmedFile(filepath.Join(dir, "head-name"))
+	} else if dir := filepath.Join(gitDir, "rebase-apply"); isDir(dir) {
+		p.Step = readIntFile(filepath.Join(dir, "next"))
+		p.Total = readIntFile(filepath.Join(dir, "last"))
+		p.StoppedAt = readTrimmedFile(filepath.Join(dir, "original-commit"))
+		p.HeadName = readTrimmedFile(filepath.Join(dir, "head-name"))
+	} else {
+		return nil, nil
+	}
+	p.HeadName = strings.TrimPrefix(p.HeadName, "refs/heads/")
+
+	unmerged, err := r.run("diff", "--name-only", "--diff-filter=U")
+	if err != nil {
+		return nil, err
+	}
+	p.Conflicts = strings.TrimSpace(unmerged) != ""
+	return &p, nil
+}
+
+// buildRebaseTodo renders the todo list for git rebase -i. Rewording is done
+// by picking the commit and amending its message from a file in stateDir,
+// so git never needs to open an editor.
+func buildRebaseTodo(todo []RebaseTodoItem, stateDir string) (string, error) {
+	var b strings.Builder
+	for i, item := range todo {
+		action := item.Action
+		if action == RebaseReword {
+			action = RebasePick
+		}
+		fmt.Fprintf(&b, "%s %s %s\n", action, item.Commit.Hash, item.Commit.Subject)
+
+		if item.Action == RebaseReword {
+			msgPath := filepath.Join(stateDir, fmt.Sprintf("message-%d", i))
+			if err := os.WriteFile(msgPath, []byte(item.Message+"\n"), 0o644); err != nil {
+				return "", err
+			}
+			fmt.Fprintf(&b, "exec git commit --amend --only --quiet --file=%s\n", shellQuote(msgPath))
+		}
+	}
+	return b.String(), nil
+}
+
+// firstKeptAction returns the action of the oldest commit that is not
+// dropped, which cannot be squashed into anything.
+func firstKeptAction(todo []RebaseTodoItem) RebaseAction {
+	for _, item := range todo {
+		if item.Action != RebaseDrop {
+			return item.Action
+		}
+	}
+	return RebaseDrop
+}
+
+// noEditorEnv accepts git's default message wherever git would open an
+// editor, e.g. the combined message of a squash or a resumed revert.
+func noEditorEnv() []string {
+	return []string{"GIT_EDITOR=true"}
+}
+
+// cleanupRebaseState removes the reword message files once no rebase is in
+// progress any more. gitDir may be empty to look it up.
+func (r *Repository) cleanupRebaseState(gitDir string) {
+	if gitDir == "" {
+		var err error
+		if gitDir, err = r.GitDir(); err != nil {
+			return
+		}
+	}
+	if isDir(filepath.Join(gitDir, "rebase-merge")) || isDir(filepath.Join(gitDir, "rebase-apply")) {
+		return
+	}
+	os.RemoveAll(filepath.Join(gitDir, rebaseStateDir))
+}
+
+func (r *Repository) checkAncestorOfHead(hash string) error {
+	_, err := r.run("merge-base", "--is-ancestor", hash, "HEAD")
+	if exitCode(err) == 1 {
+		return ErrNotOnCurrentBranch
+	}
+	return err
+}
+
+// hasParent reports whether hash has at least one parent.
+func (r *Repository) hasParent(hash string) bool {
+	_, err := r.run("rev-parse", "--verify", "--quiet", hash+"^")
+	return err == nil
+}
+
+// shellQuote quotes s for a POSIX shell, as git runs editor commands
+// through one.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func readTrimmedFile(path string) string {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func readIntFile(path string) int {
+	n, _ := strconv.Atoi(readTrimmedFile(path))
+	return n
+}
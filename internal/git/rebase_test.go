@@ -0,0 +1,149 @@
+package git_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+// rebaseFixture creates a root commit followed by commits one..four, each
+// adding its own file.
+func rebaseFixture(t *testing.T) (*gittest.Fixture, *git.Repository) {
+	t.Helper()
+	f := gittest.NewFixture(t)
+	f.WriteFile("base.txt", "base\n")
+	f.Commit("base")
+	for _, name := range []string{"one", "two", "three", "four"} {
+		f.WriteFile(name+".txt", name+"\n")
+		f.Commit(name)
+	}
+	return f, openFixture(t, f)
+}
+
+func subjects(f *gittest.Fixture) string {
+	return strings.ReplaceAll(f.Git("log", "--format=%s"), "\n", ",")
+}
+
+func planFor(t *testing.T, repo *git.Repository, hash string, actions ...git.RebaseAction) []git.RebaseTodoItem {
+	t.Helper()
+	commits, err := repo.RebaseCommits(hash)
+	if err != nil {
+		t.Fatalf("RebaseCommits: %v", err)
+	}
+	if len(commits) != len(actions) {
+		t.Fatalf("expected %d commits to rebase, got %d", len(actions), len(commits))
+	}
+	todo := make([]git.RebaseTodoItem, len(commits))
+	for i, c := range commits {
+		todo[i] = git.RebaseTodoItem{Action: actions[i], Commit: c}
+	}
+	return todo
+}
+
+func TestInteractiveRebase(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	start := f.Git("rev-parse", "HEAD~3") // "one"
+
+	todo := planFor(t, repo, start, git.RebasePick, git.RebaseSquash, git.RebaseDrop, git.RebaseReword)
+	todo[3].Message = git.ReplaceSubject(todo[3].Commit.Message, "four, reworded")
+	// Move "four" before "one".
+	todo = append([]git.RebaseTodoItem{todo[3]}, todo[:3]...)
+
+	if err := repo.InteractiveRebase(start, todo); err != nil {
+		t.Fatalf("InteractiveRebase: %v", err)
+	}
+	if got, want := subjects(f), "one,four, reworded,base"; got != want {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+	if msg := f.Git("log", "-1", "--format=%B"); !strings.Contains(msg, "one") || !strings.Contains(msg, "two") {
+		t.Errorf("squashed message should combine both commits, got %q", msg)
+	}
+	if files := f.Git("ls-files"); strings.Contains(files, "three.txt") {
+		t.Errorf("dropped commit's file still present: %s", files)
+	}
+	if p, err := repo.RebaseProgress(); err != nil || p != nil {
+		t.Errorf("expected no rebase in progress, got %+v, %v", p, err)
+	}
+}
+
+func TestInteractiveRebaseStopsForEdit(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	start := f.Git("rev-parse", "HEAD~1") // "three"
+
+	todo := planFor(t, repo, start, git.RebaseEdit, git.RebaseReword)
+	todo[1].Message = "four, reworded"
+	if err := repo.InteractiveRebase(start, todo); err != nil {
+		t.Fatalf("InteractiveRebase: %v", err)
+	}
+
+	p, err := repo.RebaseProgress()
+	if err != nil || p == nil {
+		t.Fatalf("expected a stopped rebase, got %+v, %v", p, err)
+	}
+	if p.Step != 1 || p.Total != 3 || p.Conflicts || p.HeadName != "main" {
+		t.Errorf("progress = %+v", *p)
+	}
+	if p.StoppedAt != todo[0].Commit.Hash {
+		t.Errorf("StoppedAt = %s, want %s", p.StoppedAt, todo[0].Commit.Hash)
+	}
+
+	if err := repo.RebaseContinue(); err != nil {
+		t.Fatalf("RebaseContinue: %v", err)
+	}
+	if got, want := subjects(f), "four, reworded,three,two,one,base"; got != want {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+}
+
+func TestInteractiveRebaseConflictAndAbort(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "base\n")
+	f.Commit("base")
+	f.WriteFile("a.txt", "first\n")
+	first := f.Commit("first")
+	f.WriteFile("a.txt", "second\n")
+	f.Commit("second")
+	repo := openFixture(t, f)
+	head := f.Git("rev-parse", "HEAD")
+
+	// Swapping two commits that edit the same line must conflict.
+	todo := planFor(t, repo, first, git.RebasePick, git.RebasePick)
+	todo[0], todo[1] = todo[1], todo[0]
+	err := repo.InteractiveRebase(first, todo)
+	if !git.IsErrorKind(err, git.ErrorKindMergeConflict) {
+		t.Fatalf("expected a conflict, got %v", err)
+	}
+	p, _ := repo.RebaseProgress()
+	if p == nil || !p.Conflicts {
+		t.Fatalf("expected a rebase stopped on conflicts, got %+v", p)
+	}
+
+	if err := repo.RebaseAbort(); err != nil {
+		t.Fatalf("RebaseAbort: %v", err)
+	}
+	if got := f.Git("rev-parse", "HEAD"); got != head {
+		t.Errorf("HEAD after abort = %s, want %s", got, head)
+	}
+}
+
+func TestInteractiveRebaseValidation(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	start := f.Git("rev-parse", "HEAD~1")
+
+	todo := planFor(t, repo, start, git.RebaseFixup, git.RebasePick)
+	if err := repo.InteractiveRebase(start, todo); !errors.Is(err, git.ErrSquashFirst) {
+		t.Errorf("expected ErrSquashFirst, got %v", err)
+	}
+
+	f.Git("checkout", "-q", "-b", "side", "HEAD~2")
+	f.WriteFile("side.txt", "side\n")
+	f.Commit("side")
+	f.Git("checkout", "-q", "main")
+	side := f.Git("rev-parse", "side")
+	if _, err := repo.RebaseCommits(side); !errors.Is(err, git.ErrNotOnCurrentBranch) {
+		t.Errorf("expected ErrNotOnCurrentBranch, got %v", err)
+	}
+}
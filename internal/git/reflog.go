@@ -0,0 +1,302 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNothingToUndo is returned by UndoPlan when the reflog holds no
+// operation that can be undone.
+var ErrNothingToUndo = errors.New("nothing to undo")
+
+// ErrNothingToRedo is returned by RedoPlan when the last operation was not
+// an undo.
+var ErrNothingToRedo = errors.New("nothing to redo")
+
+// ErrUndoExternal is returned by UndoPlan when the operation to undo was
+// run outside lazygit-lite, which undo leaves alone.
+var ErrUndoExternal = errors.New("HEAD was last moved outside lazygit-lite")
+
+// ReflogEntry is one move of a ref, newest first in a reflog.
+type ReflogEntry struct {
+	Selector string // e.g. "HEAD@{2}"
+	Old      string // hash before the move, "" if unknown or the ref was created
+	New      string // hash after the move
+	Action   string // e.g. "commit (amend)", "reset", "rebase (finish)"
+	Message  string // what the action did, e.g. "moving to HEAD~1"
+	Date     time.Time
+
+	// Ours is set for the operations lazygit-lite ran, other than undos
+	// and redos. Their reflogMarker is not part of Action.
+	Ours bool
+}
+
+// UndoStep is how an undo or redo would move HEAD: back over Entry, the
+// operation undone or the undo redone.
+type UndoStep struct {
+	Entry ReflogEntry
+
+	// Checkout is the branch or commit to check out when Entry switched
+	// branches; otherwise the current branch is reset to Reset.
+	Checkout string
+	Reset    string
+
+	// Soft is set when Entry made or amended a commit: the reset then
+	// keeps its changes staged instead of taking them out of the working
+	// tree.
+	Soft bool
+
+	Redo bool
+}
+
+// Markers prefixed to the reflog messages of undos and redos, so later
+// ones can tell them from the operations they reverse, and to those of the
+// other operations that move HEAD, so undo can leave alone the ones run
+// elsewhere.
+const (
+	undoMarker   = "[lazygit-lite undo]"
+	redoMarker   = "[lazygit-lite redo]"
+	reflogMarker = "[lazygit-lite]"
+)
+
+// undoScanLimit bounds how far back UndoPlan and RedoPlan look.
+const undoScanLimit = 1000
+
+// Reflog returns up to limit entries of ref's reflog ("HEAD" or a branch
+// name), newest first.
+func (r *Repository) Reflog(ref string, limit int) ([]ReflogEntry, error) {
+	// One more than asked for, to know the old hash of the last.
+	out, err := r.run("log", "--walk-reflogs", "--date=unix",
+		"--format=%H%x00%gd%x00%gs", "-n", strconv.Itoa(limit+1), ref, "--")
+	if err != nil {
+		return nil, err
+	}
+	entries := parseReflog(out, ref)
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+// UndoPlan finds the latest HEAD-moving operation not yet undone and how
+// to reverse it. A rebase counts as one operation from its start to its
+// finish, and an undo's redo brings that operation back. Only operations
+// lazygit-lite ran are undone: ErrUndoExternal is returned if that
+// operation was run elsewhere.
+func (r *Repository) UndoPlan() (UndoStep, error) {
+	entries, err := r.Reflog("HEAD", undoScanLimit)
+	if err != nil {
+		return UndoStep{}, err
+	}
+	return undoPlan(entries)
+}
+
+// RedoPlan finds the latest undo not yet redone, if nothing but undos and
+// redos happened since, and how to reverse it.
+func (r *Repository) RedoPlan() (UndoStep, error) {
+	entries, err := r.Reflog("HEAD", undoScanLimit)
+	if err != nil {
+		return UndoStep{}, err
+	}
+	return redoPlan(entries)
+}
+
+// ApplyUndo carries out an undo or redo step, marking its reflog entry as
+// one. Resets use --keep, which refuses rather than overwrite uncommitted
+// changes, or --soft for a Soft step.
+func (r *Repository) ApplyUndo(step UndoStep) error {
+	marker := undoMarker
+	if step.Redo {
+		marker = redoMarker
+	}
+	if step.Checkout != "" {
+		_, err := r.runWith(r.checkoutReflogEnv(marker, step.Checkout), nil, "checkout", step.Checkout)
+		return err
+	}
+	// Name the operation reversed after the marker, so a redo knows
+	// whether to reset softly too.
+	action := marker + " " + strings.TrimSpace(strings.TrimPrefix(step.Entry.Action, undoMarker))
+	mode := "--keep"
+	if step.Soft {
+		mode = "--soft"
+	}
+	_, err := r.runWith([]string{"GIT_REFLOG_ACTION=" + action}, nil, "reset", mode, step.Reset)
+	return err
+}
+
+// reflogEnv marks the reflog entries of a command lazygit-lite runs as its
+// own. action is what git would log otherwise, e.g. "commit (amend)":
+// commit, reset, merge and pull log GIT_REFLOG_ACTION in its place, and
+// rebase, cherry-pick and revert as a prefix, e.g. "rebase (finish)".
+func reflogEnv(action string) []string {
+	return []string{"GIT_REFLOG_ACTION=" + reflogMarker + " " + action}
+}
+
+// checkoutReflogEnv marks a checkout of target with marker. checkout takes
+// GIT_REFLOG_ACTION as its whole message, so the move is spelled out as
+// git would, for undo to reverse.
+func (r *Repository) checkoutReflogEnv(marker, target string) []string {
+	from := "HEAD"
+	if out, err := r.run("rev-parse", "--abbrev-ref", "HEAD"); err == nil {
+		from = strings.TrimSpace(out)
+	}
+	if from == "HEAD" {
+		from, _ = r.HeadHash()
+	}
+	action := fmt.Sprintf("%s checkout: moving from %s to %s", marker, from, target)
+	return []string{"GIT_REFLOG_ACTION=" + action}
+}
+
+// reflogOp is one operation in HEAD's reflog: a single entry, or the
+// entries of a rebase from its start to its finish.
+type reflogOp struct {
+	entry  ReflogEntry // the newest entry
+	before string      // HEAD before the operation
+	marker string      // undoMarker, redoMarker or ""
+	ours   bool        // run by lazygit-lite
+}
+
+// reflogOps groups HEAD's reflog entries, newest first, into operations.
+func reflogOps(entries []ReflogEntry) []reflogOp {
+	var ops []reflogOp
+	for i := 0; i < len(entries); i++ {
+		op := reflogOp{entry: entries[i], before: entries[i].Old, ours: entries[i].Ours}
+		switch {
+		case strings.HasPrefix(entries[i].Action, undoMarker):
+			op.marker = undoMarker
+			op.ours = true
+		case strings.HasPrefix(entries[i].Action, redoMarker):
+			op.marker = redoMarker
+			op.ours = true
+		case isRebaseAction(entries[i].Action):
+			// Walk back to the rebase's start. Fast-forwarded picks are
+			// logged without GIT_REFLOG_ACTION, so the rebase is ours if
+			// any of its entries is.
+			for !strings.HasSuffix(entries[i].Action, "(start)") &&
+				i+1 < len(entries) && isRebaseAction(entries[i+1].Action) {
+				i++
+				op.ours = op.ours || entries[i].Ours
+			}
+			op.before = entries[i].Old
+		}
+		ops = append(ops, op)
+	}
+	return ops
+}
+
+// isRebaseAction reports whether action is a step of a rebase, including
+// one run by pull, e.g. "pull --rebase (pick)".
+func isRebaseAction(action string) bool {
+	return strings.HasPrefix(action, "rebase") ||
+		strings.HasPrefix(action, "pull") && strings.HasSuffix(action, ")")
+}
+
+// undoPlan walks the operations newest first. Each undo hides one more
+// earlier operation, each redo brings one back, and the first operation
+// left is the one to undo. Resetting past an operation run elsewhere would
+// throw it away, so the walk stops there.
+func undoPlan(entries []ReflogEntry) (UndoStep, error) {
+	hidden := 0
+	for _, op := range reflogOps(entries) {
+		switch op.marker {
+		case undoMarker:
+			hidden++
+			continue
+		case redoMarker:
+			hidden--
+			continue
+		}
+		step, ok := reverseOp(op)
+		if !ok {
+			continue // moved nothing, e.g. an aborted rebase
+		}
+		if !op.ours {
+			return UndoStep{}, ErrUndoExternal
+		}
+		if hidden == 0 {
+			return step, nil
+		}
+		hidden--
+	}
+	return UndoStep{}, ErrNothingToUndo
+}
+
+// redoPlan walks back over the undos and redos since the last operation.
+// The first undo not cancelled by a later redo is the one to redo.
+func redoPlan(entries []ReflogEntry) (UndoStep, error) {
+	redone := 0
+	for _, op := range reflogOps(entries) {
+		switch op.marker {
+		case redoMarker:
+			redone++
+		case undoMarker:
+			if redone == 0 {
+				step, ok := reverseOp(op)
+				if !ok {
+					return UndoStep{}, ErrNothingToRedo
+				}
+				step.Redo = true
+				return step, nil
+			}
+			redone--
+		default:
+			// An operation after the undos replaces what they undid.
+			return UndoStep{}, ErrNothingToRedo
+		}
+	}
+	return UndoStep{}, ErrNothingToRedo
+}
+
+// reverseOp works out how to return HEAD to where it was before op. It
+// reports false if op did not move HEAD or where it came from is unknown.
+func reverseOp(op reflogOp) (UndoStep, bool) {
+	step := UndoStep{Entry: op.entry}
+	action := strings.TrimSpace(strings.TrimPrefix(op.entry.Action, op.marker))
+	if action == "checkout" {
+		from, to, ok := strings.Cut(strings.TrimPrefix(op.entry.Message, "moving from "), " to ")
+		if !ok || from == to {
+			return step, false
+		}
+		step.Checkout = from
+		return step, true
+	}
+	if op.before == "" || op.before == op.entry.New {
+		return step, false
+	}
+	step.Reset = op.before
+	step.Soft = action == "commit" || action == "commit (amend)"
+	return step, true
+}
+
+// parseReflog parses `log --walk-reflogs --date=unix` output in the format
+// "%H%x00%gd%x00%gs", where %gd is "<ref>@{<unix time>}". Entries are
+// newest first, so each one's old hash is the next one's new hash.
+func parseReflog(out, ref string) []ReflogEntry {
+	var entries []ReflogEntry
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.SplitN(line, "\x00", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		e := ReflogEntry{
+			Selector: fmt.Sprintf("%s@{%d}", ref, len(entries)),
+			New:      fields[0],
+		}
+		if i := strings.LastIndex(fields[1], "@{"); i >= 0 {
+			e.Date = parseUnix(strings.TrimSuffix(fields[1][i+2:], "}"))
+		}
+		e.Action, e.Message, _ = strings.Cut(fields[2], ": ")
+		e.Action = strings.TrimSuffix(e.Action, ":")
+		if action, ok := strings.CutPrefix(e.Action, reflogMarker+" "); ok {
+			e.Action, e.Ours = action, true
+		}
+		entries = append(entries, e)
+	}
+	for i := 0; i+1 < len(entries); i++ {
+		entries[i].Old = entries[i+1].New
+	}
+	return entries
+}
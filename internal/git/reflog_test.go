@@ -0,0 +1,110 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+func TestUndoRedo(t *testing.T) {
+	f, repo := rebaseFixture(t)
+	four := f.Git("rev-parse", "HEAD")
+	two := f.Git("rev-parse", "HEAD~2")
+
+	apply := func(plan func() (git.UndoStep, error)) git.UndoStep {
+		t.Helper()
+		step, err := plan()
+		if err != nil {
+			t.Fatalf("plan: %v", err)
+		}
+		if err := repo.ApplyUndo(step); err != nil {
+			t.Fatalf("ApplyUndo(%+v): %v", step, err)
+		}
+		return step
+	}
+	head := func() string { return f.Git("rev-parse", "HEAD") }
+
+	// Drop "two" in a rebase, then switch branches.
+	commits, err := repo.RebaseCommits(two)
+	if err != nil {
+		t.Fatal(err)
+	}
+	todo := make([]git.RebaseTodoItem, len(commits))
+	for i, c := range commits {
+		todo[i] = git.RebaseTodoItem{Action: git.RebasePick, Commit: c}
+	}
+	todo[0].Action = git.RebaseDrop
+	if err := repo.InteractiveRebase(two, todo); err != nil {
+		t.Fatalf("InteractiveRebase: %v", err)
+	}
+	rebased := head()
+	if err := repo.CreateBranch("feature", "HEAD", true); err != nil {
+		t.Fatalf("CreateBranch: %v", err)
+	}
+
+	if step := apply(repo.UndoPlan); step.Checkout != "main" {
+		t.Fatalf("first undo = %+v, want a checkout of main", step)
+	}
+	if step := apply(repo.UndoPlan); step.Reset != four || head() != four {
+		t.Fatalf("undo of the rebase = %+v, HEAD %s, want %s", step, head(), four)
+	}
+	if step := apply(repo.RedoPlan); !step.Redo || head() != rebased {
+		t.Fatalf("redo = %+v, HEAD %s, want %s", step, head(), rebased)
+	}
+	if step := apply(repo.RedoPlan); step.Checkout != "feature" {
+		t.Fatalf("second redo = %+v, want a checkout of feature", step)
+	}
+	if _, err := repo.RedoPlan(); err != git.ErrNothingToRedo {
+		t.Fatalf("RedoPlan with nothing undone = %v, want ErrNothingToRedo", err)
+	}
+
+	// A new operation after an undo ends what can be redone.
+	apply(repo.UndoPlan)
+	f.WriteFile("five.txt", "five\n")
+	f.Git("add", "five.txt")
+	if err := repo.Commit("five"); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	if _, err := repo.RedoPlan(); err != git.ErrNothingToRedo {
+		t.Fatalf("RedoPlan after a commit = %v, want ErrNothingToRedo", err)
+	}
+	if entries, _ := repo.Reflog("HEAD", 1); len(entries) != 1 || entries[0].Action != "commit" || !entries[0].Ours {
+		t.Errorf("reflog entry of Commit = %+v, want one of ours", entries)
+	}
+
+	// Undoing a commit keeps its changes staged, and redoing it commits
+	// them again.
+	five := head()
+	if step := apply(repo.UndoPlan); !step.Soft || head() == five {
+		t.Fatalf("undo of a commit = %+v, want a soft reset", step)
+	}
+	if staged := f.Git("diff", "--cached", "--name-only"); staged != "five.txt" {
+		t.Errorf("staged after undoing the commit = %q, want five.txt", staged)
+	}
+	if step := apply(repo.RedoPlan); !step.Soft || head() != five {
+		t.Fatalf("redo of the commit = %+v, HEAD %s, want %s", step, head(), five)
+	}
+	if status := f.Git("status", "--porcelain"); status != "" {
+		t.Errorf("status after redo = %q, want clean", status)
+	}
+
+	// Operations run outside lazygit-lite are not undone, nor is anything
+	// before them.
+	f.WriteFile("six.txt", "six\n")
+	f.Commit("six")
+	if _, err := repo.UndoPlan(); err != git.ErrUndoExternal {
+		t.Fatalf("UndoPlan after an outside commit = %v, want ErrUndoExternal", err)
+	}
+
+	entries, err := repo.Reflog("HEAD", 3)
+	if err != nil {
+		t.Fatalf("Reflog: %v", err)
+	}
+	if len(entries) != 3 || entries[0].Action != "commit" || entries[0].Message != "six" || entries[0].Ours ||
+		entries[0].Old != entries[1].New || entries[0].Selector != "HEAD@{0}" {
+		t.Fatalf("Reflog = %+v", entries)
+	}
+	if e := entries[1]; e.Action != "[lazygit-lite redo] commit" || e.Ours {
+		t.Errorf("reflog entry of the redo = %+v", e)
+	}
+}
@@ -0,0 +1,190 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrDetachedHead is returned by operations that need a current branch.
+var ErrDetachedHead = errors.New("HEAD is detached, check out a branch first")
+
+// Remote is a configured remote with its fetch and push URLs.
+type Remote struct {
+	Name     string
+	FetchURL string
+	PushURL  string // equal to FetchURL unless a separate push URL is set
+}
+
+// PushOptions selects where Push sends a branch and how. The zero value
+// is a plain `git push` to the configured upstream.
+type PushOptions struct {
+	Remote       string // "" pushes to the upstream, and ignores Branch
+	Branch       string // local branch to push
+	RemoteBranch string // branch name on the remote; defaults to Branch
+	SetUpstream  bool   // make RemoteBranch the upstream of Branch
+
+	// ForceWithLease overwrites the remote branch only if it still is
+	// where it was expected: at Expect if set, otherwise at its
+	// remote-tracking branch. Expect needs Remote and Branch.
+	ForceWithLease bool
+	Expect         string
+
+	Force    bool // overwrite the remote branch whatever it points at
+	Tags     bool // also push all tags
+	NoVerify bool // skip the pre-push hook
+}
+
+// GetRemotes returns the configured remotes in `git remote` order.
+func (r *Repository) GetRemotes() ([]Remote, error) {
+	out, err := r.run("remote", "-v")
+	if err != nil {
+		return nil, err
+	}
+	return parseRemotes(out), nil
+}
+
+// parseRemotes parses `git remote -v` output: "name<TAB>url (fetch)" and
+// "name<TAB>url (push)" lines per remote.
+func parseRemotes(out string) []Remote {
+	var remotes []Remote
+	index := make(map[string]int)
+	for _, line := range strings.Split(out, "\n") {
+		name, rest, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		url, kind, _ := strings.Cut(rest, " ")
+		i, seen := index[name]
+		if !seen {
+			i = len(remotes)
+			index[name] = i
+			remotes = append(remotes, Remote{Name: name})
+		}
+		switch kind {
+		case "(fetch)":
+			remotes[i].FetchURL = url
+		case "(push)":
+			remotes[i].PushURL = url
+		}
+	}
+	return remotes
+}
+
+// AddRemote adds a remote without fetching it.
+func (r *Repository) AddRemote(name, url string) error {
+	_, err := r.run("remote", "add", name, url)
+	return err
+}
+
+// RemoveRemote removes a remote with its remote-tracking branches and
+// configuration.
+func (r *Repository) RemoveRemote(name string) error {
+	_, err := r.run("remote", "remove", name)
+	return err
+}
+
+// RenameRemote renames a remote, updating its remote-tracking branches and
+// the upstreams that use it.
+func (r *Repository) RenameRemote(oldName, newName string) error {
+	_, err := r.run("remote", "rename", oldName, newName)
+	return err
+}
+
+// SetRemoteURL changes the URL of a remote.
+func (r *Repository) SetRemoteURL(name, url string) error {
+	_, err := r.run("remote", "set-url", name, url)
+	return err
+}
+
+// FetchRemote fetches a single remote. With prune, remote-tracking branches
+// deleted on the remote are removed too.
+func (r *Repository) FetchRemote(name string, prune bool) error {
+	args := []string{"fetch"}
+	if prune {
+		args = append(args, "--prune")
+	}
+	args = append(args, name)
+	_, err := r.run(args...)
+	return err
+}
+
+// Push pushes a branch as opts describe.
+func (r *Repository) Push(opts PushOptions) error {
+	_, err := r.run(pushArgs(opts)...)
+	return err
+}
+
+// PushOverwrites fetches the remote branch a push of opts goes to and
+// returns the commit it points at and the commits on it that are not in
+// opts.Branch, newest first: those a force push would throw away.
+func (r *Repository) PushOverwrites(opts PushOptions) (tip string, lost []*Commit, err error) {
+	if _, err := r.run("fetch", opts.Remote, "refs/heads/"+remoteBranchOf(opts)); err != nil {
+		return "", nil, err
+	}
+	out, err := r.run("rev-parse", "--verify", "FETCH_HEAD^{commit}")
+	if err != nil {
+		return "", nil, err
+	}
+	tip = strings.TrimSpace(out)
+	out, err = r.run("log", "--format="+logFormat, tip, "--not", "refs/heads/"+opts.Branch, "--")
+	if err != nil {
+		return "", nil, err
+	}
+	return tip, parseLog(out, nil), nil
+}
+
+func pushArgs(opts PushOptions) []string {
+	args := []string{"push"}
+	if opts.SetUpstream {
+		args = append(args, "--set-upstream")
+	}
+	if opts.Force {
+		args = append(args, "--force")
+	}
+	if opts.ForceWithLease {
+		lease := "--force-with-lease"
+		if opts.Expect != "" && opts.Branch != "" {
+			lease += "=refs/heads/" + remoteBranchOf(opts) + ":" + opts.Expect
+		}
+		args = append(args, lease)
+	}
+	if opts.Tags {
+		args = append(args, "--tags")
+	}
+	if opts.NoVerify {
+		args = append(args, "--no-verify")
+	}
+	if opts.Remote == "" {
+		return args
+	}
+	args = append(args, opts.Remote)
+	if opts.Branch != "" {
+		refspec := opts.Branch
+		if remote := remoteBranchOf(opts); remote != opts.Branch {
+			refspec += ":" + remote
+		}
+		args = append(args, refspec)
+	}
+	return args
+}
+
+func remoteBranchOf(opts PushOptions) string {
+	if opts.RemoteBranch != "" {
+		return opts.RemoteBranch
+	}
+	return opts.Branch
+}
+
+// CurrentBranch returns the checked-out branch, or ErrDetachedHead.
+func (r *Repository) CurrentBranch() (*Branch, error) {
+	branches, err := r.GetBranches()
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range branches {
+		if b.IsCurrent {
+			return b, nil
+		}
+	}
+	return nil, ErrDetachedHead
+}
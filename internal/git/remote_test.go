@@ -0,0 +1,173 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestRemoteManagement(t *testing.T) {
+	f := gittest.NewFixture(t)
+	repo := openFixture(t, f)
+
+	if err := repo.AddRemote("origin", "https://example.com/origin.git"); err != nil {
+		t.Fatalf("AddRemote: %v", err)
+	}
+	if err := repo.AddRemote("fork", "https://example.com/fork.git"); err != nil {
+		t.Fatalf("AddRemote: %v", err)
+	}
+	f.Git("remote", "set-url", "--push", "fork", "git@example.com:fork.git")
+
+	remotes, err := repo.GetRemotes()
+	if err != nil {
+		t.Fatalf("GetRemotes: %v", err)
+	}
+	want := []git.Remote{
+		{Name: "fork", FetchURL: "https://example.com/fork.git", PushURL: "git@example.com:fork.git"},
+		{Name: "origin", FetchURL: "https://example.com/origin.git", PushURL: "https://example.com/origin.git"},
+	}
+	if len(remotes) != len(want) {
+		t.Fatalf("remotes = %+v, want %+v", remotes, want)
+	}
+	for i := range want {
+		if remotes[i] != want[i] {
+			t.Errorf("remote %d = %+v, want %+v", i, remotes[i], want[i])
+		}
+	}
+
+	if err := repo.RenameRemote("fork", "mine"); err != nil {
+		t.Fatalf("RenameRemote: %v", err)
+	}
+	if err := repo.SetRemoteURL("mine", "https://example.com/mine.git"); err != nil {
+		t.Fatalf("SetRemoteURL: %v", err)
+	}
+	if got := f.Git("remote", "get-url", "mine"); got != "https://example.com/mine.git" {
+		t.Errorf("mine URL = %q", got)
+	}
+	if err := repo.RemoveRemote("origin"); err != nil {
+		t.Fatalf("RemoveRemote: %v", err)
+	}
+	if got := f.Git("remote"); got != "mine" {
+		t.Errorf("remotes = %q, want only mine", got)
+	}
+}
+
+func TestFetchRemoteAndPush(t *testing.T) {
+	upstream := gittest.NewFixture(t)
+	upstream.Commit("initial")
+	upstream.Git("branch", "stale")
+	upstream.Git("config", "receive.denyCurrentBranch", "ignore")
+
+	f := gittest.NewFixture(t)
+	f.Git("remote", "add", "origin", upstream.Dir)
+	repo := openFixture(t, f)
+
+	if err := repo.FetchRemote("origin", false); err != nil {
+		t.Fatalf("FetchRemote: %v", err)
+	}
+	if got := f.Git("branch", "-r"); got != "origin/main\n  origin/stale" {
+		t.Errorf("remote branches = %q", got)
+	}
+	upstream.Git("branch", "-D", "stale")
+	if err := repo.FetchRemote("origin", true); err != nil {
+		t.Fatalf("FetchRemote prune: %v", err)
+	}
+	if got := f.Git("branch", "-r"); got != "origin/main" {
+		t.Errorf("remote branches after prune = %q", got)
+	}
+
+	f.Git("checkout", "-q", "-b", "feature", "origin/main")
+	f.Git("branch", "--unset-upstream")
+	f.Commit("feature work")
+	err := repo.Push(git.PushOptions{Remote: "origin", Branch: "feature", RemoteBranch: "published", SetUpstream: true})
+	if err != nil {
+		t.Fatalf("Push: %v", err)
+	}
+	if got, want := upstream.Git("rev-parse", "published"), f.Git("rev-parse", "HEAD"); got != want {
+		t.Errorf("remote published = %s, want %s", got, want)
+	}
+	b, err := repo.CurrentBranch()
+	if err != nil || b.Name != "feature" || b.Upstream != "origin/published" {
+		t.Errorf("CurrentBranch = %+v, %v; want feature tracking origin/published", b, err)
+	}
+}
+
+func TestPushTagsWithoutHooks(t *testing.T) {
+	upstream := gittest.NewFixture(t)
+	upstream.Commit("initial")
+	upstream.Git("config", "receive.denyCurrentBranch", "ignore")
+
+	f := gittest.NewFixture(t)
+	f.Git("remote", "add", "origin", upstream.Dir)
+	f.Git("fetch", "-q", "origin")
+	f.Git("checkout", "-q", "-b", "feature", "origin/main")
+	f.Commit("feature work")
+	f.Git("tag", "v1.0")
+	hook := filepath.Join(f.Dir, ".git", "hooks", "pre-push")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\nexit 1\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	repo := openFixture(t, f)
+
+	opts := git.PushOptions{Remote: "origin", Branch: "feature", Tags: true}
+	if err := repo.Push(opts); err == nil {
+		t.Fatal("Push ran despite the failing pre-push hook")
+	}
+	opts.NoVerify = true
+	if err := repo.Push(opts); err != nil {
+		t.Fatalf("Push(NoVerify): %v", err)
+	}
+	if got, want := upstream.Git("rev-parse", "v1.0"), f.Git("rev-parse", "v1.0"); got != want {
+		t.Errorf("remote v1.0 = %s, want %s", got, want)
+	}
+	if got, want := upstream.Git("rev-parse", "feature"), f.Git("rev-parse", "HEAD"); got != want {
+		t.Errorf("remote feature = %s, want %s", got, want)
+	}
+}
+
+func TestForcePushWithLease(t *testing.T) {
+	upstream := gittest.NewFixture(t)
+	upstream.Commit("initial")
+	upstream.Git("config", "receive.denyCurrentBranch", "ignore")
+
+	f := gittest.NewFixture(t)
+	f.Git("remote", "add", "origin", upstream.Dir)
+	f.Git("fetch", "-q", "origin")
+	f.Git("reset", "-q", "--hard", "origin/main")
+	f.Git("branch", "-q", "--set-upstream-to", "origin/main")
+	repo := openFixture(t, f)
+
+	// Someone else pushes while we rewrite the commit we share.
+	upstream.Commit("theirs")
+	f.Git("commit", "-q", "--amend", "--allow-empty", "-m", "rewritten")
+
+	err := repo.Push(git.PushOptions{})
+	if !git.IsErrorKind(err, git.ErrorKindNonFastForward) {
+		t.Fatalf("Push = %v, want a non-fast-forward rejection", err)
+	}
+
+	opts := git.PushOptions{Remote: "origin", Branch: "main"}
+	tip, lost, err := repo.PushOverwrites(opts)
+	if err != nil {
+		t.Fatalf("PushOverwrites: %v", err)
+	}
+	if len(lost) != 2 || lost[0].Subject != "theirs" || lost[1].Subject != "initial" || tip != lost[0].Hash {
+		t.Fatalf("PushOverwrites = %s, %+v; want theirs and initial", tip, lost)
+	}
+
+	// A lease on a commit the remote has moved past is refused.
+	opts.ForceWithLease, opts.Expect = true, lost[1].Hash
+	if err := repo.Push(opts); !git.IsErrorKind(err, git.ErrorKindNonFastForward) {
+		t.Fatalf("Push with a stale lease = %v, want a rejection", err)
+	}
+	opts.Expect = tip
+	if err := repo.Push(opts); err != nil {
+		t.Fatalf("Push with lease: %v", err)
+	}
+	if got, want := upstream.Git("rev-parse", "main"), f.Git("rev-parse", "HEAD"); got != want {
+		t.Errorf("remote main = %s, want %s", got, want)
+	}
+}
@@ -2,9 +2,9 @@ package git
 
 import (
 	"fmt"
-	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-git/go-git/v5"
@@ -12,8 +12,25 @@ import (
 )
 
 type Repository struct {
-	repo *git.Repository
-	path string
+	repo   *git.Repository
+	path   string
+	runner Runner
+
+	// stashes is the stash list GetCommits read for its first page,
+	// reused by the later pages so they line up with it.
+	stashMu sync.Mutex
+	stashes *stashPage
+}
+
+// Option configures a Repository opened with OpenRepository.
+type Option func(*Repository)
+
+// WithRunner makes the repository execute git commands through runner
+// instead of the git binary on PATH. Used by tests to stub out git.
+func WithRunner(runner Runner) Option {
+	return func(r *Repository) {
+		r.runner = runner
+	}
 }
 
 type Commit struct {
@@ -21,11 +38,20 @@ type Commit struct {
 	ShortHash string
 	Author    string
 	Email     string
-	Date      time.Time
-	Message   string
-	Subject   string
-	Parents   []string
-	Refs      []Ref
+	Date      time.Time // author date
+
+	// Committer differs from the author for rebased, amended or
+	// cherry-picked commits.
+	Committer      string
+	CommitterEmail string
+	CommitDate     time.Time
+
+	Message  string    // full message: subject, body and trailers
+	Subject  string    // first paragraph, joined into one line
+	Body     string    // message after the subject, without the trailer block
+	Trailers []Trailer // Signed-off-by, Co-authored-by, ... in message order
+	Parents  []string
+	Refs     []Ref
 }
 
 type Ref struct {
@@ -33,6 +59,10 @@ type Ref struct {
 	RefType  RefType
 	IsHead   bool
 	IsRemote bool
+
+	// Worktree is the path of the other worktree that has this branch
+	// checked out, or "".
+	Worktree string
 }
 
 type RefType int
@@ -40,6 +70,7 @@ type RefType int
 const (
 	RefTypeBranch RefType = iota
 	RefTypeTag
+	RefTypeStash
 )
 
 // UncommittedHash is a sentinel hash used for the synthetic "Uncommitted changes"
@@ -50,10 +81,23 @@ const UncommittedHash = "0000000000000000000000000000000000000000"
 const UncommittedShortHash = "·······"
 
 type ChangedFile struct {
-	Status    string // "A" added, "M" modified, "D" deleted, "R" renamed, "?" untracked
+	Status    string // "A" added, "M" modified, "D" deleted, "R" renamed, "?" untracked, "U" unmerged
 	Path      string
-	Additions int // lines added (0 for binary files)
-	Deletions int // lines removed (0 for binary files)
+	OldPath   string // previous path of a renamed or copied file
+	Additions int    // lines added (0 for binary files)
+	Deletions int    // lines removed (0 for binary files)
+
+	// Index state, only populated for working tree files.
+	Staged   bool // the index differs from HEAD for this path
+	Unstaged bool // the working tree differs from the index (or is untracked)
+
+	// Conflict is the porcelain XY code of an unmerged path ("UU" both
+	// modified, "AA" both added, "DU" deleted by us, ...), or "".
+	Conflict string
+
+	// Submodule is set when the path is a submodule, whose diff is the
+	// range of commits it moved over rather than file contents.
+	Submodule bool
 }
 
 type Branch struct {
@@ -61,18 +105,43 @@ type Branch struct {
 	IsHead    bool
 	IsCurrent bool
 	Hash      string
+
+	// Upstream is the remote-tracking branch this branch pulls from, e.g.
+	// "origin/main", or "" if none is configured.
+	Upstream       string
+	UpstreamRemote string // remote name part of Upstream, e.g. "origin", or "." for a local branch
+	UpstreamRef    string // upstream as named on its remote, e.g. "refs/heads/main"
+
+	// Ahead and Behind count the commits on this branch that its upstream
+	// lacks and the other way round, as of the last fetch. UpstreamGone is
+	// set when the upstream is configured but no longer exists on the
+	// remote.
+	Ahead        int
+	Behind       int
+	UpstreamGone bool
+
+	// Worktree is the path of the other worktree that has this branch
+	// checked out, or "". git refuses to check it out here too.
+	Worktree string
 }
 
-func OpenRepository(path string) (*Repository, error) {
-	repo, err := git.PlainOpen(path)
+func OpenRepository(path string, opts ...Option) (*Repository, error) {
+	// In a linked worktree, refs live in the main repository's git
+	// directory rather than the worktree's own.
+	repo, err := git.PlainOpenWithOptions(path, &git.PlainOpenOptions{EnableDotGitCommonDir: true})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Repository{
-		repo: repo,
-		path: path,
-	}, nil
+	r := &Repository{
+		repo:   repo,
+		path:   path,
+		runner: ExecRunner{},
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r, nil
 }
 
 // Path returns the filesystem path of the repository root.
@@ -80,81 +149,200 @@ func (r *Repository) Path() string {
 	return r.path
 }
 
-func (r *Repository) GetCommits(limit int) ([]*Commit, error) {
-	refMap := r.buildRefMap()
+// GitDir returns the absolute path of the repository's git directory, which
+// holds in-progress operation state such as rebase-merge/.
+func (r *Repository) GitDir() (string, error) {
+	out, err := r.run("rev-parse", "--absolute-git-dir")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// GetCommits returns up to limit commits from all refs in topological order,
+// skipping the first offset. Successive pages (offset 0, limit, 2*limit, ...)
+// line up as long as the refs do not change between calls.
+//
+// Stash entries are listed just above the commit they were made on and do
+// not count towards offset and limit; see HistoryLen. The stash list is
+// read with the first page and reused for the ones after it.
+func (r *Repository) GetCommits(offset, limit int) ([]*Commit, error) {
+	stashes := r.stashPage(offset == 0)
+	refMap := r.buildRefMap(stashes.list)
 
 	// Use git log shell command instead of go-git's Log, which fails to
 	// return commits from all branches in proper topological order.
-	// Delimiter \x00 (NUL) is safe — it cannot appear in commit metadata.
-	format := "%H%x00%P%x00%an%x00%ae%x00%at%x00%s"
+	// Walking refs/stash would also list each stash's index and untracked
+	// files commits, so only the commits the stashes were made on are
+	// walked and the stashes themselves are inserted above them.
 	args := []string{
-		"-C", r.path,
-		"log", "--all", "--topo-order",
-		fmt.Sprintf("--format=%s", format),
+		"log", "--exclude=refs/stash", "--all", "--topo-order",
+		"--format=" + logFormat,
+		fmt.Sprintf("--skip=%d", offset),
 		fmt.Sprintf("-%d", limit),
 	}
+	for _, s := range stashes.list {
+		args = append(args, s.Hash+"^")
+	}
+	out, err := r.run(args...)
+	if err != nil {
+		return nil, err
+	}
 
-	cmd := exec.Command("git", args...)
-	out, err := cmd.Output()
+	history := parseLog(out, refMap)
+	if len(stashes.byBase) == 0 {
+		return history, nil
+	}
+	commits := make([]*Commit, 0, len(history))
+	for _, c := range history {
+		commits = append(commits, stashes.byBase[c.Hash]...)
+		commits = append(commits, c)
+	}
+	return commits, nil
+}
+
+// stashPage is the stash list as read for the first page of GetCommits.
+type stashPage struct {
+	list   []Stash
+	byBase map[string][]*Commit // stash commits by the commit they were made on
+}
+
+// stashPage returns the stash list for GetCommits, reading it again when
+// reload is set or it has not been read yet.
+func (r *Repository) stashPage(reload bool) *stashPage {
+	r.stashMu.Lock()
+	defer r.stashMu.Unlock()
+	if reload || r.stashes == nil {
+		r.stashes = r.loadStashPage()
+	}
+	return r.stashes
+}
+
+func (r *Repository) loadStashPage() *stashPage {
+	page := &stashPage{byBase: make(map[string][]*Commit)}
+	stashes, _ := r.GetStashes() // best-effort
+	if len(stashes) == 0 {
+		return page
+	}
+
+	args := []string{"log", "--no-walk=unsorted", "--format=" + logFormat}
+	for _, s := range stashes {
+		args = append(args, s.Hash)
+	}
+	out, err := r.run(args...)
 	if err != nil {
-		return nil, fmt.Errorf("git log: %w", err)
+		return page
+	}
+	page.list = stashes
+	for _, c := range parseLog(out, r.buildRefMap(stashes)) {
+		if len(c.Parents) == 0 {
+			continue
+		}
+		// Drop the index and untracked files parents, which are not
+		// shown, so the graph does not draw edges to them.
+		c.Parents = c.Parents[:1]
+		page.byBase[c.Parents[0]] = append(page.byBase[c.Parents[0]], c)
 	}
+	return page
+}
+
+// IsStash reports whether c is a stash entry.
+func (c *Commit) IsStash() bool {
+	for _, ref := range c.Refs {
+		if ref.RefType == RefTypeStash {
+			return true
+		}
+	}
+	return false
+}
+
+// HistoryLen returns how many of commits count towards the offset and
+// limit of GetCommits: all but the stash entries.
+func HistoryLen(commits []*Commit) int {
+	n := 0
+	for _, c := range commits {
+		if !c.IsStash() {
+			n++
+		}
+	}
+	return n
+}
+
+// logFormat is the git log format parsed by parseLog. Each record starts
+// with \x1e and fields are separated by \x00 (NUL), which cannot appear in
+// commit metadata. The raw message comes last since it may span many lines.
+const logFormat = "%x1e%H%x00%P%x00%an%x00%ae%x00%at%x00%cn%x00%ce%x00%ct%x00%s%x00%(trailers:only,unfold)%x00%B"
 
-	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
-	commits := make([]*Commit, 0, len(lines))
+// parseLog parses the records printed with logFormat.
+func parseLog(out string, refMap map[string][]Ref) []*Commit {
+	records := strings.Split(out, "\x1e")
+	commits := make([]*Commit, 0, len(records))
 
-	for _, line := range lines {
-		if line == "" {
+	for _, record := range records {
+		if record == "" {
 			continue
 		}
 
-		parts := strings.SplitN(line, "\x00", 6)
-		if len(parts) < 6 {
-			continue // malformed line
+		parts := strings.SplitN(record, "\x00", 11)
+		if len(parts) < 11 {
+			continue // malformed record
 		}
 
 		hash := parts[0]
-		parentStr := parts[1]
-		author := parts[2]
-		email := parts[3]
-		tsStr := parts[4]
-		subject := parts[5]
-
 		var parents []string
-		if parentStr != "" {
-			parents = strings.Split(parentStr, " ")
+		if parts[1] != "" {
+			parents = strings.Split(parts[1], " ")
 		}
 
-		ts, err := strconv.ParseInt(tsStr, 10, 64)
-		if err != nil {
-			ts = 0
-		}
+		message := strings.TrimRight(parts[10], "\n")
+		trailers := parseTrailers(parts[9])
 
-		refs := refMap[hash]
 		shortHash := hash
 		if len(hash) >= 7 {
 			shortHash = hash[:7]
 		}
 
 		commits = append(commits, &Commit{
-			Hash:      hash,
-			ShortHash: shortHash,
-			Author:    author,
-			Email:     email,
-			Date:      time.Unix(ts, 0),
-			Message:   subject,
-			Subject:   subject,
-			Parents:   parents,
-			Refs:      refs,
+			Hash:           hash,
+			ShortHash:      shortHash,
+			Author:         parts[2],
+			Email:          parts[3],
+			Date:           parseUnix(parts[4]),
+			Committer:      parts[5],
+			CommitterEmail: parts[6],
+			CommitDate:     parseUnix(parts[7]),
+			Message:        message,
+			Subject:        parts[8],
+			Body:           messageBody(message, len(trailers) > 0),
+			Trailers:       trailers,
+			Parents:        parents,
+			Refs:           refMap[hash],
 		})
 	}
 
-	return commits, nil
+	return commits
+}
+
+// parseUnix parses a Unix timestamp, returning the zero epoch time if it
+// is malformed.
+func parseUnix(s string) time.Time {
+	ts, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		ts = 0
+	}
+	return time.Unix(ts, 0)
 }
 
-func (r *Repository) buildRefMap() map[string][]Ref {
+// buildRefMap maps commit hashes to the branches, tags and stashes that
+// point at them.
+func (r *Repository) buildRefMap(stashes []Stash) map[string][]Ref {
 	refMap := make(map[string][]Ref)
 
+	// refs/stash only names the latest stash; the rest live in its reflog.
+	for _, s := range stashes {
+		refMap[s.Hash] = append(refMap[s.Hash], Ref{Name: s.Name, RefType: RefTypeStash})
+	}
+
 	head, _ := r.repo.Head()
 	headName := ""
 	if head != nil {
@@ -165,6 +353,7 @@ func (r *Repository) buildRefMap() map[string][]Ref {
 	if err != nil {
 		return refMap
 	}
+	worktrees := r.otherWorktreeBranches()
 
 	refs.ForEach(func(ref *plumbing.Reference) error {
 		hash := ref.Hash().String()
@@ -176,6 +365,7 @@ func (r *Repository) buildRefMap() map[string][]Ref {
 				RefType:  RefTypeBranch,
 				IsHead:   name.String() == headName,
 				IsRemote: false,
+				Worktree: worktrees[name.Short()],
 			})
 		} else if name.IsRemote() {
 			refMap[hash] = append(refMap[hash], Ref{
@@ -185,6 +375,10 @@ func (r *Repository) buildRefMap() map[string][]Ref {
 				IsRemote: true,
 			})
 		} else if name.IsTag() {
+			// Annotated tags point at a tag object; decorate its commit.
+			if tag, err := r.repo.TagObject(ref.Hash()); err == nil {
+				hash = tag.Target.String()
+			}
 			refMap[hash] = append(refMap[hash], Ref{
 				Name:     name.Short(),
 				RefType:  RefTypeTag,
@@ -211,17 +405,30 @@ func (r *Repository) GetBranches() ([]*Branch, error) {
 		return nil, err
 	}
 
+	cfg, err := r.repo.Config()
+	if err != nil {
+		return nil, err
+	}
+	worktrees := r.otherWorktreeBranches()
+
 	err = refs.ForEach(func(ref *plumbing.Reference) error {
 		if ref.Name().IsBranch() {
 			branchName := ref.Name().Short()
 			isHead := ref.Name() == head.Name()
 
-			branches = append(branches, &Branch{
+			branch := &Branch{
 				Name:      branchName,
 				IsHead:    isHead,
 				IsCurrent: isHead,
 				Hash:      ref.Hash().String(),
-			})
+				Worktree:  worktrees[branchName],
+			}
+			if bc := cfg.Branches[branchName]; bc != nil && bc.Remote != "" && bc.Merge != "" {
+				branch.Upstream = bc.Remote + "/" + bc.Merge.Short()
+				branch.UpstreamRemote = bc.Remote
+				branch.UpstreamRef = bc.Merge.String()
+			}
+			branches = append(branches, branch)
 		}
 		return nil
 	})
@@ -230,5 +437,6 @@ func (r *Repository) GetBranches() ([]*Branch, error) {
 		return nil, err
 	}
 
+	r.applyTracking(branches)
 	return branches, nil
 }
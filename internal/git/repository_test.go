@@ -0,0 +1,320 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func openFixture(t *testing.T, f *gittest.Fixture, opts ...git.Option) *git.Repository {
+	t.Helper()
+	repo, err := git.OpenRepository(f.Dir, opts...)
+	if err != nil {
+		t.Fatalf("OpenRepository: %v", err)
+	}
+	return repo
+}
+
+func findFile(files []git.ChangedFile, path string) *git.ChangedFile {
+	for i := range files {
+		if files[i].Path == path {
+			return &files[i]
+		}
+	}
+	return nil
+}
+
+func TestGetCommits(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "one\n")
+	first := f.Commit("first commit")
+	f.WriteFile("a.txt", "one\ntwo\n")
+	second := f.Commit("second commit")
+	f.Git("checkout", "-q", "-b", "feature", first)
+	f.WriteFile("b.txt", "feature\n")
+	feature := f.Commit("feature work")
+	f.Git("checkout", "-q", "main")
+	f.Git("tag", "v1.0", first)
+
+	repo := openFixture(t, f)
+	commits, err := repo.GetCommits(0, 100)
+	if err != nil {
+		t.Fatalf("GetCommits: %v", err)
+	}
+	if len(commits) != 3 {
+		t.Fatalf("expected 3 commits, got %d", len(commits))
+	}
+
+	byHash := make(map[string]*git.Commit)
+	for _, c := range commits {
+		byHash[c.Hash] = c
+	}
+	if c := commits[len(commits)-1]; c.Hash != first {
+		t.Errorf("root commit should come last, got %s", c.Subject)
+	}
+
+	c := byHash[second]
+	if c == nil {
+		t.Fatal("second commit missing")
+	}
+	if c.Subject != "second commit" || c.Author != "Test User" || c.Email != "test@example.com" {
+		t.Errorf("unexpected metadata: %+v", c)
+	}
+	if c.ShortHash != second[:7] {
+		t.Errorf("ShortHash = %q, want %q", c.ShortHash, second[:7])
+	}
+	if len(c.Parents) != 1 || c.Parents[0] != first {
+		t.Errorf("Parents = %v, want [%s]", c.Parents, first)
+	}
+	if !hasRef(c.Refs, "main", git.RefTypeBranch) {
+		t.Errorf("second commit should carry main, got %+v", c.Refs)
+	}
+	if !hasRef(byHash[feature].Refs, "feature", git.RefTypeBranch) {
+		t.Errorf("feature commit should carry feature, got %+v", byHash[feature].Refs)
+	}
+	if !hasRef(byHash[first].Refs, "v1.0", git.RefTypeTag) {
+		t.Errorf("first commit should carry v1.0, got %+v", byHash[first].Refs)
+	}
+	if len(byHash[first].Parents) != 0 {
+		t.Errorf("root commit should have no parents, got %v", byHash[first].Parents)
+	}
+
+	page, err := repo.GetCommits(0, 2)
+	if err != nil {
+		t.Fatalf("GetCommits(0, 2): %v", err)
+	}
+	if len(page) != 2 {
+		t.Errorf("expected limit to be honoured, got %d commits", len(page))
+	}
+	rest, err := repo.GetCommits(2, 2)
+	if err != nil {
+		t.Fatalf("GetCommits(2, 2): %v", err)
+	}
+	if len(rest) != 1 || rest[0].Hash != commits[2].Hash {
+		t.Errorf("second page = %v, want only %s", rest, commits[2].Hash)
+	}
+}
+
+func TestGetCommitsMessageDetails(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	f.Git("add", "-A")
+	message := "Add a\n\nLonger explanation\nof the change.\n\n" +
+		"Signed-off-by: Test User <test@example.com>\n" +
+		"Co-authored-by: Ada <ada@example.com>"
+	f.Git("commit", "-q", "--author", "Ada <ada@example.com>", "-m", message)
+
+	repo := openFixture(t, f)
+	commits, err := repo.GetCommits(0, 10)
+	if err != nil {
+		t.Fatalf("GetCommits: %v", err)
+	}
+	if len(commits) != 1 {
+		t.Fatalf("expected 1 commit, got %d", len(commits))
+	}
+	c := commits[0]
+
+	if c.Message != message {
+		t.Errorf("Message = %q, want %q", c.Message, message)
+	}
+	if c.Subject != "Add a" {
+		t.Errorf("Subject = %q", c.Subject)
+	}
+	if c.Body != "Longer explanation\nof the change." {
+		t.Errorf("Body = %q", c.Body)
+	}
+	if c.Author != "Ada" || c.Email != "ada@example.com" {
+		t.Errorf("author = %s <%s>", c.Author, c.Email)
+	}
+	if c.Committer != "Test User" || c.CommitterEmail != "test@example.com" {
+		t.Errorf("committer = %s <%s>", c.Committer, c.CommitterEmail)
+	}
+	if got := c.TrailerValues("co-authored-by"); len(got) != 1 || got[0] != "Ada <ada@example.com>" {
+		t.Errorf("Co-authored-by = %v", got)
+	}
+	if len(c.Trailers) != 2 || c.Trailers[0].Key != "Signed-off-by" {
+		t.Errorf("Trailers = %+v", c.Trailers)
+	}
+}
+
+func hasRef(refs []git.Ref, name string, refType git.RefType) bool {
+	for _, r := range refs {
+		if r.Name == name && r.RefType == refType {
+			return true
+		}
+	}
+	return false
+}
+
+func TestGetChangedFiles(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("keep.txt", "a\nb\nc\n")
+	f.WriteFile("remove.txt", "bye\n")
+	f.WriteFile("move me.txt", "some content that is long enough to be detected as a rename\n")
+	f.Commit("initial")
+
+	f.WriteFile("keep.txt", "a\nB\nc\nd\n")
+	f.Remove("remove.txt")
+	f.Remove("move me.txt")
+	f.WriteFile("moved.txt", "some content that is long enough to be detected as a rename\n")
+	f.WriteFile("sub/new.txt", "x\ny\n")
+	hash := f.Commit("changes")
+
+	repo := openFixture(t, f)
+	files, err := repo.GetChangedFiles(hash)
+	if err != nil {
+		t.Fatalf("GetChangedFiles: %v", err)
+	}
+
+	tests := []struct {
+		path, status string
+		adds, dels   int
+	}{
+		{"keep.txt", "M", 2, 1},
+		{"remove.txt", "D", 0, 1},
+		{"sub/new.txt", "A", 2, 0},
+	}
+	for _, tt := range tests {
+		file := findFile(files, tt.path)
+		if file == nil {
+			t.Errorf("%s missing from %+v", tt.path, files)
+			continue
+		}
+		if file.Status != tt.status || file.Additions != tt.adds || file.Deletions != tt.dels {
+			t.Errorf("%s = %+v, want status %s +%d -%d", tt.path, *file, tt.status, tt.adds, tt.dels)
+		}
+	}
+
+	// diff-tree only detects renames when asked to; without -M the move
+	// shows up as a delete plus an add.
+	if findFile(files, "moved.txt") == nil {
+		t.Errorf("moved.txt missing from %+v", files)
+	}
+}
+
+func TestGetWorkingTreeFiles(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("staged.txt", "one\n")
+	f.WriteFile("unstaged.txt", "one\n")
+	f.WriteFile("partial.txt", "one\n")
+	f.WriteFile("old name.txt", "content that is long enough to count as the same file\n")
+	f.Commit("initial")
+
+	f.WriteFile("staged.txt", "one\ntwo\n")
+	f.Git("add", "staged.txt")
+	f.WriteFile("unstaged.txt", "changed\n")
+	f.WriteFile("partial.txt", "one\ntwo\n")
+	f.Git("add", "partial.txt")
+	f.WriteFile("partial.txt", "one\ntwo\nthree\n")
+	f.WriteFile("untracked.txt", "new\n")
+	f.Git("mv", "old name.txt", "new name.txt")
+
+	repo := openFixture(t, f)
+	files, err := repo.GetWorkingTreeFiles()
+	if err != nil {
+		t.Fatalf("GetWorkingTreeFiles: %v", err)
+	}
+
+	tests := []struct {
+		path, status     string
+		staged, unstaged bool
+		adds, dels       int
+	}{
+		{"staged.txt", "M", true, false, 1, 0},
+		{"unstaged.txt", "M", false, true, 1, 1},
+		{"partial.txt", "M", true, true, 2, 0},
+		{"untracked.txt", "?", false, true, 0, 0},
+	}
+	for _, tt := range tests {
+		file := findFile(files, tt.path)
+		if file == nil {
+			t.Errorf("%s missing from %+v", tt.path, files)
+			continue
+		}
+		if file.Status != tt.status || file.Staged != tt.staged || file.Unstaged != tt.unstaged {
+			t.Errorf("%s = %+v, want status %s staged=%v unstaged=%v", tt.path, *file, tt.status, tt.staged, tt.unstaged)
+		}
+		if file.Additions != tt.adds || file.Deletions != tt.dels {
+			t.Errorf("%s stats = +%d -%d, want +%d -%d", tt.path, file.Additions, file.Deletions, tt.adds, tt.dels)
+		}
+	}
+
+	renamed := findFile(files, "new name.txt")
+	if renamed == nil {
+		t.Fatalf("rename missing from %+v", files)
+	}
+	if renamed.Status != "R" || renamed.OldPath != "old name.txt" || !renamed.Staged {
+		t.Errorf("rename = %+v, want R from %q", *renamed, "old name.txt")
+	}
+}
+
+func TestGetWorkingTreeFilesWithFakeRunner(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.Commit("initial")
+
+	fake := gittest.NewFakeRunner()
+	fake.StubOutput("MM main.go\x00?? notes.md\x00", "status", "--porcelain", "-z")
+	fake.StubOutput("4\t2\tmain.go\x00", "diff", "--numstat", "-z", "HEAD")
+
+	repo := openFixture(t, f, git.WithRunner(fake))
+	files, err := repo.GetWorkingTreeFiles()
+	if err != nil {
+		t.Fatalf("GetWorkingTreeFiles: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %+v", files)
+	}
+	if got := files[0]; got.Path != "main.go" || !got.Staged || !got.Unstaged || got.Additions != 4 || got.Deletions != 2 {
+		t.Errorf("main.go = %+v", got)
+	}
+	if got := files[1]; got.Path != "notes.md" || got.Status != "?" || got.Staged {
+		t.Errorf("notes.md = %+v", got)
+	}
+	if !fake.Ran("status", "--porcelain", "-z") {
+		t.Error("expected status to go through the runner")
+	}
+}
+
+func TestGitErrorFromRunner(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.Commit("initial")
+
+	fake := gittest.NewFakeRunner()
+	fake.Stub(git.Result{
+		ExitCode: 1,
+		Stderr: []byte("To origin\n ! [rejected]        main -> main (non-fast-forward)\n" +
+			"error: failed to push some refs to 'origin'\n" +
+			"hint: Updates were rejected because the tip of your current branch is behind\n"),
+	}, "push")
+
+	repo := openFixture(t, f, git.WithRunner(fake))
+	err := repo.Push(git.PushOptions{})
+	if !git.IsErrorKind(err, git.ErrorKindNonFastForward) {
+		t.Fatalf("expected non-fast-forward error, got %v", err)
+	}
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	f.Commit("initial")
+	f.WriteFile("a.txt", "b\n")
+
+	recorder := gittest.NewRecordingRunner(git.ExecRunner{})
+	live, err := openFixture(t, f, git.WithRunner(recorder)).GetWorkingTreeFiles()
+	if err != nil {
+		t.Fatalf("recording: %v", err)
+	}
+
+	replayer := gittest.NewFakeRunner()
+	replayer.Replay(recorder.Calls())
+	f.WriteFile("a.txt", "a\n") // the replay must not see this
+	replayed, err := openFixture(t, f, git.WithRunner(replayer)).GetWorkingTreeFiles()
+	if err != nil {
+		t.Fatalf("replaying: %v", err)
+	}
+	if len(live) != 1 || len(replayed) != 1 || live[0] != replayed[0] {
+		t.Errorf("replay = %+v, want %+v", replayed, live)
+	}
+}
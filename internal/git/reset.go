@@ -0,0 +1,38 @@
+package git
+
+import "strings"
+
+// ResetMode selects how much of the index and working tree git reset
+// rewrites along with HEAD.
+type ResetMode string
+
+const (
+	// ResetSoft moves HEAD only; the changes since hash stay staged.
+	ResetSoft ResetMode = "soft"
+	// ResetMixed moves HEAD and resets the index; the changes stay in the
+	// working tree, unstaged.
+	ResetMixed ResetMode = "mixed"
+	// ResetHard moves HEAD and discards all changes in the index and
+	// working tree.
+	ResetHard ResetMode = "hard"
+)
+
+// Reset moves the current branch (or a detached HEAD) to hash. It returns
+// the previous HEAD so the reset can be undone with another reset.
+func (r *Repository) Reset(hash string, mode ResetMode) (previous string, err error) {
+	previous, err = r.HeadHash()
+	if err != nil {
+		return "", err
+	}
+	_, err = r.runWith(reflogEnv("reset"), nil, "reset", "--"+string(mode), hash)
+	return previous, err
+}
+
+// HeadHash returns the full hash of the commit HEAD points at.
+func (r *Repository) HeadHash() (string, error) {
+	out, err := r.run("rev-parse", "--verify", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
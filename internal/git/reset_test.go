@@ -0,0 +1,43 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+)
+
+func TestReset(t *testing.T) {
+	tests := []struct {
+		mode           git.ResetMode
+		staged, status string
+	}{
+		{git.ResetSoft, "four.txt", "A  four.txt\n M one.txt"},
+		{git.ResetMixed, "", "M one.txt\n?? four.txt"}, // Git trims the leading space
+		{git.ResetHard, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.mode), func(t *testing.T) {
+			f, repo := rebaseFixture(t)
+			head := f.Git("rev-parse", "HEAD")
+			target := f.Git("rev-parse", "HEAD~1")
+			f.WriteFile("one.txt", "changed\n")
+
+			previous, err := repo.Reset(target, tt.mode)
+			if err != nil {
+				t.Fatalf("Reset: %v", err)
+			}
+			if previous != head {
+				t.Errorf("previous = %s, want %s", previous, head)
+			}
+			if got := f.Git("rev-parse", "HEAD"); got != target {
+				t.Errorf("HEAD = %s, want %s", got, target)
+			}
+			if got := f.Git("diff", "--cached", "--name-only"); got != tt.staged {
+				t.Errorf("staged = %q, want %q", got, tt.staged)
+			}
+			if got := f.Git("status", "--porcelain"); got != tt.status {
+				t.Errorf("status = %q, want %q", got, tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// Command describes a single git invocation.
+type Command struct {
+	Dir   string    // working directory
+	Args  []string  // arguments passed to git, without the leading "git"
+	Env   []string  // extra KEY=VALUE entries appended to the inherited environment
+	Stdin io.Reader // optional standard input
+
+	// Output, if set, also receives stdout and stderr as the command
+	// writes them, for commands whose progress is shown live.
+	Output io.Writer
+}
+
+// Result is the captured outcome of a Command.
+type Result struct {
+	Stdout   []byte
+	Stderr   []byte
+	ExitCode int
+}
+
+// Runner executes git commands. Run only returns an error when the command
+// could not be run at all (git missing, context cancelled); a command that
+// ran and failed reports it through Result.ExitCode.
+type Runner interface {
+	Run(ctx context.Context, cmd Command) (Result, error)
+}
+
+// ExecRunner runs commands with the git binary found on PATH.
+type ExecRunner struct{}
+
+func (ExecRunner) Run(ctx context.Context, c Command) (Result, error) {
+	cmd := exec.CommandContext(ctx, "git", c.Args...)
+	cmd.Dir = c.Dir
+	if len(c.Env) > 0 {
+		cmd.Env = append(os.Environ(), c.Env...)
+	}
+	cmd.Stdin = c.Stdin
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if c.Output != nil {
+		out := &lockedWriter{w: c.Output}
+		cmd.Stdout = io.MultiWriter(&stdout, out)
+		cmd.Stderr = io.MultiWriter(&stderr, out)
+	}
+
+	err := cmd.Run()
+	res := Result{Stdout: stdout.Bytes(), Stderr: stderr.Bytes()}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && ctx.Err() == nil {
+			res.ExitCode = exitErr.ExitCode()
+			return res, nil
+		}
+		return res, err
+	}
+	return res, nil
+}
+
+// lockedWriter serializes writes from the goroutines copying stdout and
+// stderr into a shared writer.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
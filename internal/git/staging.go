@@ -0,0 +1,90 @@
+package git
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrNothingStaged is returned by Commit when the index has no changes
+// relative to HEAD.
+var ErrNothingStaged = errors.New("nothing staged")
+
+// StageFile adds the working tree state of a single path to the index,
+// including deletions and untracked files.
+func (r *Repository) StageFile(path string) error {
+	_, err := r.run("add", "-A", "--", path)
+	return err
+}
+
+// UnstageFile resets the index entry for a single path back to HEAD, leaving
+// the working tree untouched.
+func (r *Repository) UnstageFile(path string) error {
+	if !r.hasHead() {
+		// No commits yet — there is no HEAD to reset to, so drop the
+		// path from the index instead.
+		_, err := r.run("rm", "--cached", "-q", "--", path)
+		return err
+	}
+	_, err := r.run("reset", "-q", "HEAD", "--", path)
+	return err
+}
+
+// ApplyPatch applies a patch to the index only, without touching the working
+// tree. With reverse set the patch is un-applied, which is how staged hunks
+// are moved back out of the index. --recount lets callers build partial
+// hunks without recomputing exact header line counts.
+func (r *Repository) ApplyPatch(patch string, reverse bool) error {
+	args := []string{"apply", "--cached", "--recount", "--whitespace=nowarn"}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	args = append(args, "-")
+	_, err := r.runWith(nil, strings.NewReader(patch), args...)
+	return err
+}
+
+// GetWorkingTreeFileDiffs returns the unstaged (worktree vs index) and staged
+// (index vs HEAD) diffs for a single file. Untracked files are reported as a
+// whole-file addition in the unstaged diff.
+func (r *Repository) GetWorkingTreeFileDiffs(filePath string) (unstaged, staged string, err error) {
+	unstaged, err = r.run("diff", "--no-color", "--", filePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	staged, err = r.run("diff", "--cached", "--no-color", "--", filePath)
+	if err != nil {
+		return "", "", err
+	}
+
+	if unstaged == "" && staged == "" {
+		// Untracked file: diff against /dev/null. --no-index exits 1 when
+		// the files differ, so the error is expected and ignored.
+		untracked, _ := r.run("diff", "--no-color", "--no-index", "/dev/null", filePath)
+		return untracked, "", nil
+	}
+
+	return unstaged, staged, nil
+}
+
+// HasStagedChanges returns true if the index differs from HEAD.
+func (r *Repository) HasStagedChanges() bool {
+	args := []string{"diff", "--cached", "--quiet"}
+	if !r.hasHead() {
+		// Compare against the empty tree in a repository with no commits.
+		args = append(args, emptyTreeHash)
+	}
+	// --quiet exits 1 when there are differences.
+	_, err := r.run(args...)
+	return exitCode(err) == 1
+}
+
+// emptyTreeHash is the object ID of the empty tree, used as a diff base in
+// repositories that have no commits yet.
+const emptyTreeHash = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// hasHead reports whether HEAD resolves to a commit.
+func (r *Repository) hasHead() bool {
+	_, err := r.run("rev-parse", "--verify", "-q", "HEAD")
+	return err == nil
+}
@@ -0,0 +1,73 @@
+package git_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestStageAndUnstageFile(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	f.WriteFile("gone.txt", "gone\n")
+	f.Commit("initial")
+	repo := openFixture(t, f)
+
+	if repo.HasStagedChanges() {
+		t.Fatal("HasStagedChanges on a clean index")
+	}
+	if err := repo.Commit("nothing"); !errors.Is(err, git.ErrNothingStaged) {
+		t.Fatalf("Commit with nothing staged = %v, want ErrNothingStaged", err)
+	}
+
+	f.WriteFile("a.txt", "changed\n")
+	f.WriteFile("new.txt", "new\n")
+	f.Remove("gone.txt")
+	for _, path := range []string{"a.txt", "new.txt", "gone.txt"} {
+		if err := repo.StageFile(path); err != nil {
+			t.Fatalf("StageFile(%s): %v", path, err)
+		}
+	}
+	if got, want := f.Git("diff", "--cached", "--name-status"), "M\ta.txt\nD\tgone.txt\nA\tnew.txt"; got != want {
+		t.Errorf("staged = %q, want %q", got, want)
+	}
+	if !repo.HasStagedChanges() {
+		t.Error("HasStagedChanges = false after staging")
+	}
+
+	for _, path := range []string{"a.txt", "new.txt", "gone.txt"} {
+		if err := repo.UnstageFile(path); err != nil {
+			t.Fatalf("UnstageFile(%s): %v", path, err)
+		}
+	}
+	if got := f.Git("diff", "--cached", "--name-status"); got != "" {
+		t.Errorf("staged after unstaging = %q", got)
+	}
+	if got, want := f.Git("diff", "--name-status"), "M\ta.txt\nD\tgone.txt"; got != want {
+		t.Errorf("unstaged = %q, want %q", got, want)
+	}
+	if got := f.Git("ls-files", "--others"); got != "new.txt" {
+		t.Errorf("untracked = %q, want new.txt", got)
+	}
+}
+
+func TestStageWithoutCommits(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	repo := openFixture(t, f)
+
+	if err := repo.StageFile("a.txt"); err != nil {
+		t.Fatalf("StageFile: %v", err)
+	}
+	if !repo.HasStagedChanges() {
+		t.Error("HasStagedChanges = false with a file staged before the first commit")
+	}
+	if err := repo.UnstageFile("a.txt"); err != nil {
+		t.Fatalf("UnstageFile: %v", err)
+	}
+	if repo.HasStagedChanges() {
+		t.Error("HasStagedChanges = true after unstaging")
+	}
+}
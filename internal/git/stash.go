@@ -0,0 +1,111 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNothingToStash is returned by StashPush when there are no local
+// changes to save.
+var ErrNothingToStash = errors.New("no local changes to stash")
+
+// Stash is an entry of the stash list.
+type Stash struct {
+	Name    string // stash@{N}
+	Hash    string
+	Message string // e.g. "WIP on main: 1a2b3c4 subject" or "On main: message"
+	Date    time.Time
+}
+
+// StashOptions controls what StashPush saves.
+type StashOptions struct {
+	Message          string // optional; git generates "WIP on <branch>" otherwise
+	IncludeUntracked bool   // also stash (and remove) untracked files
+	KeepIndex        bool   // leave staged changes in place after stashing
+}
+
+// GetStashes returns the stash list, newest first.
+func (r *Repository) GetStashes() ([]Stash, error) {
+	out, err := r.run("stash", "list", "--format=%gd%x00%H%x00%ct%x00%gs")
+	if err != nil {
+		return nil, err
+	}
+	return parseStashList(out), nil
+}
+
+// parseStashList parses `git stash list` output printed with
+// %gd%x00%H%x00%ct%x00%gs, one stash per line.
+func parseStashList(out string) []Stash {
+	var stashes []Stash
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(line, "\x00", 4)
+		if len(parts) < 4 {
+			continue
+		}
+		stashes = append(stashes, Stash{
+			Name:    parts[0],
+			Hash:    parts[1],
+			Date:    parseUnix(parts[2]),
+			Message: parts[3],
+		})
+	}
+	return stashes
+}
+
+// StashPush saves the local changes to a new stash and reverts them in the
+// working tree. Untracked files only count with IncludeUntracked: git
+// stash push leaves them alone otherwise and saves nothing if they are
+// all there is.
+func (r *Repository) StashPush(opts StashOptions) error {
+	statusArgs := []string{"status", "--porcelain"}
+	if !opts.IncludeUntracked {
+		statusArgs = append(statusArgs, "--untracked-files=no")
+	}
+	if out, _ := r.run(statusArgs...); strings.TrimSpace(out) == "" {
+		return ErrNothingToStash
+	}
+	args := []string{"stash", "push"}
+	if opts.IncludeUntracked {
+		args = append(args, "--include-untracked")
+	}
+	if opts.KeepIndex {
+		args = append(args, "--keep-index")
+	}
+	if opts.Message != "" {
+		args = append(args, "-m", opts.Message)
+	}
+	_, err := r.run(args...)
+	return err
+}
+
+// StashShow returns the patch a stash would apply, relative to the commit
+// it was created on.
+func (r *Repository) StashShow(name string) (string, error) {
+	return r.run("stash", "show", "-p", "--no-color", name)
+}
+
+// StashApply applies a stash to the working tree, keeping it in the list.
+func (r *Repository) StashApply(name string) error {
+	_, err := r.run("stash", "apply", name)
+	return err
+}
+
+// StashPop applies a stash and drops it. On conflicts the stash is kept.
+func (r *Repository) StashPop(name string) error {
+	_, err := r.run("stash", "pop", name)
+	return err
+}
+
+// StashDrop deletes a stash from the list.
+func (r *Repository) StashDrop(name string) error {
+	_, err := r.run("stash", "drop", name)
+	return err
+}
+
+// StashBranch creates and checks out branch at the commit the stash was
+// created on, applies the stash there and drops it.
+func (r *Repository) StashBranch(branch, name string) error {
+	_, err := r.run("stash", "branch", branch, name)
+	return err
+}
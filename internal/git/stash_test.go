@@ -0,0 +1,227 @@
+package git_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func stashFixture(t *testing.T) (*gittest.Fixture, *git.Repository) {
+	t.Helper()
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	f.Commit("initial")
+	return f, openFixture(t, f)
+}
+
+func TestStashPushAndList(t *testing.T) {
+	f, repo := stashFixture(t)
+
+	if err := repo.StashPush(git.StashOptions{}); !errors.Is(err, git.ErrNothingToStash) {
+		t.Fatalf("expected ErrNothingToStash, got %v", err)
+	}
+
+	f.WriteFile("a.txt", "first\n")
+	if err := repo.StashPush(git.StashOptions{}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	f.WriteFile("a.txt", "second\n")
+	f.WriteFile("new.txt", "untracked\n")
+	if err := repo.StashPush(git.StashOptions{Message: "with untracked", IncludeUntracked: true}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	if status := f.Git("status", "--porcelain"); status != "" {
+		t.Errorf("working tree should be clean, got %q", status)
+	}
+
+	stashes, err := repo.GetStashes()
+	if err != nil {
+		t.Fatalf("GetStashes: %v", err)
+	}
+	if len(stashes) != 2 {
+		t.Fatalf("expected 2 stashes, got %+v", stashes)
+	}
+	if s := stashes[0]; s.Name != "stash@{0}" || s.Message != "On main: with untracked" {
+		t.Errorf("newest stash = %+v", s)
+	}
+	if s := stashes[1]; s.Name != "stash@{1}" || !strings.HasPrefix(s.Message, "WIP on main:") {
+		t.Errorf("oldest stash = %+v", s)
+	}
+
+	patch, err := repo.StashShow("stash@{1}")
+	if err != nil || !strings.Contains(patch, "+first") {
+		t.Errorf("StashShow = %q, %v", patch, err)
+	}
+
+	// Both stashes show up in the graph, decorated with their names.
+	commits, err := repo.GetCommits(0, 100)
+	if err != nil {
+		t.Fatalf("GetCommits: %v", err)
+	}
+	for _, s := range stashes {
+		found := false
+		for _, c := range commits {
+			if c.Hash == s.Hash && hasRef(c.Refs, s.Name, git.RefTypeStash) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s not decorated in the graph", s.Name)
+		}
+	}
+}
+
+func TestStashAddsOneGraphRow(t *testing.T) {
+	f, repo := stashFixture(t)
+	f.WriteFile("a.txt", "b\n")
+	f.Commit("second")
+	before, err := repo.GetCommits(0, 100)
+	if err != nil {
+		t.Fatalf("GetCommits: %v", err)
+	}
+
+	// Staged, unstaged and untracked changes, so the stash gets both its
+	// index and untracked files commits.
+	f.WriteFile("staged.txt", "staged\n")
+	f.Git("add", "staged.txt")
+	f.WriteFile("a.txt", "unstaged\n")
+	f.WriteFile("new.txt", "untracked\n")
+	if err := repo.StashPush(git.StashOptions{IncludeUntracked: true}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	stash := f.Git("rev-parse", "stash@{0}")
+
+	after, err := repo.GetCommits(0, 100)
+	if err != nil {
+		t.Fatalf("GetCommits: %v", err)
+	}
+	if len(after) != len(before)+1 {
+		t.Fatalf("expected exactly one extra row, got %d commits before and %d after", len(before), len(after))
+	}
+	// The stash sits right above the commit it was made on and only links
+	// to it.
+	if after[0].Hash != stash || !after[0].IsStash() {
+		t.Errorf("first row = %s %+v, want the stash %s", after[0].Subject, after[0].Refs, stash)
+	}
+	if len(after[0].Parents) != 1 || after[0].Parents[0] != after[1].Hash {
+		t.Errorf("stash parents = %v, want [%s]", after[0].Parents, after[1].Hash)
+	}
+	if n := git.HistoryLen(after); n != len(before) {
+		t.Errorf("HistoryLen = %d, want %d", n, len(before))
+	}
+
+	// Paging counts history commits only, so the stash is neither
+	// repeated nor lost on the next page.
+	page, err := repo.GetCommits(0, 1)
+	if err != nil {
+		t.Fatalf("GetCommits(0, 1): %v", err)
+	}
+	rest, err := repo.GetCommits(1, 100)
+	if err != nil {
+		t.Fatalf("GetCommits(1, 100): %v", err)
+	}
+	if len(page) != 2 || len(rest) != len(before)-1 {
+		t.Errorf("pages have %d and %d commits, want 2 and %d", len(page), len(rest), len(before)-1)
+	}
+}
+
+func TestStashOnlyUntracked(t *testing.T) {
+	f, repo := stashFixture(t)
+	f.WriteFile("new.txt", "untracked\n")
+
+	if err := repo.StashPush(git.StashOptions{}); !errors.Is(err, git.ErrNothingToStash) {
+		t.Fatalf("StashPush of tracked changes = %v, want ErrNothingToStash", err)
+	}
+	if err := repo.StashPush(git.StashOptions{IncludeUntracked: true}); err != nil {
+		t.Fatalf("StashPush(IncludeUntracked): %v", err)
+	}
+	if stashes, _ := repo.GetStashes(); len(stashes) != 1 {
+		t.Errorf("stashes = %+v, want one", stashes)
+	}
+}
+
+func TestStashKeepIndex(t *testing.T) {
+	f, repo := stashFixture(t)
+	f.WriteFile("staged.txt", "staged\n")
+	f.Git("add", "staged.txt")
+	f.WriteFile("a.txt", "unstaged\n")
+
+	if err := repo.StashPush(git.StashOptions{KeepIndex: true}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	if status := f.Git("status", "--porcelain"); status != "A  staged.txt" {
+		t.Errorf("status = %q, want only the staged file", status)
+	}
+}
+
+func TestStashApplyPopDrop(t *testing.T) {
+	f, repo := stashFixture(t)
+	f.WriteFile("a.txt", "changed\n")
+	if err := repo.StashPush(git.StashOptions{}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+
+	if err := repo.StashApply("stash@{0}"); err != nil {
+		t.Fatalf("StashApply: %v", err)
+	}
+	if n := len(mustStashes(t, repo)); n != 1 {
+		t.Errorf("apply should keep the stash, got %d", n)
+	}
+	f.Git("checkout", "--", "a.txt")
+
+	if err := repo.StashPop("stash@{0}"); err != nil {
+		t.Fatalf("StashPop: %v", err)
+	}
+	if n := len(mustStashes(t, repo)); n != 0 {
+		t.Errorf("pop should drop the stash, got %d", n)
+	}
+	if status := f.Git("status", "--porcelain"); status != "M a.txt" {
+		t.Errorf("status after pop = %q", status)
+	}
+
+	if err := repo.StashPush(git.StashOptions{}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	if err := repo.StashDrop("stash@{0}"); err != nil {
+		t.Fatalf("StashDrop: %v", err)
+	}
+	if n := len(mustStashes(t, repo)); n != 0 {
+		t.Errorf("drop should remove the stash, got %d", n)
+	}
+}
+
+func TestStashBranch(t *testing.T) {
+	f, repo := stashFixture(t)
+	base := f.Git("rev-parse", "HEAD")
+	f.WriteFile("a.txt", "changed\n")
+	if err := repo.StashPush(git.StashOptions{}); err != nil {
+		t.Fatalf("StashPush: %v", err)
+	}
+	f.WriteFile("b.txt", "b\n")
+	f.Commit("moved on")
+
+	if err := repo.StashBranch("from-stash", "stash@{0}"); err != nil {
+		t.Fatalf("StashBranch: %v", err)
+	}
+	if got := f.Git("rev-parse", "--abbrev-ref", "HEAD"); got != "from-stash" {
+		t.Errorf("current branch = %q", got)
+	}
+	if got := f.Git("rev-parse", "HEAD"); got != base {
+		t.Errorf("branch should start at the stash base %s, got %s", base, got)
+	}
+	if n := len(mustStashes(t, repo)); n != 0 {
+		t.Errorf("stash should be dropped, got %d", n)
+	}
+}
+
+func mustStashes(t *testing.T, repo *git.Repository) []git.Stash {
+	t.Helper()
+	stashes, err := repo.GetStashes()
+	if err != nil {
+		t.Fatalf("GetStashes: %v", err)
+	}
+	return stashes
+}
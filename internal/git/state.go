@@ -0,0 +1,81 @@
+package git
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// RepoState describes what the repository is in the middle of, read from
+// the markers git leaves in the git directory: rebase-merge/ or
+// rebase-apply/, CHERRY_PICK_HEAD, REVERT_HEAD, MERGE_HEAD and BISECT_LOG.
+type RepoState struct {
+	Rebase    *RebaseProgress // non-nil while a rebase is stopped
+	Sequencer Sequencer       // cherry-pick, revert or merge stopped on conflicts
+	Remaining int             // commits left in a cherry-pick or revert, including the current one
+	Bisect    *BisectStatus   // non-nil while bisecting
+	Conflicts int             // unmerged paths, whatever left them
+}
+
+// Operation names the operation in progress: "rebase", "cherry-pick",
+// "revert", "merge", "bisect", or "" if there is none. A rebase or
+// sequence stopped during a bisect takes precedence.
+func (s RepoState) Operation() string {
+	switch {
+	case s.Rebase != nil:
+		return "rebase"
+	case s.Sequencer != SequencerNone:
+		return string(s.Sequencer)
+	case s.Bisect != nil:
+		return "bisect"
+	}
+	return ""
+}
+
+// State detects the operation in progress and counts unmerged paths.
+func (r *Repository) State() (RepoState, error) {
+	var s RepoState
+	gitDir, err := r.GitDir()
+	if err != nil {
+		return s, err
+	}
+
+	if s.Rebase, err = r.RebaseProgress(); err != nil {
+		return s, err
+	}
+	if s.Sequencer, err = r.SequencerInProgress(); err != nil {
+		return s, err
+	}
+	if s.Sequencer == SequencerCherryPick || s.Sequencer == SequencerRevert {
+		s.Remaining = countTodo(readTrimmedFile(filepath.Join(gitDir, "sequencer", "todo")))
+	}
+	if s.Bisect, err = r.BisectStatus(); err != nil {
+		return s, err
+	}
+
+	unmerged, err := r.run("diff", "--name-only", "-z", "--diff-filter=U")
+	if err != nil {
+		return s, err
+	}
+	for _, path := range strings.Split(unmerged, "\x00") {
+		if path != "" {
+			s.Conflicts++
+		}
+	}
+	return s, nil
+}
+
+// countTodo counts the commands of a sequencer todo list. A single-commit
+// cherry-pick or revert has no list; it then counts as one.
+func countTodo(todo string) int {
+	n := 0
+	for _, line := range strings.Split(todo, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			n++
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
@@ -0,0 +1,72 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func mustState(t *testing.T, repo *git.Repository) git.RepoState {
+	t.Helper()
+	s, err := repo.State()
+	if err != nil {
+		t.Fatalf("State: %v", err)
+	}
+	return s
+}
+
+func TestStateClean(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.Commit("initial")
+	repo := openFixture(t, f)
+
+	if s := mustState(t, repo); s.Operation() != "" || s.Conflicts != 0 {
+		t.Errorf("State = %+v, want nothing in progress", s)
+	}
+}
+
+func TestStateCherryPickSequence(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("file.txt", "base\n")
+	f.Commit("base")
+	f.Git("checkout", "-q", "-b", "topic")
+	var picks []string
+	for _, content := range []string{"one\n", "two\n", "three\n"} {
+		f.WriteFile("file.txt", content)
+		picks = append(picks, f.Commit(content))
+	}
+	f.Git("checkout", "-q", "main")
+	f.WriteFile("file.txt", "main\n")
+	f.Commit("main")
+	repo := openFixture(t, f)
+
+	if err := repo.CherryPick(picks...); err == nil {
+		t.Fatal("expected the cherry-pick to conflict")
+	}
+	s := mustState(t, repo)
+	if s.Operation() != "cherry-pick" || s.Remaining != 3 || s.Conflicts != 1 {
+		t.Errorf("State = %+v, want a cherry-pick with 3 left and 1 conflict", s)
+	}
+}
+
+func TestStateMergeAndBisect(t *testing.T) {
+	f, repo := conflictFixture(t)
+	if s := mustState(t, repo); s.Operation() != "merge" || s.Conflicts != 2 {
+		t.Errorf("State = %+v, want a merge with 2 conflicts", s)
+	}
+	if err := repo.SequencerAbort(git.SequencerMerge); err != nil {
+		t.Fatalf("abort: %v", err)
+	}
+
+	f.Git("bisect", "start")
+	if s := mustState(t, repo); s.Operation() != "bisect" || s.Bisect == nil {
+		t.Errorf("State = %+v, want a bisect", s)
+	}
+	if err := repo.BisectReset(); err != nil {
+		t.Fatalf("BisectReset: %v", err)
+	}
+	if s := mustState(t, repo); s.Operation() != "" {
+		t.Errorf("State after reset = %+v, want nothing in progress", s)
+	}
+}
@@ -0,0 +1,314 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Submodule is a submodule registered in the repository's .gitmodules.
+type Submodule struct {
+	Name string
+	Path string // relative to the repository root
+	URL  string
+
+	// Hash is the commit checked out in the submodule, or the one the
+	// superproject records if it is not initialized.
+	Hash string
+
+	Initialized bool
+	OutOfDate   bool // the checked-out commit differs from the recorded one
+	Conflict    bool // the recorded commit is unmerged
+}
+
+// SubmoduleChange describes how a commit, or the working tree, moved a
+// submodule from one commit to another.
+type SubmoduleChange struct {
+	Path string
+	Old  string // commit recorded before, "" for a new submodule
+	New  string // commit recorded after, "" for a removed one
+
+	// Commits are those in Old..New, newest first, followed by any that
+	// were in New..Old (Rewound) when the submodule went backwards or
+	// sideways.
+	Commits []SubmoduleCommit
+
+	// Missing is set when the commits could not be listed because the
+	// submodule is not checked out or does not have them fetched.
+	Missing bool
+
+	// Dirty is set for the working tree when the submodule itself has
+	// uncommitted changes.
+	Dirty bool
+}
+
+// SubmoduleCommit is one commit of a SubmoduleChange.
+type SubmoduleCommit struct {
+	Hash    string
+	Subject string
+	Rewound bool
+}
+
+// gitlinkMode is the tree entry mode git records submodules with.
+const gitlinkMode = "160000"
+
+// Submodules lists the submodules in .gitmodules with their status.
+func (r *Repository) Submodules() ([]Submodule, error) {
+	if !r.hasSubmodules() {
+		return nil, nil
+	}
+	out, err := r.run("submodule", "status")
+	if err != nil {
+		return nil, err
+	}
+	submodules := parseSubmoduleStatus(out)
+
+	config, _ := r.run("config", "-f", ".gitmodules", "--get-regexp", `^submodule\..*\.(path|url)$`) // best-effort
+	names, urls := parseGitmodules(config)
+	for i := range submodules {
+		name := names[submodules[i].Path]
+		submodules[i].Name = name
+		submodules[i].URL = urls[name]
+	}
+	return submodules, nil
+}
+
+// UpdateSubmodule checks out the commit the superproject records in the
+// submodule at path, cloning and initializing it first if needed. An empty
+// path updates every submodule.
+func (r *Repository) UpdateSubmodule(path string) error {
+	_, err := r.run(withPath([]string{"submodule", "update", "--init", "--recursive"}, path)...)
+	return err
+}
+
+// InitSubmodule registers the submodule at path in .git/config without
+// cloning it. An empty path initializes every submodule.
+func (r *Repository) InitSubmodule(path string) error {
+	_, err := r.run(withPath([]string{"submodule", "init"}, path)...)
+	return err
+}
+
+// SyncSubmodule copies the submodule's URL from .gitmodules into the
+// configuration, for when it changed upstream. An empty path syncs every
+// submodule.
+func (r *Repository) SyncSubmodule(path string) error {
+	_, err := r.run(withPath([]string{"submodule", "sync", "--recursive"}, path)...)
+	return err
+}
+
+// OpenSubmodule opens the checked-out submodule at path as a repository of
+// its own.
+func (r *Repository) OpenSubmodule(path string) (*Repository, error) {
+	return OpenRepository(filepath.Join(r.path, path), WithRunner(r.runner))
+}
+
+// GetSubmoduleChange returns how the commit hash, or UncommittedHash for
+// the working tree, moved the submodule at path.
+func (r *Repository) GetSubmoduleChange(hash, path string) (*SubmoduleChange, error) {
+	change := &SubmoduleChange{Path: path}
+	sub := r.inSubmodule(path)
+
+	if hash == UncommittedHash {
+		if out, err := r.run("rev-parse", "--verify", "-q", "HEAD:"+path); err == nil {
+			change.Old = strings.TrimSpace(out)
+		}
+		// The submodule's checkout, or the index entry if it has none.
+		if sub.isSubmoduleCheckout() {
+			if out, err := sub.run("rev-parse", "--verify", "-q", "HEAD"); err == nil {
+				change.New = strings.TrimSpace(out)
+			}
+			status, _ := sub.run("status", "--porcelain")
+			change.Dirty = strings.TrimSpace(status) != ""
+		}
+		if change.New == "" {
+			if out, err := r.run("rev-parse", "--verify", "-q", ":"+path); err == nil {
+				change.New = strings.TrimSpace(out)
+			}
+		}
+	} else {
+		out, err := r.run("diff-tree", "--no-commit-id", "--raw", "-r", "-z", "--root", hash, "--", path)
+		if err != nil {
+			return nil, err
+		}
+		link := parseGitlinks(out)[path]
+		change.Old, change.New = link[0], link[1]
+	}
+
+	if change.Old == "" || change.New == "" || change.Old == change.New {
+		return change, nil
+	}
+	if !sub.isSubmoduleCheckout() {
+		change.Missing = true
+		return change, nil
+	}
+	ahead, err := sub.submoduleLog(change.New, change.Old)
+	if err != nil {
+		change.Missing = true
+		return change, nil
+	}
+	rewound, err := sub.submoduleLog(change.Old, change.New)
+	if err != nil {
+		change.Missing = true
+		return change, nil
+	}
+	for i := range rewound {
+		rewound[i].Rewound = true
+	}
+	change.Commits = append(ahead, rewound...)
+	return change, nil
+}
+
+// submoduleLog lists the commits reachable from tip but not from base,
+// newest first.
+func (r *Repository) submoduleLog(tip, base string) ([]SubmoduleCommit, error) {
+	out, err := r.run("log", "--format=%H%x00%s", tip, "--not", base, "--")
+	if err != nil {
+		return nil, err
+	}
+	var commits []SubmoduleCommit
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		hash, subject, ok := strings.Cut(line, "\x00")
+		if ok {
+			commits = append(commits, SubmoduleCommit{Hash: hash, Subject: subject})
+		}
+	}
+	return commits, nil
+}
+
+// markSubmodules flags the files of commit hash that are submodules. Only
+// repositories with a .gitmodules pay for the extra git call.
+func (r *Repository) markSubmodules(hash string, files []ChangedFile) {
+	if !r.hasSubmodules() {
+		return
+	}
+	out, _ := r.run("diff-tree", "--no-commit-id", "--raw", "-r", "-z", hash) // best-effort
+	links := parseGitlinks(out)
+	for i := range files {
+		_, files[i].Submodule = links[files[i].Path]
+	}
+}
+
+// markWorkingTreeSubmodules flags the working tree files that are the
+// paths of registered submodules.
+func (r *Repository) markWorkingTreeSubmodules(files []ChangedFile) {
+	if !r.hasSubmodules() {
+		return
+	}
+	config, _ := r.run("config", "-f", ".gitmodules", "--get-regexp", `^submodule\..*\.path$`) // best-effort
+	names, _ := parseGitmodules(config)
+	for i := range files {
+		_, files[i].Submodule = names[files[i].Path]
+	}
+}
+
+// hasSubmodules reports whether the working tree has a .gitmodules file.
+func (r *Repository) hasSubmodules() bool {
+	_, err := os.Stat(filepath.Join(r.path, ".gitmodules"))
+	return err == nil
+}
+
+// inSubmodule returns a Repository that runs git in the submodule at path.
+// It has no go-git handle, so only the git-command methods work on it.
+func (r *Repository) inSubmodule(path string) *Repository {
+	return &Repository{path: filepath.Join(r.path, path), runner: r.runner}
+}
+
+// isSubmoduleCheckout reports whether r.path is the top of a checked-out
+// submodule, which has a .git file of its own. An uninitialized one is an
+// empty directory, where git would find the superproject instead.
+func (r *Repository) isSubmoduleCheckout() bool {
+	_, err := os.Stat(filepath.Join(r.path, ".git"))
+	return err == nil
+}
+
+func withPath(args []string, path string) []string {
+	if path == "" {
+		return args
+	}
+	return append(args, "--", path)
+}
+
+// parseSubmoduleStatus parses `git submodule status`: a status character
+// ('-' uninitialized, '+' checkout differs, 'U' conflict, ' ' up to date),
+// the commit, the path and, for checked-out submodules, a describe of the
+// commit in parentheses.
+func parseSubmoduleStatus(out string) []Submodule {
+	var submodules []Submodule
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if len(line) < 2 {
+			continue
+		}
+		flag, rest := line[0], line[1:]
+		hash, path, ok := strings.Cut(rest, " ")
+		if !ok {
+			continue
+		}
+		if flag != '-' && strings.HasSuffix(path, ")") {
+			if i := strings.LastIndex(path, " ("); i >= 0 {
+				path = path[:i]
+			}
+		}
+		submodules = append(submodules, Submodule{
+			Path:        path,
+			Hash:        hash,
+			Initialized: flag != '-',
+			OutOfDate:   flag == '+',
+			Conflict:    flag == 'U',
+		})
+	}
+	return submodules
+}
+
+// parseGitmodules parses `git config --get-regexp` output over .gitmodules
+// ("submodule.<name>.path <path>" and "submodule.<name>.url <url>") into
+// the submodule name of each path and the URL of each name.
+func parseGitmodules(out string) (names, urls map[string]string) {
+	names = make(map[string]string)
+	urls = make(map[string]string)
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		key = strings.TrimPrefix(key, "submodule.")
+		if name, ok := strings.CutSuffix(key, ".path"); ok {
+			names[value] = name
+		} else if name, ok := strings.CutSuffix(key, ".url"); ok {
+			urls[name] = value
+		}
+	}
+	return names, urls
+}
+
+// parseGitlinks picks the submodule entries out of `--raw -z` diff output,
+// mapping each path to the commits it pointed at before and after ("" on
+// the side where it did not exist). Each entry is ":<old mode> <new mode>
+// <old hash> <new hash> <status>" followed by one path, or two for renames
+// and copies.
+func parseGitlinks(out string) map[string][2]string {
+	links := make(map[string][2]string)
+	tokens := strings.Split(out, "\x00")
+	for i := 0; i+1 < len(tokens); i += 2 {
+		fields := strings.Fields(strings.TrimPrefix(tokens[i], ":"))
+		if len(fields) != 5 {
+			continue
+		}
+		path := tokens[i+1]
+		if status := fields[4]; (status[0] == 'R' || status[0] == 'C') && i+2 < len(tokens) {
+			path = tokens[i+2]
+			i++
+		}
+		if fields[0] != gitlinkMode && fields[1] != gitlinkMode {
+			continue
+		}
+		var link [2]string
+		if fields[0] == gitlinkMode {
+			link[0] = fields[2]
+		}
+		if fields[1] == gitlinkMode {
+			link[1] = fields[3]
+		}
+		links[path] = link
+	}
+	return links
+}
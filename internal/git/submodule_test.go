@@ -0,0 +1,82 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestSubmoduleChange(t *testing.T) {
+	dep := gittest.NewFixture(t)
+	dep.WriteFile("dep.txt", "one\n")
+	first := dep.Commit("dep one")
+
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	f.Commit("initial")
+	// Local clones are refused by default since git 2.38.1.
+	f.Git("-c", "protocol.file.allow=always", "submodule", "add", "-q", dep.Dir, "lib/dep")
+	f.Commit("add dep")
+	repo := openFixture(t, f)
+
+	f.Git("-C", "lib/dep", "config", "user.name", "Test User")
+	f.Git("-C", "lib/dep", "config", "user.email", "test@example.com")
+	commitDep := func(content, message string) string {
+		f.WriteFile("lib/dep/dep.txt", content)
+		f.Git("-C", "lib/dep", "commit", "-qam", message)
+		return f.Git("-C", "lib/dep", "rev-parse", "HEAD")
+	}
+	commitDep("two\n", "dep two")
+	third := commitDep("three\n", "dep three")
+
+	files, err := repo.GetWorkingTreeFiles()
+	if err != nil {
+		t.Fatalf("GetWorkingTreeFiles: %v", err)
+	}
+	if file := findFile(files, "lib/dep"); file == nil || !file.Submodule {
+		t.Fatalf("working tree files = %+v, want lib/dep as a submodule", files)
+	}
+	change, err := repo.GetSubmoduleChange(git.UncommittedHash, "lib/dep")
+	if err != nil {
+		t.Fatalf("GetSubmoduleChange(uncommitted): %v", err)
+	}
+	if change.Old != first || change.New != third || len(change.Commits) != 2 ||
+		change.Commits[0].Subject != "dep three" || change.Commits[1].Subject != "dep two" {
+		t.Fatalf("uncommitted change = %+v", change)
+	}
+
+	// Record the bump, then move the submodule back, rewinding both.
+	f.Git("add", "lib/dep")
+	f.Commit("bump dep")
+	f.Git("-C", "lib/dep", "checkout", "-q", first)
+	f.Git("add", "lib/dep")
+	rewind := f.Commit("rewind dep")
+
+	files, err = repo.GetChangedFiles(rewind)
+	if err != nil {
+		t.Fatalf("GetChangedFiles: %v", err)
+	}
+	if len(files) != 1 || !files[0].Submodule {
+		t.Fatalf("changed files = %+v, want lib/dep as a submodule", files)
+	}
+	change, err = repo.GetSubmoduleChange(rewind, "lib/dep")
+	if err != nil {
+		t.Fatalf("GetSubmoduleChange: %v", err)
+	}
+	if change.Old != third || change.New != first || len(change.Commits) != 2 || !change.Commits[0].Rewound {
+		t.Fatalf("rewind change = %+v", change)
+	}
+
+	submodules, err := repo.Submodules()
+	if err != nil {
+		t.Fatalf("Submodules: %v", err)
+	}
+	if len(submodules) != 1 || submodules[0].Path != "lib/dep" || !submodules[0].Initialized ||
+		submodules[0].URL != dep.Dir {
+		t.Fatalf("Submodules = %+v", submodules)
+	}
+	if _, err := repo.OpenSubmodule("lib/dep"); err != nil {
+		t.Fatalf("OpenSubmodule: %v", err)
+	}
+}
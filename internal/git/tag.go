@@ -0,0 +1,125 @@
+package git
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrNoRemote is returned when an operation needs a remote and the
+// repository has none.
+var ErrNoRemote = errors.New("no remote configured")
+
+// Tag is a tag with the commit it points at. Lightweight tags have no
+// tagger or message of their own.
+type Tag struct {
+	Name        string
+	Hash        string // the tagged commit
+	Annotated   bool
+	Tagger      string
+	TaggerEmail string
+	Date        time.Time // tagging date, or the commit date for lightweight tags
+	Message     string    // annotation, without any signature
+}
+
+// TagOptions controls what kind of tag CreateTag makes. A message or
+// signing makes an annotated tag; otherwise the tag is lightweight.
+type TagOptions struct {
+	Message string
+	Sign    bool
+}
+
+// tagFormat is the for-each-ref format parsed by parseTags. Records start
+// with \x1e since annotations span lines.
+const tagFormat = "%1e%(refname:short)%00%(objecttype)%00%(objectname)%00%(*objectname)%00" +
+	"%(taggername)%00%(taggeremail:trim)%00%(creatordate:unix)%00%(contents:subject)%00%(contents:body)"
+
+// GetTags returns all tags, most recently created first.
+func (r *Repository) GetTags() ([]Tag, error) {
+	out, err := r.run("for-each-ref", "--sort=-creatordate", "--format="+tagFormat, "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	return parseTags(out), nil
+}
+
+// parseTags parses `git for-each-ref` output printed with tagFormat.
+func parseTags(out string) []Tag {
+	var tags []Tag
+	for _, record := range strings.Split(out, "\x1e") {
+		parts := strings.SplitN(record, "\x00", 9)
+		if len(parts) < 9 {
+			continue
+		}
+		tag := Tag{
+			Name: parts[0],
+			Hash: parts[2],
+			Date: parseUnix(parts[6]),
+		}
+		if parts[1] == "tag" {
+			tag.Annotated = true
+			tag.Hash = parts[3] // the peeled commit, not the tag object
+			tag.Tagger = parts[4]
+			tag.TaggerEmail = parts[5]
+			tag.Message = strings.TrimSpace(parts[7] + "\n\n" + strings.TrimSpace(parts[8]))
+		}
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// CreateTag tags target (a commit hash or "HEAD").
+func (r *Repository) CreateTag(name, target string, opts TagOptions) error {
+	args := []string{"tag"}
+	switch {
+	case opts.Sign:
+		args = append(args, "-s", "-m", opts.Message)
+	case opts.Message != "":
+		args = append(args, "-a", "-m", opts.Message)
+	}
+	args = append(args, name, target)
+	_, err := r.run(args...)
+	return err
+}
+
+// DeleteTag deletes a local tag.
+func (r *Repository) DeleteTag(name string) error {
+	_, err := r.run("tag", "-d", name)
+	return err
+}
+
+// DeleteRemoteTag deletes a tag on remote.
+func (r *Repository) DeleteRemoteTag(remote, name string) error {
+	_, err := r.run("push", remote, "--delete", "refs/tags/"+name)
+	return err
+}
+
+// PushTag pushes a single tag to remote.
+func (r *Repository) PushTag(remote, name string) error {
+	_, err := r.run("push", remote, "refs/tags/"+name)
+	return err
+}
+
+// PushTags pushes all local tags to remote.
+func (r *Repository) PushTags(remote string) error {
+	return r.Push(PushOptions{Remote: remote, Tags: true})
+}
+
+// DefaultRemote returns the remote tags are pushed to: "origin" if it
+// exists, otherwise the first remote.
+func (r *Repository) DefaultRemote() (string, error) {
+	out, err := r.run("remote")
+	if err != nil {
+		return "", err
+	}
+	remotes := strings.Fields(out)
+	if len(remotes) == 0 {
+		return "", ErrNoRemote
+	}
+	for _, name := range remotes {
+		if name == "origin" {
+			return name, nil
+		}
+	}
+	return remotes[0], nil
+}
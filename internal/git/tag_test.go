@@ -0,0 +1,106 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestCreateAndListTags(t *testing.T) {
+	f := gittest.NewFixture(t)
+	first := f.Commit("first")
+	second := f.Commit("second")
+	repo := openFixture(t, f)
+
+	if err := repo.CreateTag("v1.0", first, git.TagOptions{}); err != nil {
+		t.Fatalf("CreateTag lightweight: %v", err)
+	}
+	msg := "Release 2.0\n\nWith notes."
+	if err := repo.CreateTag("v2.0", "HEAD", git.TagOptions{Message: msg}); err != nil {
+		t.Fatalf("CreateTag annotated: %v", err)
+	}
+
+	tags, err := repo.GetTags()
+	if err != nil {
+		t.Fatalf("GetTags: %v", err)
+	}
+	byName := make(map[string]git.Tag)
+	for _, tag := range tags {
+		byName[tag.Name] = tag
+	}
+
+	light := byName["v1.0"]
+	if light.Hash != first || light.Annotated || light.Message != "" {
+		t.Errorf("v1.0 = %+v, want a lightweight tag on %s", light, first)
+	}
+	annotated := byName["v2.0"]
+	if annotated.Hash != second || !annotated.Annotated {
+		t.Errorf("v2.0 = %+v, want an annotated tag on %s", annotated, second)
+	}
+	if annotated.Message != msg || annotated.Tagger != "Test User" || annotated.TaggerEmail != "test@example.com" {
+		t.Errorf("v2.0 annotation = %q by %s <%s>", annotated.Message, annotated.Tagger, annotated.TaggerEmail)
+	}
+
+	// Annotated tags decorate the commit, not the tag object.
+	commits, err := repo.GetCommits(0, 10)
+	if err != nil {
+		t.Fatalf("GetCommits: %v", err)
+	}
+	if c := commits[0]; c.Hash != second || !hasRef(c.Refs, "v2.0", git.RefTypeTag) {
+		t.Errorf("%s refs = %+v, want tag v2.0", c.ShortHash, c.Refs)
+	}
+
+	if err := repo.DeleteTag("v1.0"); err != nil {
+		t.Fatalf("DeleteTag: %v", err)
+	}
+	if out := f.Git("tag"); out != "v2.0" {
+		t.Errorf("tags after delete = %q", out)
+	}
+}
+
+func TestCreateSignedTag(t *testing.T) {
+	fake := gittest.NewFakeRunner()
+	fake.StubOutput("", "tag", "-s", "-m", "signed", "v1.0", "HEAD")
+	f := gittest.NewFixture(t)
+	repo := openFixture(t, f, git.WithRunner(fake))
+
+	if err := repo.CreateTag("v1.0", "HEAD", git.TagOptions{Message: "signed", Sign: true}); err != nil {
+		t.Fatalf("CreateTag: %v", err)
+	}
+}
+
+func TestPushAndDeleteRemoteTags(t *testing.T) {
+	remote := gittest.NewFixture(t)
+	remote.Git("config", "receive.denyCurrentBranch", "ignore")
+
+	f := gittest.NewFixture(t)
+	f.Commit("initial")
+	f.Git("remote", "add", "origin", remote.Dir)
+	f.Git("tag", "one")
+	f.Git("tag", "two")
+	repo := openFixture(t, f)
+
+	name, err := repo.DefaultRemote()
+	if err != nil || name != "origin" {
+		t.Fatalf("DefaultRemote = %q, %v", name, err)
+	}
+	if err := repo.PushTag(name, "one"); err != nil {
+		t.Fatalf("PushTag: %v", err)
+	}
+	if out := remote.Git("tag"); out != "one" {
+		t.Errorf("remote tags = %q, want one", out)
+	}
+	if err := repo.PushTags(name); err != nil {
+		t.Fatalf("PushTags: %v", err)
+	}
+	if out := remote.Git("tag"); out != "one\ntwo" {
+		t.Errorf("remote tags = %q, want one and two", out)
+	}
+	if err := repo.DeleteRemoteTag(name, "one"); err != nil {
+		t.Fatalf("DeleteRemoteTag: %v", err)
+	}
+	if out := remote.Git("tag"); out != "two" {
+		t.Errorf("remote tags = %q after delete, want two", out)
+	}
+}
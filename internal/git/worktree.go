@@ -0,0 +1,179 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree is a working tree of the repository: the main one or a linked
+// one added with git worktree add.
+type Worktree struct {
+	Path   string
+	Head   string // hash of the checked-out commit, "" for a bare repository
+	Branch string // checked-out branch, "" if detached or bare
+
+	IsMain    bool // the repository's main working tree (or bare directory)
+	IsCurrent bool // the working tree this Repository was opened in
+	Bare      bool
+	Locked    bool
+	Prunable  bool // its directory is gone; git worktree prune removes it
+}
+
+// CommonDir returns the absolute path of the git directory shared by all
+// worktrees, which holds refs, objects and config. It is the same as
+// GitDir except in a linked worktree.
+func (r *Repository) CommonDir() (string, error) {
+	out, err := r.run("rev-parse", "--git-common-dir")
+	if err != nil {
+		return "", err
+	}
+	dir := strings.TrimSpace(out)
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(r.path, dir)
+	}
+	return filepath.Clean(dir), nil
+}
+
+// Worktrees lists the repository's worktrees, the main one first.
+func (r *Repository) Worktrees() ([]Worktree, error) {
+	out, err := r.run("worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+	worktrees := parseWorktrees(out)
+
+	top, err := r.run("rev-parse", "--show-toplevel")
+	if err == nil {
+		current := resolvePath(strings.TrimSpace(top))
+		for i := range worktrees {
+			worktrees[i].IsCurrent = resolvePath(worktrees[i].Path) == current
+		}
+	}
+	return worktrees, nil
+}
+
+// AddWorktree creates a worktree at path with branch checked out. A branch
+// that does not exist yet is created at start.
+func (r *Repository) AddWorktree(path, branch, start string) error {
+	_, err := r.run("show-ref", "--verify", "--quiet", "refs/heads/"+branch)
+	if err == nil {
+		_, err = r.run("worktree", "add", path, branch)
+		return err
+	}
+	_, err = r.run("worktree", "add", "-b", branch, path, start)
+	return err
+}
+
+// RemoveWorktree deletes a linked worktree and its directory. Without force
+// git refuses if it has uncommitted changes or untracked files.
+func (r *Repository) RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove"}
+	if force {
+		args = append(args, "--force")
+	}
+	_, err := r.run(append(args, path)...)
+	return err
+}
+
+// PruneWorktrees forgets worktrees whose directories were deleted.
+func (r *Repository) PruneWorktrees() error {
+	_, err := r.run("worktree", "prune")
+	return err
+}
+
+// otherWorktreeBranches maps each branch checked out in a worktree other
+// than the current one to that worktree's path.
+func (r *Repository) otherWorktreeBranches() map[string]string {
+	if !r.hasLinkedWorktrees() {
+		return nil
+	}
+	worktrees, err := r.Worktrees()
+	if err != nil {
+		return nil
+	}
+	branches := make(map[string]string)
+	for _, w := range worktrees {
+		if w.Branch != "" && !w.IsCurrent {
+			branches[w.Branch] = w.Path
+		}
+	}
+	return branches
+}
+
+// hasLinkedWorktrees reports whether <commondir>/worktrees exists, which
+// git creates with the first linked worktree. It reads .git directly
+// rather than run git: in the main worktree it is the common directory,
+// in a linked one a file naming the worktree's git directory, whose
+// commondir file leads back to it. When unsure it reports true.
+func (r *Repository) hasLinkedWorktrees() bool {
+	dotGit := filepath.Join(r.path, ".git")
+	info, err := os.Stat(dotGit)
+	if err != nil {
+		return true
+	}
+	commonDir := dotGit
+	if !info.IsDir() {
+		b, err := os.ReadFile(dotGit)
+		if err != nil {
+			return true
+		}
+		gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir: ")
+		if !ok {
+			return true
+		}
+		if !filepath.IsAbs(gitDir) {
+			gitDir = filepath.Join(r.path, gitDir)
+		}
+		// Submodules have a .git file too, but no commondir.
+		commonDir = gitDir
+		if b, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+			commonDir = strings.TrimSpace(string(b))
+			if !filepath.IsAbs(commonDir) {
+				commonDir = filepath.Join(gitDir, commonDir)
+			}
+		}
+	}
+	_, err = os.Stat(filepath.Join(commonDir, "worktrees"))
+	return err == nil
+}
+
+// parseWorktrees parses `git worktree list --porcelain`: one block of
+// "key value" lines per worktree, separated by blank lines.
+func parseWorktrees(out string) []Worktree {
+	var worktrees []Worktree
+	for _, block := range strings.Split(strings.TrimSpace(out), "\n\n") {
+		var w Worktree
+		for _, line := range strings.Split(block, "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				w.Path = value
+			case "HEAD":
+				w.Head = value
+			case "branch":
+				w.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "bare":
+				w.Bare = true
+			case "locked":
+				w.Locked = true
+			case "prunable":
+				w.Prunable = true
+			}
+		}
+		if w.Path != "" {
+			w.IsMain = len(worktrees) == 0
+			worktrees = append(worktrees, w)
+		}
+	}
+	return worktrees
+}
+
+// resolvePath cleans path and resolves symlinks in it where possible, so
+// two spellings of the same directory compare equal.
+func resolvePath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
@@ -0,0 +1,130 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+func TestLinkedWorktree(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.WriteFile("a.txt", "a\n")
+	f.Commit("initial")
+	main := openFixture(t, f)
+
+	path := filepath.Join(t.TempDir(), "feature")
+	if err := main.AddWorktree(path, "feature", "HEAD"); err != nil {
+		t.Fatalf("AddWorktree: %v", err)
+	}
+	linked, err := git.OpenRepository(path)
+	if err != nil {
+		t.Fatalf("OpenRepository(linked): %v", err)
+	}
+
+	gitDir, err := linked.GitDir()
+	if err != nil {
+		t.Fatalf("GitDir: %v", err)
+	}
+	commonDir, err := linked.CommonDir()
+	if err != nil {
+		t.Fatalf("CommonDir: %v", err)
+	}
+	if want, _ := main.GitDir(); commonDir != want || gitDir == commonDir {
+		t.Errorf("linked GitDir = %s, CommonDir = %s, want CommonDir %s", gitDir, commonDir, want)
+	}
+
+	worktrees, err := linked.Worktrees()
+	if err != nil {
+		t.Fatalf("Worktrees: %v", err)
+	}
+	if len(worktrees) != 2 || !worktrees[0].IsMain || worktrees[0].IsCurrent ||
+		worktrees[1].Branch != "feature" || !worktrees[1].IsCurrent {
+		t.Fatalf("Worktrees = %+v", worktrees)
+	}
+
+	branches, err := linked.GetBranches()
+	if err != nil {
+		t.Fatalf("GetBranches: %v", err)
+	}
+	for _, b := range branches {
+		switch b.Name {
+		case "main":
+			if b.Worktree != worktrees[0].Path {
+				t.Errorf("main.Worktree = %q, want %q", b.Worktree, worktrees[0].Path)
+			}
+		case "feature":
+			if !b.IsCurrent || b.Worktree != "" {
+				t.Errorf("feature = %+v, want the current branch", b)
+			}
+		}
+	}
+
+	// The graph decorations are read from the common git directory too,
+	// so the linked worktree sees main and marks it as checked out
+	// elsewhere, and the other way round.
+	commits, err := linked.GetCommits(0, 10)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("GetCommits(linked) = %d commits, %v", len(commits), err)
+	}
+	refs := make(map[string]git.Ref)
+	for _, ref := range commits[0].Refs {
+		refs[ref.Name] = ref
+	}
+	if ref, ok := refs["main"]; !ok || ref.IsHead || ref.Worktree != worktrees[0].Path {
+		t.Errorf("main ref in the linked worktree = %+v, want it in %s", ref, worktrees[0].Path)
+	}
+	if ref, ok := refs["feature"]; !ok || !ref.IsHead || ref.Worktree != "" {
+		t.Errorf("feature ref in the linked worktree = %+v, want HEAD", ref)
+	}
+	commits, err = main.GetCommits(0, 10)
+	if err != nil || len(commits) != 1 {
+		t.Fatalf("GetCommits(main) = %d commits, %v", len(commits), err)
+	}
+	for _, ref := range commits[0].Refs {
+		if ref.Name == "feature" && ref.Worktree != worktrees[1].Path {
+			t.Errorf("feature ref in the main worktree = %+v, want it in %s", ref, worktrees[1].Path)
+		}
+	}
+
+	if err := os.WriteFile(filepath.Join(path, "untracked.txt"), []byte("x\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := main.RemoveWorktree(path, false); !git.IsErrorKind(err, git.ErrorKindWorktreeDirty) {
+		t.Fatalf("RemoveWorktree of a dirty worktree = %v, want ErrorKindWorktreeDirty", err)
+	}
+	if err := main.RemoveWorktree(path, true); err != nil {
+		t.Fatalf("RemoveWorktree(force): %v", err)
+	}
+	if worktrees, _ := main.Worktrees(); len(worktrees) != 1 {
+		t.Errorf("Worktrees after remove = %+v", worktrees)
+	}
+}
+
+func TestWorktreeListOnlyWithLinkedWorktrees(t *testing.T) {
+	f := gittest.NewFixture(t)
+	f.Commit("initial")
+	ranWorktreeList := func() bool {
+		t.Helper()
+		recorder := gittest.NewRecordingRunner(git.ExecRunner{})
+		if _, err := openFixture(t, f, git.WithRunner(recorder)).GetBranches(); err != nil {
+			t.Fatalf("GetBranches: %v", err)
+		}
+		for _, call := range recorder.Calls() {
+			if len(call.Args) > 0 && call.Args[0] == "worktree" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ranWorktreeList() {
+		t.Error("git worktree list ran without linked worktrees")
+	}
+	f.Git("worktree", "add", "-q", filepath.Join(t.TempDir(), "feature"))
+	if !ranWorktreeList() {
+		t.Error("git worktree list did not run with a linked worktree")
+	}
+}
@@ -1,6 +1,7 @@
 package actionbar
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
@@ -13,6 +14,12 @@ type Model struct {
 	branch  string
 	width   int
 	message string
+
+	// ahead, behind and gone describe the current branch against its
+	// upstream.
+	ahead  int
+	behind int
+	gone   bool
 }
 
 func New(styles *styles.Styles, width int) Model {
@@ -68,6 +75,10 @@ func (m Model) View() string {
 	}
 	branchName := branchStyle.Render(branchDisplay)
 	rightPart := branchIcon + branchName
+	if tracking := m.tracking(); tracking != "" {
+		trackingStyle := lipgloss.NewStyle().Foreground(theme.BranchFeature).Background(bg)
+		rightPart += trackingStyle.Render(" " + tracking)
+	}
 	rightWidth := lipgloss.Width(rightPart)
 
 	var leftPart string
@@ -86,23 +97,42 @@ func (m Model) View() string {
 		}
 		leftPart = msgStyle.Render(msg)
 	} else {
-		// Progressively drop key hints from the right until they fit.
+		// A persistent status (e.g. a rebase in progress) comes first and
+		// the key hints fill whatever space is left.
+		var statusPart string
 		availWidth := m.width - rightWidth - 2 // 2 = minimum spacer
+		if m.status != "" {
+			statusStyle := lipgloss.NewStyle().Foreground(theme.Head).Background(bg).Bold(true)
+			status := m.status
+			if runes := []rune(status); len(runes) > availWidth && availWidth > 1 {
+				status = string(runes[:availWidth-1]) + "…"
+			}
+			statusPart = statusStyle.Render(status)
+			availWidth -= lipgloss.Width(statusPart) + lipgloss.Width(sep)
+		}
+
+		// Progressively drop key hints from the right until they fit.
 		for numKeys := len(keys); numKeys > 0; numKeys-- {
 			var parts []string
 			for _, k := range keys[:numKeys] {
 				parts = append(parts, keyStyle.Render(k.key)+descStyle.Render(" "+k.desc))
 			}
 			candidate := strings.Join(parts, sep)
-			if lipgloss.Width(candidate) <= availWidth || numKeys == 1 {
+			if lipgloss.Width(candidate) <= availWidth || (numKeys == 1 && statusPart == "") {
 				leftPart = candidate
 				break
 			}
 		}
 		// If even one key doesn't fit, just show "? help".
-		if leftPart == "" {
+		if leftPart == "" && statusPart == "" {
 			leftPart = keyStyle.Render("?") + descStyle.Render(" help")
 		}
+		if statusPart != "" {
+			if leftPart != "" {
+				statusPart += sep
+			}
+			leftPart = statusPart + leftPart
+		}
 	}
 
 	leftWidth := lipgloss.Width(leftPart)
@@ -125,6 +155,30 @@ func (m *Model) SetBranch(branch string) {
 	m.branch = branch
 }
 
+// SetTracking sets how far the current branch is ahead of and behind its
+// upstream, or that the upstream is gone.
+func (m *Model) SetTracking(ahead, behind int, gone bool) {
+	m.ahead = ahead
+	m.behind = behind
+	m.gone = gone
+}
+
+// tracking formats the upstream state shown next to the branch, e.g.
+// "↑2 ↓5".
+func (m Model) tracking() string {
+	if m.gone {
+		return "upstream gone"
+	}
+	var parts []string
+	if m.ahead > 0 {
+		parts = append(parts, fmt.Sprintf("↑%d", m.ahead))
+	}
+	if m.behind > 0 {
+		parts = append(parts, fmt.Sprintf("↓%d", m.behind))
+	}
+	return strings.Join(parts, " ")
+}
+
 func (m *Model) SetWidth(width int) {
 	m.width = width
 }
@@ -136,3 +190,9 @@ func (m *Model) SetMessage(msg string) {
 func (m *Model) ClearMessage() {
 	m.message = ""
 }
+
+// SetStatus sets a persistent status shown ahead of the key hints until it
+// is replaced or cleared with an empty string.
+func (m *Model) SetStatus(status string) {
+	m.status = status
+}
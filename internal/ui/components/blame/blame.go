@@ -0,0 +1,220 @@
+package blame
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// authorWidth is the width of the author column.
+const authorWidth = 14
+
+// view is one blame in the stack: a file at a revision and where the
+// cursor was in it.
+type view struct {
+	rev    string // "" for the working tree
+	path   string
+	lines  []git.BlameLine
+	cursor int
+	offset int
+}
+
+// Model is a full-screen blame of a file. Re-blaming at a parent pushes a
+// new view; Back returns to the one before.
+type Model struct {
+	theme  styles.Theme
+	width  int
+	height int
+	views  []view
+}
+
+func New(theme styles.Theme, width, height int) Model {
+	return Model{theme: theme, width: width, height: height}
+}
+
+// Push shows the blame of path at rev on top of the current one.
+func (m *Model) Push(rev, path string, lines []git.BlameLine) {
+	m.views = append(m.views, view{rev: rev, path: path, lines: lines})
+}
+
+// Back returns to the previous blame, reporting false once there is none
+// left and the view has closed.
+func (m *Model) Back() bool {
+	if len(m.views) > 0 {
+		m.views = m.views[:len(m.views)-1]
+	}
+	return len(m.views) > 0
+}
+
+// Close drops every blame in the stack.
+func (m *Model) Close() {
+	m.views = nil
+}
+
+func (m Model) IsVisible() bool {
+	return len(m.views) > 0
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	if v := m.current(); v != nil {
+		m.ensureCursorVisible(v)
+	}
+}
+
+func (m Model) current() *view {
+	if len(m.views) == 0 {
+		return nil
+	}
+	return &m.views[len(m.views)-1]
+}
+
+// Title names the file and revision shown, for the panel border.
+func (m Model) Title() string {
+	v := m.current()
+	if v == nil {
+		return "Blame"
+	}
+	rev := "working tree"
+	if v.rev != "" {
+		rev = shortHash(v.rev)
+	}
+	return fmt.Sprintf("Blame %s @ %s", v.path, rev)
+}
+
+// SelectedLine returns the line under the cursor, or nil.
+func (m Model) SelectedLine() *git.BlameLine {
+	v := m.current()
+	if v == nil || v.cursor >= len(v.lines) {
+		return nil
+	}
+	return &v.lines[v.cursor]
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	v := m.current()
+	if v == nil {
+		return m, nil
+	}
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			v.cursor++
+		case "k", "up":
+			v.cursor--
+		case "g", "home":
+			v.cursor = 0
+		case "G", "end":
+			v.cursor = len(v.lines) - 1
+		case "ctrl+d":
+			v.cursor += m.height / 2
+		case "ctrl+u":
+			v.cursor -= m.height / 2
+		}
+	case tea.MouseMsg:
+		switch msg.Button {
+		case tea.MouseButtonWheelDown:
+			v.cursor += 3
+		case tea.MouseButtonWheelUp:
+			v.cursor -= 3
+		}
+	}
+	m.ensureCursorVisible(v)
+	return m, nil
+}
+
+// ensureCursorVisible clamps the cursor to the file and scrolls to it.
+func (m Model) ensureCursorVisible(v *view) {
+	if v.cursor >= len(v.lines) {
+		v.cursor = len(v.lines) - 1
+	}
+	if v.cursor < 0 {
+		v.cursor = 0
+	}
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if m.height > 0 && v.cursor >= v.offset+m.height {
+		v.offset = v.cursor - m.height + 1
+	}
+}
+
+// View renders the visible lines: the commit, date and author, shown on
+// the first line of each run of lines from the same commit, then the line
+// number and text.
+func (m Model) View() string {
+	v := m.current()
+	if v == nil {
+		return ""
+	}
+	bgStyle := lipgloss.NewStyle().Background(m.theme.Background)
+	if len(v.lines) == 0 {
+		empty := lipgloss.NewStyle().Foreground(m.theme.Subtext).Background(m.theme.Background).Italic(true)
+		return empty.Width(m.width).Render("  (empty file)")
+	}
+
+	numWidth := len(fmt.Sprint(len(v.lines)))
+	var rows []string
+	for i := v.offset; i < len(v.lines) && len(rows) < m.height; i++ {
+		l := v.lines[i]
+		bg := m.theme.Background
+		if i == v.cursor {
+			bg = m.theme.Selection
+		}
+		hashStyle := lipgloss.NewStyle().Foreground(m.theme.CommitHash).Background(bg)
+		dateStyle := lipgloss.NewStyle().Foreground(m.theme.Subtext).Background(bg)
+		authorStyle := lipgloss.NewStyle().Foreground(m.theme.BranchFeature).Background(bg)
+		numStyle := lipgloss.NewStyle().Foreground(m.theme.DiffContext).Background(bg)
+		textStyle := lipgloss.NewStyle().Foreground(m.theme.Foreground).Background(bg)
+		spacer := lipgloss.NewStyle().Background(bg).Render(" ")
+
+		hash, date, author := "", "", ""
+		if i == v.offset || v.lines[i-1].Hash != l.Hash || i == v.cursor {
+			hash = shortHash(l.Hash)
+			if l.Hash == git.UncommittedHash {
+				hash = git.UncommittedShortHash
+			} else {
+				date = l.AuthorTime.Format("2006-01-02")
+			}
+			author = truncate(l.Author, authorWidth)
+		}
+
+		prefix := hashStyle.Width(7).Render(hash) + spacer +
+			dateStyle.Width(10).Render(date) + spacer +
+			authorStyle.Width(authorWidth).Render(author) + spacer +
+			numStyle.Width(numWidth).Align(lipgloss.Right).Render(fmt.Sprint(l.Line)) + spacer
+		avail := m.width - lipgloss.Width(prefix)
+		if avail < 1 {
+			avail = 1
+		}
+		text := truncate(strings.ReplaceAll(l.Text, "\t", "    "), avail)
+		rows = append(rows, prefix+textStyle.Width(avail).Render(text))
+	}
+	for len(rows) < m.height {
+		rows = append(rows, bgStyle.Width(m.width).Render(""))
+	}
+	return strings.Join(rows, "\n")
+}
+
+// shortHash abbreviates a commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// truncate cuts s to at most n runes, appending "…" if it was cut.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n && n > 1 {
+		return string(runes[:n-1]) + "…"
+	}
+	return s
+}
@@ -26,12 +26,26 @@ type FilesLoadedMsg struct {
 	Err   error
 }
 
-// FileDiffLoadedMsg is sent after a per-file diff is loaded.
+// FileDiffLoadedMsg is sent after a per-file diff is loaded. For the
+// uncommitted entry Diff holds the unstaged changes and StagedDiff the
+// staged ones. A submodule has Submodule set instead.
 type FileDiffLoadedMsg struct {
-	Hash     string
-	FilePath string
-	Diff     string
-	Err      error
+	Hash       string
+	FilePath   string
+	Diff       string
+	StagedDiff string
+	Submodule  *git.SubmoduleChange
+	Err        error
+}
+
+// CommitsPageLoadedMsg is sent after a further page of history is loaded
+// in the background. Offset is the number of commits that were already
+// loaded when the page was requested.
+type CommitsPageLoadedMsg struct {
+	Offset  int
+	Commits []*git.Commit
+	HasMore bool
+	Err     error
 }
 
 // ---------------------------------------------------------------------------
@@ -50,6 +64,31 @@ type ExpandState struct {
 
 	// The formatted diff content for ExpandedFile, split into lines.
 	DiffLines []string
+
+	// Raw diff text for ExpandedFile, kept so DiffLines can be re-rendered
+	// and partial patches built from it. For the uncommitted entry RawDiff
+	// is the unstaged diff and StagedDiff the staged one.
+	RawDiff    string
+	StagedDiff string
+
+	// Submodule replaces the raw diff when ExpandedFile is a submodule.
+	Submodule *git.SubmoduleChange
+
+	// Selected hunk of ExpandedFile, numbered across the unstaged then the
+	// staged hunks (-1 = none). Only used for the uncommitted entry.
+	HunkIndex int
+
+	// Selected change line of the selected hunk, counting its added and
+	// removed lines but not its context (-1 = the whole hunk).
+	LineIndex int
+
+	// Offset of each hunk header within DiffLines, and of the row showing
+	// the selected line (-1 = none).
+	HunkLines []int
+	LineRow   int
+
+	// Whether Files has been populated at least once.
+	filesLoaded bool
 }
 
 // ---------------------------------------------------------------------------
@@ -77,6 +116,22 @@ type Model struct {
 
 	// Track last cursor for selection-changed detection.
 	lastCursor int
+
+	// Paging: history is loaded pageSize commits at a time, and the next
+	// page is requested once the cursor is within loadThreshold rows of
+	// the end. maxCommits caps the total (0 = no limit).
+	pageSize      int
+	loadThreshold int
+	maxCommits    int
+	hasMore       bool
+	loadingMore   bool
+
+	// Commits marked for a multi-commit action, keyed by hash.
+	marked map[string]bool
+
+	// In a file's history, the file each commit changed, keyed by hash.
+	// Expanding such a commit shows only that file, with its diff open.
+	scope map[string]git.ChangedFile
 }
 
 func New(commits []*git.Commit, theme styles.Theme, width, height int) Model {
@@ -118,6 +173,9 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	case FileDiffLoadedMsg:
 		return m.handleFileDiffLoaded(msg)
+
+	case CommitsPageLoadedMsg:
+		return m.handleCommitsPageLoaded(msg)
 	}
 
 	return m, nil
@@ -205,6 +263,8 @@ func (m Model) moveCursorDown() (Model, tea.Cmd) {
 			// Past the end of the diff — collapse it and move to next file.
 			es.ExpandedFile = ""
 			es.DiffLines = nil
+			es.HunkIndex = -1
+			es.LineIndex = -1
 			if es.FileIndex < len(es.Files)-1 {
 				es.FileIndex++
 				m.ensureCursorVisible()
@@ -257,6 +317,8 @@ func (m Model) moveCursorUp() (Model, tea.Cmd) {
 			// At the top of the diff — collapse it and stay on this file.
 			es.ExpandedFile = ""
 			es.DiffLines = nil
+			es.HunkIndex = -1
+			es.LineIndex = -1
 			m.ensureCursorVisible()
 			return m, nil
 		}
@@ -387,23 +449,17 @@ func (m *Model) ToggleExpand(repo *git.Repository) tea.Cmd {
 					// Collapse the file diff.
 					es.ExpandedFile = ""
 					es.DiffLines = nil
+					es.HunkIndex = -1
+					es.LineIndex = -1
 					return nil
 				}
 				// Expand a different file diff.
 				es.ExpandedFile = file.Path
 				es.DiffLines = nil
-				hash := m.commits[m.cursor].Hash
-				filePath := file.Path
-				if hash == git.UncommittedHash {
-					return func() tea.Msg {
-						diff, err := repo.GetWorkingTreeFileDiff(filePath)
-						return FileDiffLoadedMsg{Hash: hash, FilePath: filePath, Diff: diff, Err: err}
-					}
-				}
-				return func() tea.Msg {
-					diff, err := repo.GetFileDiff(hash, filePath)
-					return FileDiffLoadedMsg{Hash: hash, FilePath: filePath, Diff: diff, Err: err}
-				}
+				es.Submodule = nil
+				es.HunkIndex = -1
+				es.LineIndex = -1
+				return loadFileDiffCmd(repo, m.commits[m.cursor].Hash, file)
 			}
 			// FileIndex == -1 (on metadata) — collapse the whole commit.
 			m.collapseExpanded()
@@ -417,8 +473,37 @@ func (m *Model) ToggleExpand(repo *git.Repository) tea.Cmd {
 	m.expandedIdx = m.cursor
 	m.expandState = &ExpandState{
 		FileIndex: -1,
+		HunkIndex: -1,
+		LineIndex: -1,
 	}
 	hash := m.commits[m.cursor].Hash
+	if file, ok := m.scope[hash]; ok {
+		m.expandState.Files = []git.ChangedFile{file}
+		m.expandState.filesLoaded = true
+		m.expandState.FileIndex = 0
+		m.expandState.ExpandedFile = file.Path
+		return loadFileDiffCmd(repo, hash, file)
+	}
+	return loadFilesCmd(repo, hash)
+}
+
+// RefreshUncommitted reloads the file list and any open file diff of the
+// expanded "Uncommitted changes" entry, keeping the file cursor in place.
+// It does nothing if a regular commit (or nothing) is expanded, since their
+// contents cannot change.
+func (m *Model) RefreshUncommitted(repo *git.Repository) tea.Cmd {
+	if !m.isExpanded() || m.commits[m.expandedIdx].Hash != git.UncommittedHash {
+		return nil
+	}
+	cmds := []tea.Cmd{loadFilesCmd(repo, git.UncommittedHash)}
+	if es := m.expandState; es.ExpandedFile != "" {
+		file := git.ChangedFile{Path: es.ExpandedFile, Submodule: es.Submodule != nil}
+		cmds = append(cmds, loadFileDiffCmd(repo, git.UncommittedHash, file))
+	}
+	return tea.Batch(cmds...)
+}
+
+func loadFilesCmd(repo *git.Repository, hash string) tea.Cmd {
 	if hash == git.UncommittedHash {
 		return func() tea.Msg {
 			files, err := repo.GetWorkingTreeFiles()
@@ -431,6 +516,110 @@ func (m *Model) ToggleExpand(repo *git.Repository) tea.Cmd {
 	}
 }
 
+func loadFileDiffCmd(repo *git.Repository, hash string, file git.ChangedFile) tea.Cmd {
+	filePath := file.Path
+	if file.Submodule {
+		return func() tea.Msg {
+			change, err := repo.GetSubmoduleChange(hash, filePath)
+			return FileDiffLoadedMsg{Hash: hash, FilePath: filePath, Submodule: change, Err: err}
+		}
+	}
+	if hash == git.UncommittedHash {
+		return func() tea.Msg {
+			unstaged, staged, err := repo.GetWorkingTreeFileDiffs(filePath)
+			return FileDiffLoadedMsg{Hash: hash, FilePath: filePath, Diff: unstaged, StagedDiff: staged, Err: err}
+		}
+	}
+	if file.OldPath != "" {
+		return func() tea.Msg {
+			diff, err := repo.GetRenameDiff(hash, file.OldPath, filePath)
+			return FileDiffLoadedMsg{Hash: hash, FilePath: filePath, Diff: diff, Err: err}
+		}
+	}
+	return func() tea.Msg {
+		diff, err := repo.GetFileDiff(hash, filePath)
+		return FileDiffLoadedMsg{Hash: hash, FilePath: filePath, Diff: diff, Err: err}
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Paging
+// ---------------------------------------------------------------------------
+
+// SetPaging configures lazy loading. pageSize is the number of commits
+// fetched per page, threshold how close to the end the cursor must get
+// before the next page is requested, and maxCommits an overall cap
+// (0 = no limit).
+func (m *Model) SetPaging(pageSize, threshold, maxCommits int) {
+	m.pageSize = pageSize
+	m.loadThreshold = threshold
+	m.maxCommits = maxCommits
+}
+
+// LoadedCount returns the number of history commits loaded so far, not
+// counting the synthetic "Uncommitted changes" entry or stashes. It is the
+// offset of the next page.
+func (m Model) LoadedCount() int {
+	commits := m.commits
+	if len(commits) > 0 && commits[0].Hash == git.UncommittedHash {
+		commits = commits[1:]
+	}
+	return git.HistoryLen(commits)
+}
+
+// LoadMoreIfNeeded returns a command fetching the next page of history when
+// the cursor is near the end of the loaded commits, or nil if no page is
+// needed or one is already in flight.
+func (m *Model) LoadMoreIfNeeded(repo *git.Repository) tea.Cmd {
+	if !m.hasMore || m.loadingMore || m.pageSize <= 0 {
+		return nil
+	}
+	if m.cursor < len(m.commits)-1-m.loadThreshold {
+		return nil
+	}
+
+	offset := m.LoadedCount()
+	limit := m.pageSize
+	if m.maxCommits > 0 && offset+limit > m.maxCommits {
+		limit = m.maxCommits - offset
+	}
+	if limit <= 0 {
+		m.hasMore = false
+		return nil
+	}
+
+	m.loadingMore = true
+	capped := m.maxCommits > 0 && offset+limit >= m.maxCommits
+	return func() tea.Msg {
+		commits, err := repo.GetCommits(offset, limit)
+		return CommitsPageLoadedMsg{
+			Offset:  offset,
+			Commits: commits,
+			HasMore: git.HistoryLen(commits) == limit && !capped,
+			Err:     err,
+		}
+	}
+}
+
+func (m Model) handleCommitsPageLoaded(msg CommitsPageLoadedMsg) (Model, tea.Cmd) {
+	// A full reload since the page was requested makes it stale.
+	if !m.loadingMore || msg.Offset != m.LoadedCount() {
+		return m, nil
+	}
+	m.loadingMore = false
+	if msg.Err != nil {
+		// Stop paging until the next reload rather than retrying on
+		// every cursor move.
+		m.hasMore = false
+		return m, nil
+	}
+
+	m.commits = append(m.commits, msg.Commits...)
+	m.renderer.AppendGraph(msg.Commits)
+	m.hasMore = msg.HasMore
+	return m, nil
+}
+
 func (m *Model) collapseExpanded() {
 	m.expandedIdx = -1
 	m.expandState = nil
@@ -456,9 +645,32 @@ func (m Model) handleFilesLoaded(msg FilesLoadedMsg) (Model, tea.Cmd) {
 	if m.commits[m.expandedIdx].Hash != msg.Hash {
 		return m, nil
 	}
-	m.expandState.Files = msg.Files
-	if len(msg.Files) > 0 {
-		m.expandState.FileIndex = 0
+	es := m.expandState
+	es.Files = msg.Files
+	if !es.filesLoaded {
+		es.filesLoaded = true
+		if len(msg.Files) > 0 {
+			es.FileIndex = 0
+		}
+	} else {
+		// A refresh of an already expanded entry: keep the file cursor
+		// where it was and drop the open diff if its file went away.
+		if es.FileIndex >= len(es.Files) {
+			es.FileIndex = len(es.Files) - 1
+		}
+		found := false
+		for _, f := range es.Files {
+			if f.Path == es.ExpandedFile {
+				found = true
+				break
+			}
+		}
+		if !found {
+			es.ExpandedFile = ""
+			es.DiffLines = nil
+			es.HunkIndex = -1
+			es.LineIndex = -1
+		}
 	}
 	// The expanded content just grew (metadata + file list appeared). Make sure
 	// the cursor is still visible, but only scroll forward — never snap back.
@@ -476,6 +688,32 @@ func (m Model) handleFileDiffLoaded(msg FileDiffLoadedMsg) (Model, tea.Cmd) {
 	if m.commits[m.expandedIdx].Hash != msg.Hash || m.expandState.ExpandedFile != msg.FilePath {
 		return m, nil
 	}
+	es := m.expandState
+	es.RawDiff = msg.Diff
+	es.StagedDiff = msg.StagedDiff
+	es.Submodule = msg.Submodule
+	if es.HunkIndex >= countHunks(es.RawDiff)+countHunks(es.StagedDiff) {
+		es.HunkIndex = -1
+		es.LineIndex = -1
+	}
+	// Staging a line leaves the next one at the same index.
+	if n := m.selectedHunkChanges(); es.LineIndex >= n {
+		es.LineIndex = n - 1
+	}
+	m.formatExpandedDiff()
+	// Don't call ensureCursorVisible here — the cursor (file entry) is already
+	// visible since the user just pressed Enter on it. Calling it would snap
+	// the viewport back to the cursor line, fighting any scroll the user has
+	// already done. Just clamp to valid range.
+	m.clampScroll()
+	return m, nil
+}
+
+// formatExpandedDiff re-renders DiffLines from the raw diff of the expanded
+// file. The uncommitted entry is split into unstaged / staged sections with
+// the selected hunk highlighted.
+func (m *Model) formatExpandedDiff() {
+	es := m.expandState
 	// Subtract the lane gutter width so diff lines fit alongside the gutter.
 	gutterWidth := m.renderer.MaxLanes()
 	if gutterWidth < 1 {
@@ -485,13 +723,144 @@ func (m Model) handleFileDiffLoaded(msg FileDiffLoadedMsg) (Model, tea.Cmd) {
 	if diffWidth < 20 {
 		diffWidth = 20
 	}
-	m.expandState.DiffLines = m.renderer.FormatDiffLines(msg.Diff, diffWidth)
-	// Don't call ensureCursorVisible here — the cursor (file entry) is already
-	// visible since the user just pressed Enter on it. Calling it would snap
-	// the viewport back to the cursor line, fighting any scroll the user has
-	// already done. Just clamp to valid range.
+	if es.Submodule != nil {
+		es.DiffLines = m.renderer.FormatSubmoduleLines(es.Submodule, diffWidth)
+		es.HunkLines, es.LineRow = nil, -1
+	} else if m.commits[m.expandedIdx].Hash == git.UncommittedHash {
+		es.DiffLines, es.HunkLines, es.LineRow = m.renderer.FormatStagingDiffLines(es.RawDiff, es.StagedDiff, es.HunkIndex, es.LineIndex, diffWidth)
+	} else {
+		es.DiffLines = m.renderer.FormatDiffLines(es.RawDiff, diffWidth)
+		es.HunkLines, es.LineRow = nil, -1
+	}
+}
+
+// ---------------------------------------------------------------------------
+// Staging
+// ---------------------------------------------------------------------------
+
+// hasOpenStagingDiff reports whether the uncommitted entry is expanded with
+// the diff of the selected file open.
+func (m Model) hasOpenStagingDiff() bool {
+	if !m.isExpanded() || m.expandedIdx != m.cursor || m.commits[m.expandedIdx].Hash != git.UncommittedHash {
+		return false
+	}
+	es := m.expandState
+	return es.FileIndex >= 0 && es.FileIndex < len(es.Files) &&
+		es.Files[es.FileIndex].Path == es.ExpandedFile && len(es.DiffLines) > 0
+}
+
+// SelectHunk moves the hunk selection of the open uncommitted file diff by
+// delta, wrapping around, and scrolls the selected hunk into view. Moving
+// past either end clears the selection so space acts on the whole file
+// again. Returns false if no staging diff is open.
+func (m *Model) SelectHunk(delta int) bool {
+	if !m.hasOpenStagingDiff() {
+		return false
+	}
+	es := m.expandState
+	total := countHunks(es.RawDiff) + countHunks(es.StagedDiff)
+	if total == 0 {
+		return false
+	}
+	// Positions run -1 (whole file) .. total-1.
+	es.HunkIndex = (es.HunkIndex+1+delta+total+1)%(total+1) - 1
+	es.LineIndex = -1
+	m.formatExpandedDiff()
+
+	if es.HunkIndex >= 0 && es.HunkIndex < len(es.HunkLines) {
+		m.scrollDiffRowIntoView(es.HunkLines[es.HunkIndex])
+	}
+	return true
+}
+
+// SelectLine moves the line selection within the selected hunk by delta,
+// wrapping around, so space stages or unstages that line alone. Moving
+// past either end goes back to the whole hunk. Returns false if no hunk
+// is selected.
+func (m *Model) SelectLine(delta int) bool {
+	if !m.hasOpenStagingDiff() || m.expandState.HunkIndex < 0 {
+		return false
+	}
+	es := m.expandState
+	total := m.selectedHunkChanges()
+	if total == 0 {
+		return false
+	}
+	// Positions run -1 (whole hunk) .. total-1.
+	es.LineIndex = (es.LineIndex+1+delta+total+1)%(total+1) - 1
+	m.formatExpandedDiff()
+
+	switch {
+	case es.LineRow >= 0:
+		m.scrollDiffRowIntoView(es.LineRow)
+	case es.HunkIndex < len(es.HunkLines):
+		m.scrollDiffRowIntoView(es.HunkLines[es.HunkIndex])
+	}
+	return true
+}
+
+// LineSelected reports whether a single line of the selected hunk is
+// selected, rather than the whole hunk or file.
+func (m Model) LineSelected() bool {
+	return m.hasOpenStagingDiff() && m.expandState.HunkIndex >= 0 && m.expandState.LineIndex >= 0
+}
+
+// selectedHunkChanges returns the number of added and removed lines in the
+// selected hunk of the open diff.
+func (m Model) selectedHunkChanges() int {
+	es := m.expandState
+	if es == nil || es.HunkIndex < 0 {
+		return 0
+	}
+	unstagedHunks := countHunks(es.RawDiff)
+	if es.HunkIndex < unstagedHunks {
+		return countChanges(es.RawDiff, es.HunkIndex)
+	}
+	return countChanges(es.StagedDiff, es.HunkIndex-unstagedHunks)
+}
+
+// scrollDiffRowIntoView scrolls so that row of the open diff is visible,
+// a third of the way down if it was not.
+func (m *Model) scrollDiffRowIntoView(row int) {
+	target := m.cursorVisualLine() + 1 + row
+	if target < m.scrollOffset || target >= m.scrollOffset+m.height {
+		m.scrollOffset = target - m.height/3
+	}
 	m.clampScroll()
-	return m, nil
+}
+
+// SelectedFile returns the file under the cursor in the expanded commit, or
+// nil if the cursor is not on a file.
+func (m Model) SelectedFile() *git.ChangedFile {
+	if !m.isExpanded() || m.expandedIdx != m.cursor {
+		return nil
+	}
+	es := m.expandState
+	if es.FileIndex < 0 || es.FileIndex >= len(es.Files) {
+		return nil
+	}
+	return &es.Files[es.FileIndex]
+}
+
+// SelectedHunkPatch returns a patch for the selected hunk of the open
+// uncommitted file diff, or for its selected line. staged reports whether
+// the hunk is currently in the index, in which case the patch must be
+// applied in reverse to unstage it. ok is false when no hunk is selected.
+func (m Model) SelectedHunkPatch() (patch string, staged bool, ok bool) {
+	if !m.hasOpenStagingDiff() || m.expandState.HunkIndex < 0 {
+		return "", false, false
+	}
+	es := m.expandState
+	raw, hunk := es.RawDiff, es.HunkIndex
+	if unstagedHunks := countHunks(es.RawDiff); hunk >= unstagedHunks {
+		raw, hunk, staged = es.StagedDiff, hunk-unstagedHunks, true
+	}
+	if es.LineIndex >= 0 {
+		patch = BuildLinePatch(raw, hunk, []int{es.LineIndex}, staged)
+	} else {
+		patch = BuildHunkPatch(raw, hunk, staged)
+	}
+	return patch, staged, patch != ""
 }
 
 // ---------------------------------------------------------------------------
@@ -578,6 +947,8 @@ func (m Model) renderCommitRow(idx int) string {
 		rowBg = m.theme.Selection
 	} else if isExpandedHeader {
 		rowBg = m.theme.BackgroundPanel
+	} else if m.marked[commit.Hash] {
+		rowBg = m.theme.Border
 	} else {
 		rowBg = m.theme.Background
 	}
@@ -604,7 +975,7 @@ func (m Model) renderCommitRow(idx int) string {
 			Render(line)
 	} else {
 		line = lipgloss.NewStyle().
-			Background(m.theme.Background).
+			Background(rowBg).
 			Width(m.width).
 			Render(line)
 	}
@@ -672,10 +1043,7 @@ func (m Model) renderMetadata(commit *git.Commit) []string {
 	isUncommitted := commit.Hash == git.UncommittedHash
 
 	// maxContent is the usable character width inside the indent.
-	maxContent := m.width - len(indent)
-	if maxContent < 10 {
-		maxContent = 10
-	}
+	maxContent := m.metadataContentWidth()
 
 	padToWidth := func(line string) string {
 		w := lipgloss.Width(line)
@@ -729,6 +1097,19 @@ func (m Model) renderMetadata(commit *git.Commit) []string {
 			valueStyle.Render(subjectDisplay)
 		lines = append(lines, bgStyle.Width(m.width).Render(padToWidth(line2)))
 
+		// Committer line, only when it differs from the author (rebased,
+		// amended or cherry-picked commits).
+		if showCommitter(commit) {
+			committerDisplay := truncStr(commit.Committer, maxContent/4)
+			committerEmail := truncStr("<"+commit.CommitterEmail+">", maxContent/4)
+			lineC := indentStr +
+				labelStyle.Render("Committer:") + bgStyle.Render(" ") +
+				authorStyle.Render(committerDisplay) + bgStyle.Render(" ") +
+				emailStyle.Render(committerEmail) + spacer +
+				dateStyle.Render(commit.CommitDate.Format("2006-01-02 15:04:05"))
+			lines = append(lines, bgStyle.Width(m.width).Render(padToWidth(lineC)))
+		}
+
 		// Refs line if any.
 		if len(commit.Refs) > 0 {
 			var refParts []string
@@ -745,6 +1126,9 @@ func (m Model) renderMetadata(commit *git.Commit) []string {
 				case git.RefTypeTag:
 					refParts = append(refParts, lipgloss.NewStyle().
 						Foreground(m.theme.Tag).Background(panelBg).Render("tag: "+ref.Name))
+				case git.RefTypeStash:
+					refParts = append(refParts, lipgloss.NewStyle().
+						Foreground(m.theme.Subtext).Background(panelBg).Italic(true).Render(ref.Name))
 				}
 			}
 			if len(refParts) > 0 {
@@ -753,6 +1137,29 @@ func (m Model) renderMetadata(commit *git.Commit) []string {
 				lines = append(lines, bgStyle.Width(m.width).Render(padToWidth(line3)))
 			}
 		}
+
+		// Message body, wrapped, separated from the header by a blank row.
+		if body := bodyLines(commit, maxContent); len(body) > 0 {
+			lines = append(lines, bgStyle.Width(m.width).Render(""))
+			for _, bl := range body {
+				lines = append(lines, bgStyle.Width(m.width).Render(padToWidth(indentStr+valueStyle.Render(bl))))
+			}
+		}
+
+		// Trailers, one per row.
+		if len(commit.Trailers) > 0 {
+			lines = append(lines, bgStyle.Width(m.width).Render(""))
+			for _, t := range commit.Trailers {
+				value := truncStr(t.Value, maxContent-len([]rune(t.Key))-2)
+				line := indentStr + labelStyle.Render(t.Key+":") + bgStyle.Render(" ") + emailStyle.Render(value)
+				lines = append(lines, bgStyle.Width(m.width).Render(padToWidth(line)))
+			}
+		}
+
+		// Blank row before the file list when a message block was shown.
+		if len(commit.Trailers) > 0 || commit.Body != "" {
+			lines = append(lines, bgStyle.Width(m.width).Render(""))
+		}
 	}
 
 	// Separator / file list header.
@@ -762,6 +1169,85 @@ func (m Model) renderMetadata(commit *git.Commit) []string {
 	return lines
 }
 
+// maxBodyLines caps the number of message body rows shown in the expanded
+// metadata; the full message can still be copied.
+const maxBodyLines = 20
+
+// showCommitter reports whether a commit's committer differs from its author
+// and so deserves its own metadata row.
+func showCommitter(commit *git.Commit) bool {
+	if commit.Committer == "" {
+		return false
+	}
+	return commit.Committer != commit.Author ||
+		commit.CommitterEmail != commit.Email ||
+		!commit.CommitDate.Equal(commit.Date)
+}
+
+// bodyLines returns the commit message body wrapped to width, capped at
+// maxBodyLines with a final "… N more lines" row.
+func bodyLines(commit *git.Commit, width int) []string {
+	if commit.Body == "" {
+		return nil
+	}
+	lines := wrapText(commit.Body, width)
+	if len(lines) > maxBodyLines {
+		more := len(lines) - maxBodyLines + 1
+		note := fmt.Sprintf("… %d more lines (Y copies the full message)", more)
+		if runes := []rune(note); len(runes) > width {
+			note = string(runes[:width])
+		}
+		lines = append(lines[:maxBodyLines-1], note)
+	}
+	return lines
+}
+
+// wrapText word-wraps each line of text to at most width columns, breaking
+// words that are longer than a whole line. Blank lines are kept.
+func wrapText(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			out = append(out, "")
+			continue
+		}
+		current := ""
+		for _, word := range strings.Fields(line) {
+			for lipgloss.Width(word) > width {
+				if current != "" {
+					out = append(out, current)
+					current = ""
+				}
+				runes := []rune(word)
+				cut := width
+				if cut > len(runes) {
+					cut = len(runes)
+				}
+				out = append(out, string(runes[:cut]))
+				word = string(runes[cut:])
+			}
+			switch {
+			case word == "":
+			case current == "":
+				current = word
+			case lipgloss.Width(current)+1+lipgloss.Width(word) <= width:
+				current += " " + word
+			default:
+				out = append(out, current)
+				current = word
+			}
+		}
+		if current != "" {
+			out = append(out, current)
+		}
+	}
+	return out
+}
+
 func (m Model) renderFileEntry(fileIdx int, file git.ChangedFile) string {
 	indent := "      "
 
@@ -790,6 +1276,12 @@ func (m Model) renderFileEntry(fileIdx int, file git.ChangedFile) string {
 	case "?":
 		statusIcon = "?"
 		statusColor = m.theme.DiffAdd // Untracked files shown as green (new)
+	case "U":
+		statusIcon = "!"
+		statusColor = m.theme.DiffRemove // Unmerged: resolve with the conflict panel
+	case "R":
+		statusIcon = "→"
+		statusColor = m.theme.BranchFeature
 	default:
 		statusIcon = "?"
 		statusColor = m.theme.Subtext
@@ -809,12 +1301,32 @@ func (m Model) renderFileEntry(fileIdx int, file git.ChangedFile) string {
 
 	indicatorStyle := lipgloss.NewStyle().Foreground(m.theme.Subtext).Background(bg)
 
+	// Working tree files show their index state: ● staged, ◐ partially
+	// staged, ○ unstaged.
+	var stageMark string
+	stageWidth := 0
+	if m.expandedIdx >= 0 && m.commits[m.expandedIdx].Hash == git.UncommittedHash {
+		mark, color := "○", m.theme.Subtext
+		switch {
+		case file.Staged && file.Unstaged:
+			mark, color = "◐", m.theme.Tag
+		case file.Staged:
+			mark, color = "●", m.theme.DiffAdd
+		}
+		stageMark = lipgloss.NewStyle().Foreground(color).Background(bg).Render(mark) + bgStyle.Render(" ")
+		stageWidth = 2
+	}
+
 	// Build the line stats string: "+N -M" (colored green/red).
 	addStyle := lipgloss.NewStyle().Foreground(m.theme.DiffAdd).Background(bg)
 	delStyle := lipgloss.NewStyle().Foreground(m.theme.DiffRemove).Background(bg)
 	var statsStr string
 	statsWidth := 0
-	if file.Additions > 0 || file.Deletions > 0 {
+	if file.Submodule {
+		// Line counts of a submodule are just its "Subproject commit" line.
+		statsStr = bgStyle.Render(" ") + lipgloss.NewStyle().Foreground(m.theme.Subtext).Background(bg).Italic(true).Render("submodule")
+		statsWidth = 1 + len("submodule")
+	} else if file.Additions > 0 || file.Deletions > 0 {
 		addText := fmt.Sprintf("+%d", file.Additions)
 		delText := fmt.Sprintf("-%d", file.Deletions)
 		statsStr = bgStyle.Render(" ") + addStyle.Render(addText) + bgStyle.Render(" ") + delStyle.Render(delText)
@@ -825,11 +1337,14 @@ func (m Model) renderFileEntry(fileIdx int, file git.ChangedFile) string {
 	// Truncate the file path to prevent overflow.
 	// Prefix consumes: indent(6) + indicator(1) + space(1) + status(1) + space(1) = 10 chars
 	// Stats are right-aligned and consume statsWidth chars.
-	pathAvail := m.width - 10 - statsWidth
+	pathAvail := m.width - 10 - stageWidth - statsWidth
 	if pathAvail < 8 {
 		pathAvail = 8
 	}
 	displayPath := file.Path
+	if file.OldPath != "" {
+		displayPath = file.OldPath + " → " + file.Path
+	}
 	pathRunes := []rune(displayPath)
 	if len(pathRunes) > pathAvail {
 		displayPath = "…" + string(pathRunes[len(pathRunes)-pathAvail+1:])
@@ -837,6 +1352,7 @@ func (m Model) renderFileEntry(fileIdx int, file git.ChangedFile) string {
 
 	line := bgStyle.Render(indent) +
 		indicatorStyle.Render(expandIndicator) + bgStyle.Render(" ") +
+		stageMark +
 		statusStyle.Render(statusIcon) + bgStyle.Render(" ") +
 		fileStyle.Render(displayPath) +
 		statsStr
@@ -969,6 +1485,15 @@ func (m Model) expandedLineCount() int {
 	return count
 }
 
+// metadataContentWidth is the usable width inside the metadata indent.
+func (m Model) metadataContentWidth() int {
+	w := m.width - 4
+	if w < 10 {
+		w = 10
+	}
+	return w
+}
+
 func (m Model) metadataLineCount() int {
 	if m.expandState == nil || m.expandedIdx < 0 || m.expandedIdx >= len(m.commits) {
 		return 0
@@ -980,9 +1505,21 @@ func (m Model) metadataLineCount() int {
 	}
 
 	count := 3 // hash+author, date+msg, files header
+	if showCommitter(commit) {
+		count++ // committer line
+	}
 	if len(commit.Refs) > 0 {
 		count++ // refs line
 	}
+	if body := bodyLines(commit, m.metadataContentWidth()); len(body) > 0 {
+		count += 1 + len(body) // blank separator + body
+	}
+	if len(commit.Trailers) > 0 {
+		count += 1 + len(commit.Trailers) // blank separator + trailers
+	}
+	if len(commit.Trailers) > 0 || commit.Body != "" {
+		count++ // blank row before the file list
+	}
 	return count
 }
 
@@ -997,6 +1534,91 @@ func (m Model) SelectedCommit() *git.Commit {
 	return nil
 }
 
+// ToggleMark marks or unmarks the selected commit for a multi-commit
+// action. The uncommitted changes entry can't be marked.
+func (m *Model) ToggleMark() {
+	commit := m.SelectedCommit()
+	if commit == nil || commit.Hash == git.UncommittedHash {
+		return
+	}
+	if m.marked[commit.Hash] {
+		delete(m.marked, commit.Hash)
+		return
+	}
+	if m.marked == nil {
+		m.marked = make(map[string]bool)
+	}
+	m.marked[commit.Hash] = true
+}
+
+// MarkedCommits returns the marked commits in graph order (newest first),
+// or the selected commit if nothing is marked.
+func (m Model) MarkedCommits() []*git.Commit {
+	if len(m.marked) == 0 {
+		if commit := m.SelectedCommit(); commit != nil && commit.Hash != git.UncommittedHash {
+			return []*git.Commit{commit}
+		}
+		return nil
+	}
+	var commits []*git.Commit
+	for _, c := range m.commits {
+		if m.marked[c.Hash] {
+			commits = append(commits, c)
+		}
+	}
+	return commits
+}
+
+// MarkCount returns the number of marked commits.
+func (m Model) MarkCount() int {
+	return len(m.marked)
+}
+
+// ClearMarks unmarks all commits.
+func (m *Model) ClearMarks() {
+	m.marked = nil
+}
+
+// SetFileScope limits what expanding each commit shows to the file it
+// changed, as listed in a file's history, or lifts the limit if scope is
+// nil.
+func (m *Model) SetFileScope(scope map[string]git.ChangedFile) {
+	m.scope = scope
+}
+
+// SetBisect decorates the commits marked good, bad or skipped and the one
+// under test in the bisect s, or clears the decorations if s is nil.
+func (m *Model) SetBisect(s *git.BisectStatus) {
+	m.renderer.SetBisect(s)
+}
+
+// SelectCommit moves the cursor to the loaded commit with the given hash,
+// reporting whether it was found.
+func (m *Model) SelectCommit(hash string) bool {
+	for i, c := range m.commits {
+		if c.Hash == hash {
+			if m.isExpanded() && m.expandedIdx != i {
+				m.collapseExpanded()
+			}
+			m.cursor = i
+			m.lastCursor = i
+			m.ensureCursorVisible()
+			return true
+		}
+	}
+	return false
+}
+
+// FindCommit returns the loaded commit with the given hash, or nil.
+func (m Model) FindCommit(hash string) *git.Commit {
+	for _, c := range m.commits {
+		if c.Hash == hash {
+			return c
+		}
+	}
+	return nil
+}
+
 func (m *Model) SetSize(width, height int) {
 	m.width = width
 	m.height = height
@@ -1005,8 +1627,9 @@ func (m *Model) SetSize(width, height int) {
 // SetCommits replaces the commit list and rebuilds the graph, while trying
 // to preserve the cursor position and expanded state. If the previously
 // selected commit still exists in the new list, the cursor is placed on it.
-// If a commit was expanded and still exists, it stays expanded.
-func (m *Model) SetCommits(commits []*git.Commit) {
+// If a commit was expanded and still exists, it stays expanded. hasMore
+// reports whether further history can be paged in below the list.
+func (m *Model) SetCommits(commits []*git.Commit, hasMore bool) {
 	// Remember the currently selected commit hash so we can restore position.
 	var prevHash string
 	if m.cursor >= 0 && m.cursor < len(m.commits) {
@@ -1025,6 +1648,19 @@ func (m *Model) SetCommits(commits []*git.Commit) {
 
 	m.commits = commits
 	m.renderer.InitGraph(commits)
+	m.hasMore = hasMore
+	m.loadingMore = false
+
+	// Drop marks on commits that are gone, e.g. after a rebase.
+	if len(m.marked) > 0 {
+		present := make(map[string]bool, len(m.marked))
+		for _, c := range commits {
+			if m.marked[c.Hash] {
+				present[c.Hash] = true
+			}
+		}
+		m.marked = present
+	}
 
 	// Try to find the previously selected commit in the new list.
 	cursorPreserved := false
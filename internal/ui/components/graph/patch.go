@@ -0,0 +1,210 @@
+package graph
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ---------------------------------------------------------------------------
+// Partial patches for hunk / line staging
+// ---------------------------------------------------------------------------
+
+// countHunks returns the number of "@@" hunks in a raw unified diff.
+func countHunks(raw string) int {
+	n := 0
+	for _, dl := range parseDiffLines(raw) {
+		if dl.kind == '@' {
+			n++
+		}
+	}
+	return n
+}
+
+// countChanges returns the number of added and removed lines in hunk of a
+// raw unified diff, the lines BuildLinePatch indexes.
+func countChanges(raw string, hunk int) int {
+	n, hunkNum := 0, -1
+	for _, dl := range parseDiffLines(raw) {
+		switch {
+		case dl.kind == '@':
+			hunkNum++
+		case hunkNum == hunk && (dl.kind == '+' || dl.kind == '-'):
+			n++
+		}
+	}
+	return n
+}
+
+// BuildHunkPatch returns a patch containing a single hunk of raw, which must
+// be a single-file unified diff. See BuildLinePatch for the meaning of
+// reverse. Returns "" if the hunk does not exist.
+func BuildHunkPatch(raw string, hunk int, reverse bool) string {
+	return buildPatch(raw, hunk, func(int) bool { return true }, reverse)
+}
+
+// BuildLinePatch returns a patch for one hunk of raw that only carries the
+// selected change lines. lines holds indexes into the hunk's added/removed
+// lines (context lines are not counted).
+//
+// Set reverse when the patch will be applied with `git apply --reverse`, as
+// when unstaging from a staged diff: unselected additions then stay in the
+// patch as context and unselected removals are dropped, instead of the other
+// way round.
+func BuildLinePatch(raw string, hunk int, lines []int, reverse bool) string {
+	selected := make(map[int]bool, len(lines))
+	for _, l := range lines {
+		selected[l] = true
+	}
+	return buildPatch(raw, hunk, func(i int) bool { return selected[i] }, reverse)
+}
+
+func buildPatch(raw string, hunk int, keep func(changeIdx int) bool, reverse bool) string {
+	// The file header is everything before the first hunk, copied verbatim.
+	var header []string
+	for _, line := range strings.Split(raw, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			break
+		}
+		header = append(header, line)
+	}
+
+	parsed := parseDiffLines(raw)
+	start := -1
+	hunkNum := 0
+	for i, dl := range parsed {
+		if dl.kind == '@' {
+			if hunkNum == hunk {
+				start = i
+				break
+			}
+			hunkNum++
+		}
+	}
+	if start < 0 {
+		return ""
+	}
+
+	var body []patchLine
+	oldCount, newCount := 0, 0
+	changeIdx := 0
+	changed := false
+	lastKept := true // whether the previous line made it into the patch
+	for _, dl := range parsed[start+1:] {
+		if dl.kind == '@' {
+			break
+		}
+		switch dl.kind {
+		case ' ':
+			body = append(body, patchLine{kind: ' ', content: dl.content})
+			oldCount++
+			newCount++
+			lastKept = true
+		case '+':
+			switch {
+			case keep(changeIdx):
+				body = append(body, patchLine{kind: '+', content: dl.content})
+				newCount++
+				changed = true
+				lastKept = true
+			case reverse:
+				// The line is already present on the "new" side; keep
+				// it as context so the patch still applies.
+				body = append(body, patchLine{kind: ' ', content: dl.content})
+				oldCount++
+				newCount++
+				lastKept = true
+			default:
+				lastKept = false
+			}
+			changeIdx++
+		case '-':
+			switch {
+			case keep(changeIdx):
+				body = append(body, patchLine{kind: '-', content: dl.content})
+				oldCount++
+				changed = true
+				lastKept = true
+			case reverse:
+				lastKept = false
+			default:
+				body = append(body, patchLine{kind: ' ', content: dl.content})
+				oldCount++
+				newCount++
+				lastKept = true
+			}
+			changeIdx++
+		case '\\':
+			// "\ No newline at end of file" belongs to the line above it.
+			if lastKept && len(body) > 0 {
+				body[len(body)-1].noNewline = dl.content
+			}
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	oldStart, newStart := parseHunkHeader(parsed[start].content)
+	hunkHeader := fmt.Sprintf("@@ -%d,%d +%d,%d @@", oldStart, oldCount, newStart, newCount)
+
+	var b strings.Builder
+	for _, h := range header {
+		b.WriteString(h + "\n")
+	}
+	b.WriteString(hunkHeader + "\n")
+	writeBody(&b, body)
+	return b.String()
+}
+
+// patchLine is a line of a partial hunk. noNewline holds the "\ No newline
+// at end of file" marker when the line ends its file without one.
+type patchLine struct {
+	kind      byte // ' ', '+' or '-'
+	content   string
+	noNewline string
+}
+
+// writeBody writes the lines of a partial hunk. Only the last line of the
+// old or the new side may lack a newline, but dropping changes can move a
+// line that lacks one off the end of a side: a removal or addition then
+// gets its newline back, and a context line is split into a removal and an
+// addition so each side keeps the ending it has.
+func writeBody(b *strings.Builder, body []patchLine) {
+	lastOld, lastNew := -1, -1
+	for i, l := range body {
+		if l.kind != '+' {
+			lastOld = i
+		}
+		if l.kind != '-' {
+			lastNew = i
+		}
+	}
+	write := func(kind byte, content, marker string) {
+		b.WriteString(string(kind) + content + "\n")
+		if marker != "" {
+			b.WriteString(marker + "\n")
+		}
+	}
+	for i, l := range body {
+		oldEnd := l.noNewline != "" && i == lastOld
+		newEnd := l.noNewline != "" && i == lastNew
+		switch {
+		case l.kind == '-':
+			write('-', l.content, pick(oldEnd, l.noNewline))
+		case l.kind == '+':
+			write('+', l.content, pick(newEnd, l.noNewline))
+		case oldEnd == newEnd:
+			write(' ', l.content, pick(oldEnd, l.noNewline))
+		default:
+			write('-', l.content, pick(oldEnd, l.noNewline))
+			write('+', l.content, pick(newEnd, l.noNewline))
+		}
+	}
+}
+
+func pick(ok bool, s string) string {
+	if ok {
+		return s
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/git/gittest"
+)
+
+// stagingFixture commits before as f.txt, writes after over it and opens
+// the repository.
+func stagingFixture(t *testing.T, before, after string) (*gittest.Fixture, *git.Repository) {
+	t.Helper()
+	f := gittest.NewFixture(t)
+	f.WriteFile("f.txt", before)
+	f.Commit("initial")
+	f.WriteFile("f.txt", after)
+	repo, err := git.OpenRepository(f.Dir)
+	if err != nil {
+		t.Fatalf("OpenRepository: %v", err)
+	}
+	return f, repo
+}
+
+// apply builds a patch from the unstaged diff, or with reverse from the
+// staged one, and applies it to the index.
+func apply(t *testing.T, repo *git.Repository, reverse bool, build func(raw string) string) {
+	t.Helper()
+	unstaged, staged, err := repo.GetWorkingTreeFileDiffs("f.txt")
+	if err != nil {
+		t.Fatalf("GetWorkingTreeFileDiffs: %v", err)
+	}
+	raw := unstaged
+	if reverse {
+		raw = staged
+	}
+	patch := build(raw)
+	if patch == "" {
+		t.Fatalf("empty patch from\n%s", raw)
+	}
+	if err := repo.ApplyPatch(patch, reverse); err != nil {
+		t.Fatalf("ApplyPatch(reverse=%v): %v\n%s", reverse, err, patch)
+	}
+}
+
+// cachedHunks returns `git diff --cached` from its first hunk on.
+func cachedHunks(f *gittest.Fixture) string {
+	out := f.Git("diff", "--cached")
+	if i := strings.Index(out, "@@"); i >= 0 {
+		return out[i:]
+	}
+	return out
+}
+
+func checkCached(t *testing.T, f *gittest.Fixture, want string) {
+	t.Helper()
+	if got := cachedHunks(f); got != want {
+		t.Errorf("git diff --cached:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestStageAndUnstageHunk(t *testing.T) {
+	before := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	f, repo := stagingFixture(t, before, strings.Replace(strings.Replace(before, "2\n", "two\n", 1), "11\n", "eleven\n", 1))
+
+	apply(t, repo, false, func(raw string) string { return BuildHunkPatch(raw, 1, false) })
+	checkCached(t, f, "@@ -8,5 +8,5 @@\n 8\n 9\n 10\n-11\n+eleven\n 12")
+
+	apply(t, repo, true, func(raw string) string { return BuildHunkPatch(raw, 0, true) })
+	checkCached(t, f, "")
+	if got := f.Git("diff"); !strings.Contains(got, "+two") || !strings.Contains(got, "+eleven") {
+		t.Errorf("working tree diff lost changes:\n%s", got)
+	}
+}
+
+func TestStageAndUnstageLine(t *testing.T) {
+	// Change lines: 0 -b, 1 -c, 2 +B, 3 +C.
+	f, repo := stagingFixture(t, "a\nb\nc\nd\n", "a\nB\nC\nd\n")
+
+	apply(t, repo, false, func(raw string) string { return BuildLinePatch(raw, 0, []int{2}, false) })
+	checkCached(t, f, "@@ -1,4 +1,5 @@\n a\n b\n c\n+B\n d")
+
+	apply(t, repo, true, func(raw string) string { return BuildLinePatch(raw, 0, []int{0}, true) })
+	checkCached(t, f, "")
+
+	// Unstaging one line of a staged hunk keeps the others staged.
+	f.Git("add", "f.txt")
+	apply(t, repo, true, func(raw string) string { return BuildLinePatch(raw, 0, []int{0}, true) })
+	checkCached(t, f, "@@ -1,4 +1,5 @@\n a\n b\n-c\n+B\n+C\n d")
+}
+
+func TestStageLineWithoutFinalNewline(t *testing.T) {
+	// Change lines: 0 -y, 1 +Y, both without a final newline.
+	f, repo := stagingFixture(t, "x\ny", "x\nY")
+	const marker = "\\ No newline at end of file"
+
+	// Staging the addition alone keeps y, which now needs a newline.
+	apply(t, repo, false, func(raw string) string { return BuildLinePatch(raw, 0, []int{1}, false) })
+	checkCached(t, f, "@@ -1,2 +1,3 @@\n x\n-y\n"+marker+"\n+y\n+Y\n"+marker)
+
+	apply(t, repo, true, func(raw string) string { return BuildHunkPatch(raw, 0, true) })
+	checkCached(t, f, "")
+
+	apply(t, repo, false, func(raw string) string { return BuildLinePatch(raw, 0, []int{0}, false) })
+	checkCached(t, f, "@@ -1,2 +1 @@\n x\n-y\n"+marker)
+
+	// Unstaging the removal alone from the whole staged change brings y
+	// back ahead of Y, which keeps the missing newline.
+	f.Git("add", "f.txt")
+	apply(t, repo, true, func(raw string) string { return BuildLinePatch(raw, 0, []int{0}, true) })
+	checkCached(t, f, "@@ -1,2 +1,3 @@\n x\n-y\n"+marker+"\n+y\n+Y\n"+marker)
+}
@@ -2,6 +2,7 @@ package graph
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -32,8 +33,23 @@ type GraphRenderer struct {
 	theme  styles.Theme
 	colors []lipgloss.Color
 	graph  *GraphBuilder
+
+	// bisect holds the bisect marks shown next to commit hashes.
+	bisect map[string]bisectMark
 }
 
+// bisectMark is the part a commit plays in a bisect.
+type bisectMark int
+
+const (
+	bisectNone bisectMark = iota
+	bisectSkipped
+	bisectGood
+	bisectBad
+	bisectCurrent
+	bisectFirstBad
+)
+
 type Vertex struct {
 	id       int
 	hash     string
@@ -49,6 +65,11 @@ type LaneState struct {
 	maxLanes   int
 }
 
+// provisionalBase is the first vertex id handed to parents that are not
+// loaded yet. It sits far above any real commit index so the two never
+// collide in lane state.
+const provisionalBase = 1 << 30
+
 type GraphBuilder struct {
 	commits           []*git.Commit
 	vertices          []*Vertex
@@ -56,6 +77,22 @@ type GraphBuilder struct {
 	laneSnapshots     []LaneState // lane state AT each commit (before parent assignment)
 	postLaneSnapshots []LaneState // lane state AFTER each commit (after parent assignment)
 	maxLanes          int
+
+	// Layout state after the last laid-out row, kept so a further page of
+	// commits can be appended without recomputing the rows above it.
+	lanes         []int
+	laneColors    []int
+	nextColor     int
+	isMergeTarget map[int]bool
+
+	// Parents referenced before they are loaded get a provisional id so
+	// their lane can be reserved. When the parent arrives, alias maps the
+	// provisional id to its real index and waiting lists the children whose
+	// parent ids must be rewritten.
+	pending         map[string]int
+	waiting         map[int][]int
+	alias           map[int]int
+	nextProvisional int
 }
 
 func NewGraphRenderer(theme styles.Theme) *GraphRenderer {
@@ -71,61 +108,143 @@ func NewGraphRenderer(theme styles.Theme) *GraphRenderer {
 	}
 }
 
+// InitGraph lays out commits from scratch.
 func (g *GraphRenderer) InitGraph(commits []*git.Commit) {
 	gb := &GraphBuilder{
-		commits:           commits,
-		vertices:          make([]*Vertex, len(commits)),
-		commitIndex:       make(map[string]int),
-		laneSnapshots:     make([]LaneState, len(commits)),
-		postLaneSnapshots: make([]LaneState, len(commits)),
-		maxLanes:          0,
+		commitIndex:     make(map[string]int),
+		isMergeTarget:   make(map[int]bool),
+		pending:         make(map[string]int),
+		waiting:         make(map[int][]int),
+		alias:           make(map[int]int),
+		nextProvisional: provisionalBase,
 	}
+	gb.appendCommits(commits)
+	g.graph = gb
+}
+
+// AppendGraph extends the layout with commits that follow the ones already
+// laid out in topological order. Existing rows are left untouched.
+func (g *GraphRenderer) AppendGraph(commits []*git.Commit) {
+	if g.graph == nil {
+		g.InitGraph(commits)
+		return
+	}
+	g.graph.appendCommits(commits)
+}
+
+func (gb *GraphBuilder) appendCommits(commits []*git.Commit) {
+	start := len(gb.vertices)
 
-	for i, c := range commits {
+	for k, c := range commits {
+		i := start + k
+		gb.commits = append(gb.commits, c)
 		gb.commitIndex[c.Hash] = i
-		gb.vertices[i] = &Vertex{
+		gb.vertices = append(gb.vertices, &Vertex{
 			id:       i,
 			hash:     c.Hash,
 			parents:  make([]int, 0),
 			children: make([]int, 0),
 			x:        -1,
 			color:    -1,
+		})
+		if prov, ok := gb.pending[c.Hash]; ok {
+			delete(gb.pending, c.Hash)
+			gb.resolveProvisional(prov, i)
 		}
 	}
 
-	for i, c := range commits {
-		for _, parentHash := range c.Parents {
-			if parentIdx, exists := gb.commitIndex[parentHash]; exists {
-				gb.vertices[i].parents = append(gb.vertices[i].parents, parentIdx)
+	for k, c := range commits {
+		i := start + k
+		v := gb.vertices[i]
+		for j, parentHash := range c.Parents {
+			if parentHash == "" {
+				continue
+			}
+			parentIdx, exists := gb.commitIndex[parentHash]
+			if exists {
 				gb.vertices[parentIdx].children = append(gb.vertices[parentIdx].children, i)
+			} else {
+				parentIdx = gb.provisionalID(parentHash)
+				gb.waiting[parentIdx] = append(gb.waiting[parentIdx], i)
+			}
+			v.parents = append(v.parents, parentIdx)
+
+			// Secondary parents of a merge are "branch-off" commits that
+			// should get their own visual lane rather than sharing their
+			// first-parent's lane.
+			if j > 0 {
+				gb.isMergeTarget[parentIdx] = true
 			}
 		}
 	}
 
-	gb.computeLayout()
-	g.graph = gb
+	gb.laneSnapshots = append(gb.laneSnapshots, make([]LaneState, len(commits))...)
+	gb.postLaneSnapshots = append(gb.postLaneSnapshots, make([]LaneState, len(commits))...)
+	gb.computeLayout(start)
 }
 
-func (gb *GraphBuilder) computeLayout() {
-	if len(gb.vertices) == 0 {
-		return
+// provisionalID returns the provisional vertex id for a parent hash that has
+// not been loaded yet, allocating one on first use.
+func (gb *GraphBuilder) provisionalID(hash string) int {
+	if id, ok := gb.pending[hash]; ok {
+		return id
 	}
+	id := gb.nextProvisional
+	gb.nextProvisional++
+	gb.pending[hash] = id
+	return id
+}
 
-	// Pre-compute the set of vertices that appear as a secondary parent
-	// of any merge commit. These are "branch-off" commits that should get
-	// their own visual lane rather than sharing their first-parent's lane.
-	isMergeTarget := make(map[int]bool)
-	for _, v := range gb.vertices {
-		for j := 1; j < len(v.parents); j++ {
-			isMergeTarget[v.parents[j]] = true
+// resolveProvisional rewrites every reference to a provisional id now that
+// the commit it stood for has been loaded at index idx.
+func (gb *GraphBuilder) resolveProvisional(prov, idx int) {
+	gb.alias[prov] = idx
+	for _, childIdx := range gb.waiting[prov] {
+		child := gb.vertices[childIdx]
+		for k, p := range child.parents {
+			if p == prov {
+				child.parents[k] = idx
+			}
+		}
+		gb.vertices[idx].children = append(gb.vertices[idx].children, childIdx)
+	}
+	delete(gb.waiting, prov)
+
+	if gb.isMergeTarget[prov] {
+		gb.isMergeTarget[idx] = true
+		delete(gb.isMergeTarget, prov)
+	}
+	for laneIdx, occupant := range gb.lanes {
+		if occupant == prov {
+			gb.lanes[laneIdx] = idx
+		}
+	}
+}
+
+// resolve maps a lane occupant recorded in a snapshot to the vertex index it
+// refers to now, following provisional ids that have since been loaded.
+func (gb *GraphBuilder) resolve(id int) int {
+	if id >= provisionalBase {
+		if idx, ok := gb.alias[id]; ok {
+			return idx
 		}
 	}
+	return id
+}
 
-	lanes := make([]int, 0)
-	laneColors := make([]int, 0) // color index per lane (branch-aware)
-	nextColor := 0               // rotating counter for new branches
+// computeLayout assigns lanes and colors to the vertices from index start
+// onward, continuing from the lane state left by the rows above.
+func (gb *GraphBuilder) computeLayout(start int) {
+	if start >= len(gb.vertices) {
+		return
+	}
 
-	for i := 0; i < len(gb.vertices); i++ {
+	isMergeTarget := gb.isMergeTarget
+	lanes := gb.lanes
+	laneColors := gb.laneColors // color index per lane (branch-aware)
+	nextColor := gb.nextColor   // rotating counter for new branches
+
+	for i := start; i < len(gb.vertices); i++ {
 		v := gb.vertices[i]
 
 		assignedLane := -1
@@ -285,6 +404,10 @@ func (gb *GraphBuilder) computeLayout() {
 			gb.maxLanes = len(lanes)
 		}
 	}
+
+	gb.lanes = lanes
+	gb.laneColors = laneColors
+	gb.nextColor = nextColor
 }
 
 func findAvailableLane(lanes []int) int {
@@ -363,13 +486,13 @@ func (g *GraphRenderer) RenderCommitLine(commit *git.Commit, index int, maxWidth
 		postSnap := g.graph.postLaneSnapshots[index]
 		for j := 1; j < len(v.parents); j++ {
 			parentIdx := v.parents[j]
-			if parentIdx < len(g.graph.vertices) {
-				// Find the lane reserved for this parent in the post-snapshot.
-				for lane := 0; lane < len(postSnap.lanes); lane++ {
-					if postSnap.lanes[lane] == parentIdx && lane != v.x {
-						mergeTargetLanes[lane] = true
-						break
-					}
+			// Find the lane reserved for this parent in the post-snapshot.
+			// The parent may not have been loaded when the snapshot was
+			// taken, so resolve provisional ids first.
+			for lane := 0; lane < len(postSnap.lanes); lane++ {
+				if g.graph.resolve(postSnap.lanes[lane]) == parentIdx && lane != v.x {
+					mergeTargetLanes[lane] = true
+					break
 				}
 			}
 		}
@@ -546,6 +669,9 @@ func (g *GraphRenderer) RenderCommitLine(commit *git.Commit, index int, maxWidth
 	// Calculate how much space the prefix (graph + hash + refs) and time consume
 	// so we can truncate the subject to fit within maxWidth.
 	prefix := graphStr + spacer + hashStyle.Render(commit.ShortHash)
+	if mark := g.renderBisectMark(g.bisect[commit.Hash]); mark != "" {
+		prefix = prefix + spacer + mark
+	}
 	if refStr != "" {
 		prefix = prefix + spacer + refStr
 	}
@@ -585,7 +711,7 @@ func (g *GraphRenderer) renderRefs(refs []git.Ref, bg lipgloss.Color) string {
 
 	for _, ref := range refs {
 		var style lipgloss.Style
-		var icon string
+		var icon, hint string
 
 		switch ref.RefType {
 		case git.RefTypeTag:
@@ -595,6 +721,13 @@ func (g *GraphRenderer) renderRefs(refs []git.Ref, bg lipgloss.Color) string {
 				Bold(true).
 				Padding(0, 1)
 			icon = "t:"
+		case git.RefTypeStash:
+			style = lipgloss.NewStyle().
+				Foreground(g.theme.Subtext).
+				Background(decoBg).
+				Italic(true).
+				Padding(0, 1)
+			icon = ""
 		case git.RefTypeBranch:
 			if ref.IsHead {
 				style = lipgloss.NewStyle().
@@ -609,6 +742,16 @@ func (g *GraphRenderer) renderRefs(refs []git.Ref, bg lipgloss.Color) string {
 					Background(decoBg).
 					Padding(0, 1)
 				icon = ""
+			} else if ref.Worktree != "" {
+				// Checked out in another worktree, so it cannot be
+				// checked out here.
+				style = lipgloss.NewStyle().
+					Foreground(g.theme.BranchMain).
+					Background(decoBg).
+					Italic(true).
+					Padding(0, 1)
+				icon = "+ "
+				hint = " in " + filepath.Base(ref.Worktree)
 			} else {
 				style = lipgloss.NewStyle().
 					Foreground(g.theme.BranchMain).
@@ -619,7 +762,7 @@ func (g *GraphRenderer) renderRefs(refs []git.Ref, bg lipgloss.Color) string {
 			}
 		}
 
-		parts = append(parts, style.Render(icon+ref.Name))
+		parts = append(parts, style.Render(icon+ref.Name+hint))
 	}
 
 	if len(parts) == 0 {
@@ -629,6 +772,48 @@ func (g *GraphRenderer) renderRefs(refs []git.Ref, bg lipgloss.Color) string {
 	return strings.Join(parts, lipgloss.NewStyle().Background(bg).Render(" "))
 }
 
+// renderBisectMark renders the bisect badge shown after a commit's hash, or
+// "" if the commit takes no part in the bisect.
+func (g *GraphRenderer) renderBisectMark(mark bisectMark) string {
+	style := lipgloss.NewStyle().Background(g.theme.BackgroundPanel).Padding(0, 1)
+	switch mark {
+	case bisectSkipped:
+		return style.Foreground(g.theme.Subtext).Italic(true).Render("skip")
+	case bisectGood:
+		return style.Foreground(g.theme.DiffAdd).Bold(true).Render("good")
+	case bisectBad:
+		return style.Foreground(g.theme.DiffRemove).Bold(true).Render("bad")
+	case bisectCurrent:
+		return style.Foreground(g.theme.Tag).Bold(true).Render("bisect ?")
+	case bisectFirstBad:
+		return style.Foreground(g.theme.Background).Background(g.theme.DiffRemove).Bold(true).Render("first bad")
+	}
+	return ""
+}
+
+// SetBisect sets the bisect marks to show, or clears them if s is nil.
+func (g *GraphRenderer) SetBisect(s *git.BisectStatus) {
+	g.bisect = nil
+	if s == nil {
+		return
+	}
+	g.bisect = make(map[string]bisectMark)
+	for _, hash := range s.Skipped {
+		g.bisect[hash] = bisectSkipped
+	}
+	for _, hash := range s.Good {
+		g.bisect[hash] = bisectGood
+	}
+	if s.Bad != "" {
+		g.bisect[s.Bad] = bisectBad
+	}
+	if s.FirstBad != "" {
+		g.bisect[s.FirstBad] = bisectFirstBad
+	} else if s.Current != "" && g.bisect[s.Current] == bisectNone {
+		g.bisect[s.Current] = bisectCurrent
+	}
+}
+
 func (g *GraphRenderer) renderSimple(commit *git.Commit, index int, bg lipgloss.Color) string {
 	colorIndex := index % len(g.colors)
 	color := g.colors[colorIndex]
@@ -729,25 +914,30 @@ type diffLine struct {
 }
 
 // parseDiffLines parses raw unified diff text into structured diffLines,
-// skipping file-level headers (diff --git, index, ---, +++).
+// skipping file-level headers (diff --git, index, ---, +++). Header lines are
+// only recognised outside of a hunk, so removed lines that happen to start
+// with "--" are kept.
 func parseDiffLines(raw string) []diffLine {
-	lines := strings.Split(raw, "\n")
+	lines := strings.Split(strings.TrimSuffix(raw, "\n"), "\n")
 	var result []diffLine
 	var oldLine, newLine int
+	inHunk := false
 
 	for _, line := range lines {
-		if strings.HasPrefix(line, "diff --git") ||
-			strings.HasPrefix(line, "index ") ||
-			strings.HasPrefix(line, "---") ||
-			strings.HasPrefix(line, "+++") ||
-			strings.HasPrefix(line, "new file") ||
-			strings.HasPrefix(line, "deleted file") {
+		if strings.HasPrefix(line, "diff --git") || strings.HasPrefix(line, "diff --cc") {
+			inHunk = false
 			continue
 		}
 
 		if strings.HasPrefix(line, "@@") {
 			oldLine, newLine = parseHunkHeader(line)
 			result = append(result, diffLine{kind: '@', content: line})
+			inHunk = true
+			continue
+		}
+
+		if !inHunk {
+			// File-level header (index, ---, +++, new file mode, ...).
 			continue
 		}
 
@@ -791,12 +981,18 @@ type sideBySidePair struct {
 	rightNum  int
 	rightText string
 	rightKind byte // ' ', '+', or '@'
+
+	// Index of each side among its hunk's added and removed lines, as
+	// BuildLinePatch counts them; -1 if that side is not a change.
+	leftChange  int
+	rightChange int
 }
 
 // buildSideBySidePairs converts parsed diff lines into paired left/right rows.
 // Adjacent remove/add blocks are zipped together; context appears on both sides.
 func buildSideBySidePairs(dlines []diffLine) []sideBySidePair {
 	var pairs []sideBySidePair
+	change := 0 // next change index in the current hunk
 	i := 0
 	for i < len(dlines) {
 		dl := dlines[i]
@@ -804,21 +1000,26 @@ func buildSideBySidePairs(dlines []diffLine) []sideBySidePair {
 		switch dl.kind {
 		case '@':
 			pairs = append(pairs, sideBySidePair{
-				leftKind:  '@',
-				leftText:  dl.content,
-				rightKind: '@',
-				rightText: dl.content,
+				leftKind:    '@',
+				leftText:    dl.content,
+				rightKind:   '@',
+				rightText:   dl.content,
+				leftChange:  -1,
+				rightChange: -1,
 			})
+			change = 0
 			i++
 
 		case ' ':
 			pairs = append(pairs, sideBySidePair{
-				leftNum:   dl.oldNum,
-				leftText:  dl.content,
-				leftKind:  ' ',
-				rightNum:  dl.newNum,
-				rightText: dl.content,
-				rightKind: ' ',
+				leftNum:     dl.oldNum,
+				leftText:    dl.content,
+				leftKind:    ' ',
+				rightNum:    dl.newNum,
+				rightText:   dl.content,
+				rightKind:   ' ',
+				leftChange:  -1,
+				rightChange: -1,
 			})
 			i++
 
@@ -841,36 +1042,44 @@ func buildSideBySidePairs(dlines []diffLine) []sideBySidePair {
 				maxLen = len(adds)
 			}
 			for j := 0; j < maxLen; j++ {
-				p := sideBySidePair{}
+				p := sideBySidePair{leftChange: -1, rightChange: -1}
 				if j < len(removes) {
 					p.leftNum = removes[j].oldNum
 					p.leftText = removes[j].content
 					p.leftKind = '-'
+					p.leftChange = change + j
 				}
 				if j < len(adds) {
 					p.rightNum = adds[j].newNum
 					p.rightText = adds[j].content
 					p.rightKind = '+'
+					p.rightChange = change + len(removes) + j
 				}
 				pairs = append(pairs, p)
 			}
+			change += len(removes) + len(adds)
 
 		case '+':
 			// Orphan add (no preceding remove).
 			pairs = append(pairs, sideBySidePair{
-				rightNum:  dl.newNum,
-				rightText: dl.content,
-				rightKind: '+',
+				rightNum:    dl.newNum,
+				rightText:   dl.content,
+				rightKind:   '+',
+				leftChange:  -1,
+				rightChange: change,
 			})
+			change++
 			i++
 
 		case '\\':
 			// "\ No newline at end of file" — show on both sides.
 			pairs = append(pairs, sideBySidePair{
-				leftText:  dl.content,
-				leftKind:  '\\',
-				rightText: dl.content,
-				rightKind: '\\',
+				leftText:    dl.content,
+				leftKind:    '\\',
+				rightText:   dl.content,
+				rightKind:   '\\',
+				leftChange:  -1,
+				rightChange: -1,
 			})
 			i++
 
@@ -884,8 +1093,62 @@ func buildSideBySidePairs(dlines []diffLine) []sideBySidePair {
 // FormatDiffLines takes a raw diff string and returns styled side-by-side lines.
 // maxWidth is the total available character width for the diff area.
 func (g *GraphRenderer) FormatDiffLines(diff string, maxWidth int) []string {
+	lines, _, _ := g.formatDiff(diff, maxWidth, -1, -1)
+	return lines
+}
+
+// FormatStagingDiffLines renders the unstaged and staged diffs of a working
+// tree file as two labelled sections. Hunks are numbered across both sections
+// (unstaged first) and the hunk numbered selectedHunk is highlighted, as is
+// its change line selectedLine (-1 = none). It also returns the line offset
+// of every hunk header and of the selected line (-1 if none) so callers can
+// scroll to them.
+func (g *GraphRenderer) FormatStagingDiffLines(unstaged, staged string, selectedHunk, selectedLine int, maxWidth int) ([]string, []int, int) {
+	sectionStyle := lipgloss.NewStyle().
+		Foreground(g.theme.Subtext).
+		Background(g.theme.BackgroundPanel).
+		Bold(true).
+		Width(maxWidth)
+
+	var result []string
+	var hunkLines []int
+	lineRow := -1
+	firstHunk := 0
+	sections := []struct {
+		title string
+		diff  string
+	}{
+		{"Unstaged", unstaged},
+		{"Staged", staged},
+	}
+	for _, sec := range sections {
+		if sec.diff == "" {
+			continue
+		}
+		n := countHunks(sec.diff)
+		result = append(result, sectionStyle.Render(truncate(fmt.Sprintf(" %s (%d hunks)", sec.title, n), maxWidth)))
+		lines, offsets, row := g.formatDiff(sec.diff, maxWidth, selectedHunk-firstHunk, selectedLine)
+		for _, off := range offsets {
+			hunkLines = append(hunkLines, len(result)+off)
+		}
+		if row >= 0 {
+			lineRow = len(result) + row
+		}
+		result = append(result, lines...)
+		firstHunk += n
+	}
+	return result, hunkLines, lineRow
+}
+
+// formatDiff renders a raw diff as side-by-side lines. The hunk whose index
+// equals selectedHunk (-1 = none) gets a highlighted header, and its change
+// line selectedLine (-1 = none) a highlighted half row. The returned offsets
+// give the line index of each rendered hunk header, and lineRow that of the
+// selected line (-1 if none).
+func (g *GraphRenderer) formatDiff(diff string, maxWidth int, selectedHunk, selectedLine int) (result []string, hunkOffsets []int, lineRow int) {
+	lineRow = -1
 	if diff == "" {
-		return nil
+		return nil, nil, lineRow
 	}
 
 	parsed := parseDiffLines(diff)
@@ -948,6 +1211,11 @@ func (g *GraphRenderer) FormatDiffLines(diff string, maxWidth int) []string {
 		Foreground(g.theme.BranchFeature).
 		Background(g.theme.BackgroundPanel).
 		Width(maxWidth)
+	selectedHunkStyle := lipgloss.NewStyle().
+		Foreground(g.theme.Head).
+		Background(g.theme.Selection).
+		Bold(true).
+		Width(maxWidth)
 	sepStyle := lipgloss.NewStyle().
 		Foreground(g.theme.DiffContext).
 		Background(g.theme.Background)
@@ -957,13 +1225,21 @@ func (g *GraphRenderer) FormatDiffLines(diff string, maxWidth int) []string {
 		Italic(true).
 		Width(maxWidth)
 
-	sep := sepStyle.Render("│")
+	// The selected line keeps its colour on the selection background.
+	selectedRemoveStyle := removeContentStyle.Background(g.theme.Selection).Bold(true)
+	selectedAddStyle := addContentStyle.Background(g.theme.Selection).Bold(true)
 
-	var result []string
+	sep := sepStyle.Render("│")
 
 	for _, p := range pairs {
+		inSelectedHunk := len(hunkOffsets)-1 == selectedHunk
 		if p.leftKind == '@' {
-			result = append(result, hunkStyle.Render(truncate(p.leftText, maxWidth)))
+			if len(hunkOffsets) == selectedHunk {
+				result = append(result, selectedHunkStyle.Render(truncate("▶ "+p.leftText, maxWidth)))
+			} else {
+				result = append(result, hunkStyle.Render(truncate(p.leftText, maxWidth)))
+			}
+			hunkOffsets = append(hunkOffsets, len(result)-1)
 			continue
 		}
 
@@ -977,7 +1253,12 @@ func (g *GraphRenderer) FormatDiffLines(diff string, maxWidth int) []string {
 		switch p.leftKind {
 		case '-':
 			leftNum = numStyleOld.Render(fmt.Sprintf("%d", p.leftNum))
-			leftContent = removeContentStyle.Render(truncate(p.leftText, contentWidth))
+			if inSelectedHunk && p.leftChange == selectedLine {
+				leftContent = selectedRemoveStyle.Render(truncate(p.leftText, contentWidth))
+				lineRow = len(result)
+			} else {
+				leftContent = removeContentStyle.Render(truncate(p.leftText, contentWidth))
+			}
 		case ' ':
 			leftNum = numStyleCtx.Render(fmt.Sprintf("%d", p.leftNum))
 			leftContent = contextContentStyle.Render(truncate(p.leftText, contentWidth))
@@ -991,7 +1272,12 @@ func (g *GraphRenderer) FormatDiffLines(diff string, maxWidth int) []string {
 		switch p.rightKind {
 		case '+':
 			rightNum = numStyleNew.Render(fmt.Sprintf("%d", p.rightNum))
-			rightContent = addContentStyle.Render(truncate(p.rightText, contentWidth))
+			if inSelectedHunk && p.rightChange == selectedLine {
+				rightContent = selectedAddStyle.Render(truncate(p.rightText, contentWidth))
+				lineRow = len(result)
+			} else {
+				rightContent = addContentStyle.Render(truncate(p.rightText, contentWidth))
+			}
 		case ' ':
 			rightNum = numStyleCtx.Render(fmt.Sprintf("%d", p.rightNum))
 			rightContent = contextContentStyle.Render(truncate(p.rightText, contentWidth))
@@ -1010,11 +1296,82 @@ func (g *GraphRenderer) FormatDiffLines(diff string, maxWidth int) []string {
 		result = result[:maxDiffLines]
 		result = append(result, headerStyle.Render(
 			fmt.Sprintf("  ... %d more lines (truncated)", len(pairs)-maxDiffLines)))
+		for i, off := range hunkOffsets {
+			if off >= maxDiffLines {
+				hunkOffsets[i] = maxDiffLines // point at the truncation notice
+			}
+		}
+		if lineRow >= maxDiffLines {
+			lineRow = maxDiffLines
+		}
 	}
 
+	return result, hunkOffsets, lineRow
+}
+
+// FormatSubmoduleLines renders how a submodule moved: a header with its
+// old..new range, then the commits it gained (>) and lost (<), newest
+// first.
+func (g *GraphRenderer) FormatSubmoduleLines(change *git.SubmoduleChange, maxWidth int) []string {
+	headerStyle := lipgloss.NewStyle().
+		Foreground(g.theme.BranchFeature).
+		Background(g.theme.BackgroundPanel).
+		Width(maxWidth)
+	noteStyle := lipgloss.NewStyle().
+		Foreground(g.theme.Subtext).
+		Background(g.theme.Background).
+		Italic(true).
+		Width(maxWidth)
+	addStyle := lipgloss.NewStyle().
+		Foreground(g.theme.DiffAdd).
+		Background(g.theme.DiffAddBg).
+		Width(maxWidth)
+	removeStyle := lipgloss.NewStyle().
+		Foreground(g.theme.DiffRemove).
+		Background(g.theme.DiffRemoveBg).
+		Width(maxWidth)
+
+	var header string
+	switch {
+	case change.Old == "" && change.New == "":
+		header = "Submodule " + change.Path
+	case change.Old == "":
+		header = fmt.Sprintf("Submodule %s added at %s", change.Path, shortHash(change.New))
+	case change.New == "":
+		header = fmt.Sprintf("Submodule %s removed (was at %s)", change.Path, shortHash(change.Old))
+	case change.Old == change.New:
+		header = fmt.Sprintf("Submodule %s at %s", change.Path, shortHash(change.New))
+	default:
+		header = fmt.Sprintf("Submodule %s %s..%s", change.Path, shortHash(change.Old), shortHash(change.New))
+	}
+	result := []string{headerStyle.Render(truncate(header, maxWidth))}
+
+	for _, c := range change.Commits {
+		line := fmt.Sprintf("  > %s %s", shortHash(c.Hash), c.Subject)
+		style := addStyle
+		if c.Rewound {
+			line = fmt.Sprintf("  < %s %s", shortHash(c.Hash), c.Subject)
+			style = removeStyle
+		}
+		result = append(result, style.Render(truncate(line, maxWidth)))
+	}
+	if change.Missing {
+		result = append(result, noteStyle.Render(truncate("  commits not available: the submodule is not checked out or lacks them (O then u to update)", maxWidth)))
+	}
+	if change.Dirty {
+		result = append(result, noteStyle.Render(truncate("  contains uncommitted changes", maxWidth)))
+	}
 	return result
 }
 
+// shortHash abbreviates a full commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
 func truncate(s string, maxWidth int) string {
 	if maxWidth <= 0 {
 		return s
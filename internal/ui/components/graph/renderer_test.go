@@ -0,0 +1,117 @@
+package graph
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// history builds a small topologically ordered history with two feature
+// branches, one merged and one still open:
+//
+//	m  merge of f2 into b
+//	o  open branch tip, forked from a
+//	f2
+//	b
+//	f1
+//	a
+//	root
+func history() []*git.Commit {
+	c := func(hash string, parents ...string) *git.Commit {
+		return &git.Commit{Hash: hash, Parents: parents}
+	}
+	return []*git.Commit{
+		c("m", "b", "f2"),
+		c("o", "a"),
+		c("f2", "f1"),
+		c("b", "a"),
+		c("f1", "a"),
+		c("a", "root"),
+		c("root"),
+	}
+}
+
+type laidOut struct {
+	X, Color          int
+	Parents, Children []int
+	Lanes, Post       []int
+}
+
+func layoutOf(g *GraphRenderer) []laidOut {
+	gb := g.graph
+	out := make([]laidOut, len(gb.vertices))
+	for i, v := range gb.vertices {
+		resolved := func(ids []int) []int {
+			r := make([]int, len(ids))
+			for k, id := range ids {
+				r[k] = gb.resolve(id)
+			}
+			return r
+		}
+		out[i] = laidOut{
+			X:        v.x,
+			Color:    v.color,
+			Parents:  v.parents,
+			Children: v.children,
+			Lanes:    resolved(gb.laneSnapshots[i].lanes),
+			Post:     resolved(gb.postLaneSnapshots[i].lanes),
+		}
+	}
+	return out
+}
+
+func TestAppendGraphMatchesFullLayout(t *testing.T) {
+	commits := history()
+
+	full := &GraphRenderer{}
+	full.InitGraph(commits)
+	want := layoutOf(full)
+
+	for split := 0; split <= len(commits); split++ {
+		t.Run(fmt.Sprintf("split=%d", split), func(t *testing.T) {
+			paged := &GraphRenderer{}
+			paged.InitGraph(commits[:split])
+			paged.AppendGraph(commits[split:])
+
+			got := layoutOf(paged)
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("paged layout differs:\n got %+v\nwant %+v", got, want)
+			}
+			if paged.graph.maxLanes != full.graph.maxLanes {
+				t.Errorf("maxLanes = %d, want %d", paged.graph.maxLanes, full.graph.maxLanes)
+			}
+		})
+	}
+}
+
+func TestUnloadedParentKeepsLane(t *testing.T) {
+	g := &GraphRenderer{}
+	g.InitGraph(history()[:2]) // merge and open tip, no parents loaded yet
+
+	post := g.graph.postLaneSnapshots[1].lanes
+	occupied := 0
+	for _, id := range post {
+		if id != -1 {
+			occupied++
+		}
+	}
+	// b, f2 and a are all still pending below the page.
+	if occupied != 3 {
+		t.Errorf("expected 3 lanes reserved for unloaded parents, got %v", post)
+	}
+}
+
+func TestRenderRefsNamesOtherWorktree(t *testing.T) {
+	g := NewGraphRenderer(styles.CatppuccinMocha())
+	out := g.renderRefs([]git.Ref{
+		{Name: "main", RefType: git.RefTypeBranch, IsHead: true},
+		{Name: "feature", RefType: git.RefTypeBranch, Worktree: "/src/repo-feature"},
+	}, g.theme.Background)
+	if !strings.Contains(out, "* main") || !strings.Contains(out, "+ feature in repo-feature") {
+		t.Errorf("renderRefs = %q, want the worktree of feature named", out)
+	}
+}
@@ -1,6 +1,9 @@
 package modals
 
 import (
+	"fmt"
+	"path/filepath"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/yourusername/lazygit-lite/internal/git"
 	"github.com/yourusername/lazygit-lite/internal/ui/styles"
@@ -66,13 +69,13 @@ func (m BranchModal) View() string {
 
 	// Adaptive hint text for the title row.
 	titleText := " Branches"
-	hintText := "Enter to checkout | Esc to close"
+	hintText := "Enter checkout | m merge | n new | r rename | d delete | u/U set/unset upstream | l reflog | Esc close"
 	titleRendered := titleStyle.Render(titleText)
 	hintRendered := hintStyle.Render(hintText)
 	titleGap := innerWidth - lipgloss.Width(titleText) - lipgloss.Width(hintText)
 	if titleGap < 1 {
 		// Try shorter hint.
-		hintText = "Enter | Esc"
+		hintText = "Enter | m | n | r | d | u | U | l | Esc"
 		hintRendered = hintStyle.Render(hintText)
 		titleGap = innerWidth - lipgloss.Width(titleText) - lipgloss.Width(hintText)
 		if titleGap < 1 {
@@ -123,18 +126,34 @@ func (m BranchModal) View() string {
 		nameStyle := lipgloss.NewStyle().Foreground(theme.BranchMain).Background(bg).Bold(true)
 		currentStyle := lipgloss.NewStyle().Foreground(theme.Head).Background(bg)
 		hashStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		upstreamStyle := lipgloss.NewStyle().Foreground(theme.BranchFeature).Background(bg)
+		worktreeStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg).Italic(true)
 
+		// "+" marks a branch checked out in another worktree, as git
+		// branch does.
 		prefix := "  "
 		if b.IsCurrent {
 			prefix = currentStyle.Render("* ")
+		} else if b.Worktree != "" {
+			prefix = worktreeStyle.Render("+ ")
 		} else {
 			prefix = rowBg.Render("  ")
 		}
 
-		// Truncate branch name to fit. Reserve: prefix(2) + hash(8) + space(1) = 11
-		nameAvail := innerWidth - 11
+		// Truncate branch name to fit. Reserve: prefix(2) + hash(8) + space(1) = 11,
+		// plus the upstream when there is one.
+		upstream := ""
+		if b.Upstream != "" {
+			upstream = " → " + b.Upstream + branchTracking(b)
+		}
+		worktree := ""
+		if b.Worktree != "" {
+			worktree = " in " + filepath.Base(b.Worktree)
+		}
+		nameAvail := innerWidth - 11 - lipgloss.Width(upstream) - lipgloss.Width(worktree)
 		if nameAvail < 6 {
 			nameAvail = 6
+			upstream, worktree = "", ""
 		}
 		displayName := b.Name
 		nameRunes := []rune(displayName)
@@ -144,7 +163,7 @@ func (m BranchModal) View() string {
 
 		name := nameStyle.Render(displayName)
 		hash := hashStyle.Render(" " + b.Hash[:7])
-		row := prefix + name + hash
+		row := prefix + name + hash + upstreamStyle.Render(upstream) + worktreeStyle.Render(worktree)
 
 		visWidth := lipgloss.Width(row)
 		if visWidth < innerWidth {
@@ -192,6 +211,28 @@ func (m *BranchModal) Show(branches []*git.Branch) {
 	}
 }
 
+// SetBranches refreshes the list after a branch was created, renamed or
+// deleted, keeping the cursor on the same branch name where possible.
+func (m *BranchModal) SetBranches(branches []*git.Branch) {
+	selected := ""
+	if b := m.SelectedBranch(); b != nil {
+		selected = b.Name
+	}
+	m.branches = branches
+	for i, b := range branches {
+		if b.Name == selected {
+			m.cursor = i
+			return
+		}
+	}
+	if m.cursor >= len(branches) {
+		m.cursor = len(branches) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
 func (m *BranchModal) Hide() {
 	m.visible = false
 	m.branches = nil
@@ -228,3 +269,19 @@ func (m *BranchModal) SetSize(width, height int) {
 	m.width = width
 	m.height = height
 }
+
+// branchTracking formats how far a branch is ahead of and behind its
+// upstream, e.g. " ↑2 ↓5", or notes that the upstream is gone.
+func branchTracking(b *git.Branch) string {
+	if b.UpstreamGone {
+		return " (gone)"
+	}
+	s := ""
+	if b.Ahead > 0 {
+		s += fmt.Sprintf(" ↑%d", b.Ahead)
+	}
+	if b.Behind > 0 {
+		s += fmt.Sprintf(" ↓%d", b.Behind)
+	}
+	return s
+}
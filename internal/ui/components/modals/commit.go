@@ -1,16 +1,31 @@
 package modals
 
 import (
+	"strings"
+
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/yourusername/lazygit-lite/internal/ui/styles"
 )
 
+// CommitMode is what the commit bar does with the message entered.
+type CommitMode int
+
+const (
+	// CommitNew creates a commit from the staged changes.
+	CommitNew CommitMode = iota
+	// CommitAmend folds the staged changes into HEAD with the message.
+	CommitAmend
+	// CommitReword replaces the message of a commit.
+	CommitReword
+)
+
 type CommitModal struct {
 	input   textinput.Model
 	styles  *styles.Styles
 	visible bool
+	mode    CommitMode
 	width   int
 	height  int
 }
@@ -87,7 +102,7 @@ func (m CommitModal) View() string {
 		Background(panelBg).
 		Italic(true)
 
-	label := labelStyle.Render(" Commit:")
+	label := labelStyle.Render(" " + m.label() + ":")
 	tiView := m.input.View()
 
 	// Adaptive hint: drop or shorten based on available width.
@@ -95,7 +110,7 @@ func (m CommitModal) View() string {
 	tiWidth := lipgloss.Width(tiView)
 	innerAvail := m.width - 4 // border left/right + small padding
 
-	hintText := "  Enter to commit | Esc to cancel"
+	hintText := "  Enter to " + strings.ToLower(m.label()) + " | Esc to cancel"
 	hintWidth := lipgloss.Width(hintText)
 	used := labelWidth + 1 + tiWidth + hintWidth
 	if used > innerAvail {
@@ -132,9 +147,32 @@ func (m CommitModal) View() string {
 }
 
 func (m *CommitModal) Show() {
+	m.ShowMode(CommitNew, "")
+}
+
+// ShowMode opens the bar to amend or reword a commit, pre-filled with
+// message.
+func (m *CommitModal) ShowMode(mode CommitMode, message string) {
 	m.visible = true
+	m.mode = mode
 	m.input.Focus()
-	m.input.SetValue("")
+	m.input.SetValue(message)
+	m.input.CursorEnd()
+}
+
+func (m CommitModal) Mode() CommitMode {
+	return m.mode
+}
+
+func (m CommitModal) label() string {
+	switch m.mode {
+	case CommitAmend:
+		return "Amend"
+	case CommitReword:
+		return "Reword"
+	default:
+		return "Commit"
+	}
 }
 
 func (m *CommitModal) Hide() {
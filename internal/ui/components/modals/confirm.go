@@ -0,0 +1,96 @@
+package modals
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// ConfirmModal is an inline yes/no prompt for destructive operations. It
+// lists what the operation would affect, e.g. the files a hard reset would
+// discard.
+type ConfirmModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	title   string
+	lines   []string
+}
+
+func NewConfirmModal(s *styles.Styles) ConfirmModal {
+	return ConfirmModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// visibleRows returns the number of detail rows shown, including the
+// "… N more" row when the list is cut.
+func (m ConfirmModal) visibleRows() int {
+	return listRows(len(m.lines), m.height)
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m ConfirmModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	return m.visibleRows() + 3 // border(2) + title(1) + detail rows
+}
+
+// View renders the inline confirmation prompt.
+func (m ConfirmModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+	lineStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(panelBg)
+
+	rows := []string{titleRow(theme, innerWidth, m.title,
+		"y to confirm | Esc to cancel", "y | Esc")}
+
+	shown := m.lines
+	if n := m.visibleRows(); len(shown) > n {
+		shown = shown[:n-1]
+	}
+	for _, line := range shown {
+		rows = append(rows, lineStyle.Width(innerWidth).Render(truncateRunes("  "+line, innerWidth)))
+	}
+	if more := len(m.lines) - len(shown); more > 0 {
+		moreStyle := lineStyle.Italic(true)
+		rows = append(rows, moreStyle.Width(innerWidth).Render(fmt.Sprintf("  … %d more", more)))
+	}
+	if len(m.lines) == 0 {
+		rows = append(rows, lineStyle.Width(innerWidth).Render(""))
+	}
+
+	return panel(theme, m.width, theme.DiffRemove, rows)
+}
+
+// Show opens the prompt with a question and the details it affects.
+func (m *ConfirmModal) Show(title string, lines []string) {
+	m.visible = true
+	m.title = title
+	m.lines = lines
+}
+
+func (m *ConfirmModal) Hide() {
+	m.visible = false
+	m.lines = nil
+}
+
+func (m *ConfirmModal) IsVisible() bool {
+	return m.visible
+}
+
+func (m *ConfirmModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,263 @@
+package modals
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// maxConflictSideRows caps how many lines of each side of a hunk show.
+const maxConflictSideRows = 6
+
+// conflictDescriptions explains the porcelain codes of unmerged paths.
+var conflictDescriptions = map[string]string{
+	"UU": "both modified",
+	"AA": "both added",
+	"DD": "both deleted",
+	"AU": "added by us",
+	"UA": "added by them",
+	"DU": "deleted by us",
+	"UD": "deleted by them",
+}
+
+// ConflictModal is an inline panel listing unmerged paths. Opening a file
+// steps through its conflict hunks, showing our and their side of each.
+type ConflictModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	files   []git.ChangedFile
+	cursor  int
+
+	// path and content are the file whose hunks are shown, if any.
+	path    string
+	content *git.ConflictedContent
+	hunk    int
+}
+
+func NewConflictModal(s *styles.Styles) ConflictModal {
+	return ConflictModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// conflictLine is one unstyled body row of the hunk view.
+type conflictLine struct {
+	text  string
+	label bool // a side header rather than file content
+	side  int  // 0 ours, 1 base, 2 theirs
+}
+
+// hunkLines returns the rows showing the selected hunk.
+func (m ConflictModal) hunkLines() []conflictLine {
+	if m.content == nil || len(m.content.Hunks) == 0 {
+		return []conflictLine{{text: "No conflict markers — o / t / b to keep our, their or both versions", label: true}}
+	}
+	h := m.content.Hunks[m.hunk]
+	var lines []conflictLine
+	add := func(side int, label string, body []string) {
+		lines = append(lines, conflictLine{text: label, label: true, side: side})
+		shown := body
+		if len(shown) > maxConflictSideRows {
+			shown = shown[:maxConflictSideRows-1]
+		}
+		for _, l := range shown {
+			lines = append(lines, conflictLine{text: strings.TrimRight(l, "\r\n"), side: side})
+		}
+		if len(shown) < len(body) {
+			lines = append(lines, conflictLine{text: fmt.Sprintf("… %d more lines", len(body)-len(shown)), side: side})
+		}
+	}
+	add(0, "ours ("+h.OursLabel+")", h.Ours)
+	if h.HasBase {
+		add(1, "base", h.Base)
+	}
+	add(2, "theirs ("+h.TheirsLabel+")", h.Theirs)
+	return lines
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m ConflictModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	if m.InFile() {
+		// border(2) + title(1) + hunk rows
+		return len(m.hunkLines()) + 3
+	}
+	// border(2) + title(1) + file rows
+	return listRows(len(m.files), m.height) + 3
+}
+
+// View renders the file list or the hunks of the open file.
+func (m ConflictModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	if m.InFile() {
+		title := "Conflicts in " + m.path
+		if m.content != nil && len(m.content.Hunks) > 0 {
+			title += fmt.Sprintf(" — hunk %d/%d, line %d", m.hunk+1, len(m.content.Hunks), m.content.Hunks[m.hunk].Line)
+		}
+		rows := []string{titleRow(theme, innerWidth, title,
+			"o ours | t theirs | b both | O/T/B whole file | Esc back",
+			"o | t | b | O | T | B | Esc")}
+		sideColors := []lipgloss.Color{theme.Head, theme.Subtext, theme.BranchFeature}
+		for _, line := range m.hunkLines() {
+			style := lipgloss.NewStyle().Foreground(theme.Foreground).Background(panelBg)
+			text := "    " + line.text
+			if line.label {
+				style = style.Foreground(sideColors[line.side]).Bold(true)
+				text = "  " + line.text
+			}
+			rows = append(rows, padRow(style.Render(truncateRunes(text, innerWidth)), innerWidth, panelBg))
+		}
+		return panel(theme, m.width, theme.DiffRemove, rows)
+	}
+
+	rows := []string{titleRow(theme, innerWidth, "Conflicts",
+		"Enter hunks | o ours | t theirs | b both | a mark resolved | alt+c continue | alt+a abort | Esc close",
+		"Enter | o | t | b | a | alt+c | alt+a | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.files), listRows(len(m.files), m.height))
+	for i := start; i < end; i++ {
+		file := m.files[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		codeStyle := lipgloss.NewStyle().Foreground(theme.DiffRemove).Background(bg).Bold(true)
+		pathStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Background(bg)
+		descStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+
+		row := rowBg.Render("  ") + codeStyle.Render(file.Conflict) + rowBg.Render(" ") +
+			pathStyle.Render(truncateRunes(file.Path, innerWidth*2/3))
+		if desc := conflictDescriptions[file.Conflict]; desc != "" {
+			if avail := innerWidth - lipgloss.Width(row) - 2; avail > 3 {
+				row += rowBg.Render("  ") + descStyle.Render(truncateRunes(desc, avail))
+			}
+		}
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	if len(m.files) == 0 {
+		doneStyle := lipgloss.NewStyle().Foreground(theme.DiffAdd).Background(panelBg).Italic(true)
+		rows = append(rows, padRow(doneStyle.Render("  All conflicts resolved — alt+c to continue, alt+a to abort"), innerWidth, panelBg))
+	}
+
+	return panel(theme, m.width, theme.DiffRemove, rows)
+}
+
+// Show opens the panel with the given unmerged paths, keeping the cursor
+// in range when the list is refreshed.
+func (m *ConflictModal) Show(files []git.ChangedFile) {
+	m.visible = true
+	m.files = files
+	if m.cursor >= len(files) {
+		m.cursor = len(files) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *ConflictModal) Hide() {
+	m.visible = false
+	m.files = nil
+	m.cursor = 0
+	m.CloseFile()
+}
+
+func (m *ConflictModal) IsVisible() bool {
+	return m.visible
+}
+
+// OpenFile shows the hunks of path, keeping the hunk cursor in range when
+// the same file is reloaded after a hunk was resolved.
+func (m *ConflictModal) OpenFile(path string, content *git.ConflictedContent) {
+	if path != m.path {
+		m.hunk = 0
+	}
+	m.path = path
+	m.content = content
+	if m.hunk >= len(content.Hunks) {
+		m.hunk = len(content.Hunks) - 1
+	}
+	if m.hunk < 0 {
+		m.hunk = 0
+	}
+}
+
+// CloseFile returns to the file list.
+func (m *ConflictModal) CloseFile() {
+	m.path = ""
+	m.content = nil
+	m.hunk = 0
+}
+
+// InFile reports whether the hunks of a file are shown.
+func (m ConflictModal) InFile() bool {
+	return m.path != ""
+}
+
+// OpenPath returns the file whose hunks are shown, or "".
+func (m ConflictModal) OpenPath() string {
+	return m.path
+}
+
+// SelectedHunk returns the index of the highlighted hunk of the open file.
+func (m ConflictModal) SelectedHunk() int {
+	return m.hunk
+}
+
+// MoveUp moves to the previous file, or hunk when a file is open.
+func (m *ConflictModal) MoveUp() {
+	if m.InFile() {
+		if m.hunk > 0 {
+			m.hunk--
+		}
+		return
+	}
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves to the next file, or hunk when a file is open.
+func (m *ConflictModal) MoveDown() {
+	if m.InFile() {
+		if m.content != nil && m.hunk < len(m.content.Hunks)-1 {
+			m.hunk++
+		}
+		return
+	}
+	if m.cursor < len(m.files)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedFile returns the highlighted file, or nil.
+func (m ConflictModal) SelectedFile() *git.ChangedFile {
+	if m.cursor >= 0 && m.cursor < len(m.files) {
+		return &m.files[m.cursor]
+	}
+	return nil
+}
+
+func (m *ConflictModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -23,6 +23,109 @@ func NewHelpModal(styles *styles.Styles) HelpModal {
 	}
 }
 
+type helpEntry struct {
+	key  string
+	desc string
+}
+
+type helpSection struct {
+	title   string
+	entries []helpEntry
+}
+
+// helpColumns returns the keybinding sections shown in the left and right
+// columns of the help panel.
+func helpColumns() (left, right []helpSection) {
+	left = []helpSection{
+		{"Navigation", []helpEntry{
+			{"j / Down", "Move down"},
+			{"k / Up", "Move up"},
+			{"g / Home", "Go to top"},
+			{"G / End", "Go to bottom"},
+			{"Ctrl+D", "Page down"},
+			{"Ctrl+U", "Page up"},
+		}},
+		{"Expand / Collapse", []helpEntry{
+			{"Enter", "Expand / toggle diff"},
+			{"Esc", "Collapse"},
+			{"j / k", "Navigate files"},
+			{"a", "Blame selected file"},
+			{"H", "History of selected file / a path"},
+		}},
+		{"Staging", []helpEntry{
+			{"Space", "Stage / unstage file, hunk or line"},
+			{"Tab", "Next hunk"},
+			{"Shift+Tab", "Previous hunk"},
+			{"J / K", "Next / previous line in hunk"},
+		}},
+		{"History", []helpEntry{
+			{"i", "Interactive rebase from commit"},
+			{"v", "Mark / unmark commit"},
+			{"C", "Cherry-pick marked commits"},
+			{"R", "Revert marked commits"},
+			{"X", "Reset HEAD to commit"},
+			{"L", "Reflog of HEAD"},
+			{"z", "Undo last operation"},
+			{"Z", "Redo last undo"},
+			{"m", "Merge branch on commit into HEAD"},
+			{"F", "Fixup commit for selected commit"},
+			{"Ctrl+F", "Autosquash pending fixups"},
+			{"x", "Resolve conflicts"},
+			{"B", "Bisect: mark commit good / bad / skip"},
+			{"Alt+C", "Continue rebase / pick / merge"},
+			{"Alt+S", "Skip commit (also bisect)"},
+			{"Alt+A", "Abort rebase / pick / merge, end bisect"},
+		}},
+	}
+	right = []helpSection{
+		{"Actions", []helpEntry{
+			{"c", "Commit staged changes"},
+			{"A", "Amend last commit"},
+			{"r", "Reword commit"},
+			{"p", "Push"},
+			{"P", "Pull"},
+			{"f", "Fetch"},
+			{"M", "Remotes"},
+			{"W", "Worktrees"},
+			{"O", "Submodules"},
+			{"Backspace", "Back to parent repository"},
+			{"b", "Branches"},
+			{"n", "New branch from commit"},
+		}},
+		{"Tags", []helpEntry{
+			{"t", "Tag list"},
+		}},
+		{"Stash", []helpEntry{
+			{"s", "Stash changes"},
+			{"S", "Stash list"},
+		}},
+		{"Clipboard", []helpEntry{
+			{"y", "Copy hash"},
+			{"Y", "Copy message"},
+			{"Ctrl+Y", "Copy diff"},
+		}},
+		{"General", []helpEntry{
+			{"o", "Show last git error output"},
+			{"?", "Toggle help"},
+			{"q", "Quit"},
+		}},
+	}
+	return left, right
+}
+
+// sectionRowCount returns the number of rows the sections occupy, including
+// one title row per section and a blank row between sections.
+func sectionRowCount(sections []helpSection) int {
+	rows := 0
+	for i, sec := range sections {
+		if i > 0 {
+			rows++ // blank separator
+		}
+		rows += 1 + len(sec.entries)
+	}
+	return rows
+}
+
 // singleColumn returns true when the terminal is too narrow for a two-column layout.
 func (m HelpModal) singleColumn() bool {
 	return m.width < 60
@@ -31,16 +134,16 @@ func (m HelpModal) singleColumn() bool {
 // contentRowCount returns the number of content rows (title + key rows) in the
 // help panel. This is used by both Height() and View() to stay consistent.
 func (m HelpModal) contentRowCount() int {
+	left, right := helpColumns()
+	leftCount := sectionRowCount(left)
+	rightCount := sectionRowCount(right)
+
 	if m.singleColumn() {
-		// Single-column: all entries stacked vertically.
-		// Nav(1) + 6 + blank + Expand(1) + 3 + blank + Actions(1) + 5 + blank + Clipboard(1) + 3 + blank + General(1) + 2 = 26
-		return 26 + 1 // +1 title
+		// Single-column: left sections, a blank row, then right sections.
+		return leftCount + 1 + rightCount + 1 // +1 title
 	}
 
 	// Two-column layout.
-	leftCount := 11
-	rightCount := 15
-
 	rows := leftCount
 	if rightCount > rows {
 		rows = rightCount
@@ -120,36 +223,23 @@ func (m HelpModal) View() string {
 		return bgStyle.Render(" ") + keyStyle.Render(key) + descStyle.Render(desc)
 	}
 
-	var leftLines []string
-	leftLines = append(leftLines, sectionStyle.Render("Navigation"))
-	leftLines = append(leftLines, makeRow("j / Down", "Move down"))
-	leftLines = append(leftLines, makeRow("k / Up", "Move up"))
-	leftLines = append(leftLines, makeRow("g / Home", "Go to top"))
-	leftLines = append(leftLines, makeRow("G / End", "Go to bottom"))
-	leftLines = append(leftLines, makeRow("Ctrl+D", "Page down"))
-	leftLines = append(leftLines, makeRow("Ctrl+U", "Page up"))
-	leftLines = append(leftLines, bgStyle.Render(""))
-	leftLines = append(leftLines, sectionStyle.Render("Expand / Collapse"))
-	leftLines = append(leftLines, makeRow("Enter", "Expand / toggle diff"))
-	leftLines = append(leftLines, makeRow("Esc", "Collapse"))
-	leftLines = append(leftLines, makeRow("j / k", "Navigate files"))
-
-	var rightLines []string
-	rightLines = append(rightLines, sectionStyle.Render("Actions"))
-	rightLines = append(rightLines, makeRow("c", "Commit"))
-	rightLines = append(rightLines, makeRow("p", "Push"))
-	rightLines = append(rightLines, makeRow("P", "Pull"))
-	rightLines = append(rightLines, makeRow("f", "Fetch"))
-	rightLines = append(rightLines, makeRow("b", "Switch branch"))
-	rightLines = append(rightLines, bgStyle.Render(""))
-	rightLines = append(rightLines, sectionStyle.Render("Clipboard"))
-	rightLines = append(rightLines, makeRow("y", "Copy hash"))
-	rightLines = append(rightLines, makeRow("Y", "Copy message"))
-	rightLines = append(rightLines, makeRow("Ctrl+Y", "Copy diff"))
-	rightLines = append(rightLines, bgStyle.Render(""))
-	rightLines = append(rightLines, sectionStyle.Render("General"))
-	rightLines = append(rightLines, makeRow("?", "Toggle help"))
-	rightLines = append(rightLines, makeRow("q", "Quit"))
+	renderSections := func(sections []helpSection) []string {
+		var lines []string
+		for i, sec := range sections {
+			if i > 0 {
+				lines = append(lines, bgStyle.Render(""))
+			}
+			lines = append(lines, sectionStyle.Render(sec.title))
+			for _, e := range sec.entries {
+				lines = append(lines, makeRow(e.key, e.desc))
+			}
+		}
+		return lines
+	}
+
+	left, right := helpColumns()
+	leftLines := renderSections(left)
+	rightLines := renderSections(right)
 
 	// Title row: adapt hint text for narrow widths.
 	hintText := "? to close"
@@ -0,0 +1,170 @@
+package modals
+
+import (
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// InputModal is a single-line inline prompt, e.g. for a new commit subject
+// or branch name. The caller decides what the value is for.
+type InputModal struct {
+	input   textinput.Model
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	label   string
+}
+
+func NewInputModal(s *styles.Styles) InputModal {
+	ti := textinput.New()
+	ti.CharLimit = 500
+	ti.Width = 60
+
+	panelBg := s.Theme.BackgroundPanel
+	ti.PromptStyle = lipgloss.NewStyle().
+		Foreground(s.Theme.BranchFeature).
+		Background(panelBg).
+		Bold(true)
+	ti.TextStyle = lipgloss.NewStyle().
+		Foreground(s.Theme.Foreground).
+		Background(panelBg)
+	ti.PlaceholderStyle = lipgloss.NewStyle().
+		Foreground(s.Theme.DiffContext).
+		Background(panelBg)
+	ti.Cursor.Style = lipgloss.NewStyle().
+		Background(s.Theme.Foreground)
+	ti.Prompt = "  "
+
+	return InputModal{
+		input:   ti,
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+func (m InputModal) Update(msg tea.Msg) (InputModal, tea.Cmd) {
+	if !m.visible {
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m InputModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	return 3 // border top + input line + border bottom
+}
+
+// View renders the inline prompt.
+func (m InputModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	bgStyle := lipgloss.NewStyle().Background(panelBg)
+
+	labelStyle := lipgloss.NewStyle().
+		Foreground(theme.BranchFeature).
+		Background(panelBg).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.DiffContext).
+		Background(panelBg).
+		Italic(true)
+
+	label := labelStyle.Render(" " + m.label)
+	tiView := m.input.View()
+
+	labelWidth := lipgloss.Width(label)
+	tiWidth := lipgloss.Width(tiView)
+	innerAvail := m.width - 4
+
+	hintText := "  Enter to confirm | Esc to cancel"
+	used := labelWidth + 1 + tiWidth + lipgloss.Width(hintText)
+	if used > innerAvail {
+		hintText = "  Enter | Esc"
+		used = labelWidth + 1 + tiWidth + lipgloss.Width(hintText)
+		if used > innerAvail {
+			hintText = ""
+		}
+	}
+
+	var hint string
+	if hintText != "" {
+		hint = hintStyle.Render(hintText)
+	}
+
+	innerContent := label + bgStyle.Render(" ") + tiView + hint
+
+	visWidth := lipgloss.Width(innerContent)
+	if visWidth < m.width-2 {
+		innerContent = innerContent + bgStyle.Width(m.width-2-visWidth).Render("")
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(theme.BranchFeature).
+		BorderBackground(theme.Background).
+		Background(panelBg).
+		Width(m.width - 2).
+		Render(innerContent)
+}
+
+// Show opens the prompt with a label (e.g. "Reword:") and an initial value.
+func (m *InputModal) Show(label, value string) {
+	m.visible = true
+	m.label = label
+	m.input.Placeholder = ""
+	m.input.SetValue(value)
+	m.input.CursorEnd()
+	m.input.Focus()
+	m.resizeInput()
+}
+
+// SetPlaceholder sets the text shown while the input is empty.
+func (m *InputModal) SetPlaceholder(placeholder string) {
+	m.input.Placeholder = placeholder
+}
+
+func (m *InputModal) Hide() {
+	m.visible = false
+	m.input.Blur()
+}
+
+func (m *InputModal) IsVisible() bool {
+	return m.visible
+}
+
+func (m *InputModal) Value() string {
+	return m.input.Value()
+}
+
+func (m *InputModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	m.resizeInput()
+}
+
+// resizeInput gives the text input the space left after the label.
+func (m *InputModal) resizeInput() {
+	tiWidth := m.width - lipgloss.Width(m.label) - 8 // label + borders + small pad
+	if tiWidth < 10 {
+		tiWidth = 10
+	}
+	if tiWidth > 80 {
+		tiWidth = 80
+	}
+	m.input.Width = tiWidth
+}
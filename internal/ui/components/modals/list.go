@@ -0,0 +1,102 @@
+package modals
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// maxListRows caps how many rows a list panel shows at once.
+const maxListRows = 10
+
+// listRows returns the number of item rows a list panel of n items shows,
+// capped to maxListRows and to the terminal height.
+func listRows(n, termHeight int) int {
+	rows := n
+	if rows > maxListRows {
+		rows = maxListRows
+	}
+	if maxH := termHeight - 8; rows > maxH {
+		rows = maxH
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// scrollWindow returns the [start, end) range of a list of total items to
+// show in rows rows so that cursor stays visible.
+func scrollWindow(cursor, total, rows int) (int, int) {
+	start := 0
+	if cursor >= rows {
+		start = cursor - rows + 1
+	}
+	end := start + rows
+	if end > total {
+		end = total
+		start = end - rows
+		if start < 0 {
+			start = 0
+		}
+	}
+	return start, end
+}
+
+// titleRow renders a panel title with the longest of hints that fits on the
+// right, dropping the hint entirely if none does.
+func titleRow(theme styles.Theme, innerWidth int, title string, hints ...string) string {
+	panelBg := theme.BackgroundPanel
+	bgStyle := lipgloss.NewStyle().Background(panelBg)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Foreground).
+		Background(panelBg).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.DiffContext).
+		Background(panelBg).
+		Italic(true)
+
+	titleText := " " + title
+	for _, hint := range hints {
+		gap := innerWidth - lipgloss.Width(titleText) - lipgloss.Width(hint)
+		if gap >= 1 {
+			return titleStyle.Render(titleText) + bgStyle.Width(gap).Render("") + hintStyle.Render(hint)
+		}
+	}
+	gap := innerWidth - lipgloss.Width(titleText)
+	if gap < 0 {
+		titleText = truncateRunes(titleText, innerWidth)
+		gap = 0
+	}
+	return titleStyle.Render(titleText) + bgStyle.Width(gap).Render("")
+}
+
+// padRow pads a rendered row with bg to innerWidth.
+func padRow(row string, innerWidth int, bg lipgloss.Color) string {
+	if w := lipgloss.Width(row); w < innerWidth {
+		row += lipgloss.NewStyle().Background(bg).Width(innerWidth - w).Render("")
+	}
+	return lipgloss.NewStyle().Background(bg).Width(innerWidth).Render(row)
+}
+
+// panel wraps rows in the rounded border used by all inline panels.
+func panel(theme styles.Theme, width int, border lipgloss.Color, rows []string) string {
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(border).
+		BorderBackground(theme.Background).
+		Background(theme.BackgroundPanel).
+		Width(width - 2).
+		Render(strings.Join(rows, "\n"))
+}
+
+// innerWidthFor returns the usable row width inside a panel of width.
+func innerWidthFor(width int) int {
+	w := width - 4
+	if w < 20 {
+		w = 20
+	}
+	return w
+}
@@ -0,0 +1,147 @@
+package modals
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// MenuItem is one choice in a MenuModal. Key, if set, selects the item
+// directly.
+type MenuItem struct {
+	Key         string
+	Label       string
+	Description string
+}
+
+// MenuModal is an inline list of choices, e.g. which parent to revert a
+// merge against. The caller decides what the chosen item means.
+type MenuModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	title   string
+	items   []MenuItem
+	cursor  int
+}
+
+func NewMenuModal(s *styles.Styles) MenuModal {
+	return MenuModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m MenuModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	return listRows(len(m.items), m.height) + 3 // border(2) + title(1) + rows
+}
+
+// View renders the inline menu.
+func (m MenuModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, m.title,
+		"Enter to select | Esc to cancel", "Enter | Esc")}
+
+	keyWidth := 0
+	for _, item := range m.items {
+		if w := lipgloss.Width(item.Key); w > keyWidth {
+			keyWidth = w
+		}
+	}
+
+	start, end := scrollWindow(m.cursor, len(m.items), listRows(len(m.items), m.height))
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		keyStyle := lipgloss.NewStyle().Foreground(theme.BranchFeature).Background(bg).Bold(true)
+		labelStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Background(bg)
+		descStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+
+		row := rowBg.Render("  ")
+		if keyWidth > 0 {
+			row += keyStyle.Width(keyWidth).Render(item.Key) + rowBg.Render("  ")
+		}
+		row += labelStyle.Render(item.Label)
+		if item.Description != "" {
+			avail := innerWidth - lipgloss.Width(row) - 2
+			if avail > 3 {
+				row += rowBg.Render("  ") + descStyle.Render(truncateRunes(item.Description, avail))
+			}
+		}
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	return panel(theme, m.width, theme.BranchFeature, rows)
+}
+
+// Show opens the menu with a title and items, selecting the first item.
+func (m *MenuModal) Show(title string, items []MenuItem) {
+	m.visible = true
+	m.title = title
+	m.items = items
+	m.cursor = 0
+}
+
+func (m *MenuModal) Hide() {
+	m.visible = false
+	m.items = nil
+	m.cursor = 0
+}
+
+func (m *MenuModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *MenuModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *MenuModal) MoveDown() {
+	if m.cursor < len(m.items)-1 {
+		m.cursor++
+	}
+}
+
+// Selected returns the index of the highlighted item, or -1 if empty.
+func (m *MenuModal) Selected() int {
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		return m.cursor
+	}
+	return -1
+}
+
+// IndexForKey returns the index of the item bound to key, or -1.
+func (m *MenuModal) IndexForKey(key string) int {
+	for i, item := range m.items {
+		if item.Key != "" && item.Key == key {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *MenuModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
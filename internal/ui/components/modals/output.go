@@ -0,0 +1,182 @@
+package modals
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// maxOutputRows caps how many output lines are visible at once.
+const maxOutputRows = 12
+
+// OutputModal is an inline, scrollable panel that shows the full output of a
+// git command, e.g. the stderr of a failed push.
+type OutputModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	title   string
+	lines   []string
+	offset  int
+}
+
+func NewOutputModal(s *styles.Styles) OutputModal {
+	return OutputModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// visibleRows returns the number of output rows shown, capped to the panel
+// limit and to the terminal height.
+func (m OutputModal) visibleRows() int {
+	rows := len(m.lines)
+	if rows > maxOutputRows {
+		rows = maxOutputRows
+	}
+	if maxH := m.height - 8; rows > maxH {
+		rows = maxH
+	}
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m OutputModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	return m.visibleRows() + 3 // border(2) + title(1) + output rows
+}
+
+// View renders the inline output panel.
+func (m OutputModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+
+	bgStyle := lipgloss.NewStyle().Background(panelBg)
+	titleStyle := lipgloss.NewStyle().
+		Foreground(theme.Foreground).
+		Background(panelBg).
+		Bold(true)
+	hintStyle := lipgloss.NewStyle().
+		Foreground(theme.DiffContext).
+		Background(panelBg).
+		Italic(true)
+	lineStyle := lipgloss.NewStyle().
+		Foreground(theme.Subtext).
+		Background(panelBg)
+
+	innerWidth := m.width - 4
+	if innerWidth < 20 {
+		innerWidth = 20
+	}
+
+	titleText := " " + m.title
+	hintText := "j/k to scroll | Esc to close"
+	if len(m.lines) <= m.visibleRows() {
+		hintText = "Esc to close"
+	}
+	hintRendered := hintStyle.Render(hintText)
+	titleGap := innerWidth - lipgloss.Width(titleText) - lipgloss.Width(hintText)
+	if titleGap < 1 {
+		hintRendered = ""
+		titleGap = innerWidth - lipgloss.Width(titleText)
+		if titleGap < 0 {
+			titleText = truncateRunes(titleText, innerWidth)
+			titleGap = 0
+		}
+	}
+	titleRow := titleStyle.Render(titleText) + bgStyle.Width(titleGap).Render("") + hintRendered
+
+	rows := []string{titleRow}
+	end := m.offset + m.visibleRows()
+	if end > len(m.lines) {
+		end = len(m.lines)
+	}
+	for _, line := range m.lines[m.offset:end] {
+		rows = append(rows, lineStyle.Width(innerWidth).Render(truncateRunes("  "+line, innerWidth)))
+	}
+	if len(m.lines) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(panelBg).Italic(true)
+		rows = append(rows, emptyStyle.Render("  (no output)"))
+	}
+
+	return lipgloss.NewStyle().
+		BorderStyle(lipgloss.RoundedBorder()).
+		BorderForeground(theme.DiffRemove).
+		BorderBackground(theme.Background).
+		Background(panelBg).
+		Width(m.width - 2).
+		Render(strings.Join(rows, "\n"))
+}
+
+// Show opens the panel with the given title and output text.
+func (m *OutputModal) Show(title, output string) {
+	m.visible = true
+	m.title = title
+	m.offset = 0
+	m.lines = nil
+	output = strings.TrimRight(output, "\n")
+	if output != "" {
+		m.lines = strings.Split(output, "\n")
+	}
+}
+
+// Append adds a line of output, e.g. from a command still running. The
+// panel follows new lines unless it has been scrolled up.
+func (m *OutputModal) Append(line string) {
+	follow := m.offset >= len(m.lines)-m.visibleRows()
+	m.lines = append(m.lines, line)
+	if follow && len(m.lines) > m.visibleRows() {
+		m.offset = len(m.lines) - m.visibleRows()
+	}
+}
+
+func (m *OutputModal) Hide() {
+	m.visible = false
+	m.lines = nil
+	m.offset = 0
+}
+
+func (m *OutputModal) IsVisible() bool {
+	return m.visible
+}
+
+// ScrollUp scrolls the output up by one line.
+func (m *OutputModal) ScrollUp() {
+	if m.offset > 0 {
+		m.offset--
+	}
+}
+
+// ScrollDown scrolls the output down by one line.
+func (m *OutputModal) ScrollDown() {
+	if m.offset < len(m.lines)-m.visibleRows() {
+		m.offset++
+	}
+}
+
+func (m *OutputModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// truncateRunes cuts s to at most n runes, appending "…" if it was cut.
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n && n > 1 {
+		return string(runes[:n-1]) + "…"
+	}
+	return s
+}
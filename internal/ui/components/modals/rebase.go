@@ -0,0 +1,222 @@
+package modals
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// RebaseModal is the inline interactive rebase editor. It lists the commits
+// being rewritten newest first, as in the graph, and lets the user reorder
+// them and choose an action for each.
+type RebaseModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	base    *git.Commit
+	items   []git.RebaseTodoItem // newest first
+	cursor  int
+}
+
+func NewRebaseModal(s *styles.Styles) RebaseModal {
+	return RebaseModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m RebaseModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	return listRows(len(m.items), m.height) + 3 // border(2) + title(1) + rows
+}
+
+// View renders the inline rebase editor.
+func (m RebaseModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	title := fmt.Sprintf("Rebase %d commits", len(m.items))
+	rows := []string{titleRow(theme, innerWidth, title,
+		"j/k select | J/K move | p r e s f d action | Enter start | Esc cancel",
+		"p r e s f d | Enter start | Esc cancel",
+		"Enter | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.items), listRows(len(m.items), m.height))
+	for i := start; i < end; i++ {
+		item := m.items[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+
+		actionStyle := lipgloss.NewStyle().Background(bg).Bold(true).Foreground(m.actionColor(item.Action))
+		hashStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		subjectStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Background(bg)
+		rowBg := lipgloss.NewStyle().Background(bg)
+
+		subject := item.Commit.Subject
+		switch item.Action {
+		case git.RebaseReword:
+			if item.Message != "" {
+				subject = firstLine(item.Message)
+			}
+			subjectStyle = subjectStyle.Foreground(theme.Tag)
+		case git.RebaseDrop:
+			subjectStyle = subjectStyle.Foreground(theme.DiffContext).Strikethrough(true)
+		case git.RebaseSquash, git.RebaseFixup:
+			subject = "↳ " + subject
+		}
+
+		// Reserve: indent(2) + action(7) + hash(7) + spaces(2).
+		subjectAvail := innerWidth - 18
+		if subjectAvail < 6 {
+			subjectAvail = 6
+		}
+
+		row := rowBg.Render("  ") +
+			actionStyle.Render(fmt.Sprintf("%-7s", item.Action)) +
+			hashStyle.Render(item.Commit.ShortHash) + rowBg.Render("  ") +
+			subjectStyle.Render(truncateRunes(subject, subjectAvail))
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	return panel(theme, m.width, theme.Head, rows)
+}
+
+func (m RebaseModal) actionColor(action git.RebaseAction) lipgloss.Color {
+	theme := m.styles.Theme
+	switch action {
+	case git.RebaseReword:
+		return theme.Tag
+	case git.RebaseEdit:
+		return theme.Head
+	case git.RebaseSquash, git.RebaseFixup:
+		return theme.BranchFeature
+	case git.RebaseDrop:
+		return theme.DiffRemove
+	}
+	return theme.BranchMain
+}
+
+// Show opens the editor for commits listed oldest first, as returned by
+// Repository.RebaseCommits, with every commit picked.
+func (m *RebaseModal) Show(commits []*git.Commit) {
+	m.visible = true
+	m.items = make([]git.RebaseTodoItem, len(commits))
+	for i, c := range commits {
+		m.items[len(commits)-1-i] = git.RebaseTodoItem{Action: git.RebasePick, Commit: c}
+	}
+	m.base = nil
+	if len(commits) > 0 {
+		m.base = commits[0]
+	}
+	m.cursor = 0
+}
+
+func (m *RebaseModal) Hide() {
+	m.visible = false
+	m.items = nil
+	m.base = nil
+	m.cursor = 0
+}
+
+func (m *RebaseModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *RebaseModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *RebaseModal) MoveDown() {
+	if m.cursor < len(m.items)-1 {
+		m.cursor++
+	}
+}
+
+// MoveItemUp swaps the selected commit with the one above it, making it
+// newer, and keeps it selected.
+func (m *RebaseModal) MoveItemUp() {
+	if m.cursor > 0 {
+		m.items[m.cursor], m.items[m.cursor-1] = m.items[m.cursor-1], m.items[m.cursor]
+		m.cursor--
+	}
+}
+
+// MoveItemDown swaps the selected commit with the one below it, making it
+// older, and keeps it selected.
+func (m *RebaseModal) MoveItemDown() {
+	if m.cursor < len(m.items)-1 {
+		m.items[m.cursor], m.items[m.cursor+1] = m.items[m.cursor+1], m.items[m.cursor]
+		m.cursor++
+	}
+}
+
+// SetAction sets the action of the selected commit.
+func (m *RebaseModal) SetAction(action git.RebaseAction) {
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		m.items[m.cursor].Action = action
+	}
+}
+
+// SetReword marks the selected commit for rewording with message.
+func (m *RebaseModal) SetReword(message string) {
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		m.items[m.cursor].Action = git.RebaseReword
+		m.items[m.cursor].Message = message
+	}
+}
+
+// SelectedItem returns the highlighted todo item, or nil.
+func (m *RebaseModal) SelectedItem() *git.RebaseTodoItem {
+	if m.cursor >= 0 && m.cursor < len(m.items) {
+		return &m.items[m.cursor]
+	}
+	return nil
+}
+
+// Base returns the oldest commit being rewritten; the rebase starts from it.
+func (m *RebaseModal) Base() *git.Commit {
+	return m.base
+}
+
+// Todo returns the plan oldest first, as git expects it.
+func (m *RebaseModal) Todo() []git.RebaseTodoItem {
+	todo := make([]git.RebaseTodoItem, len(m.items))
+	for i, item := range m.items {
+		todo[len(m.items)-1-i] = item
+	}
+	return todo
+}
+
+func (m *RebaseModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// firstLine returns s up to its first newline.
+func firstLine(s string) string {
+	for i, r := range s {
+		if r == '\n' {
+			return s[:i]
+		}
+	}
+	return s
+}
@@ -0,0 +1,149 @@
+package modals
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// ReflogModal is an inline panel listing the reflog of HEAD or a branch:
+// where each entry moved it from and to, how, and when.
+type ReflogModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	ref     string
+	entries []git.ReflogEntry
+	cursor  int
+}
+
+func NewReflogModal(s *styles.Styles) ReflogModal {
+	return ReflogModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m ReflogModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	// border(2) + title(1) + entry rows
+	return listRows(len(m.entries), m.height) + 3
+}
+
+// View renders the inline reflog.
+func (m ReflogModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, "Reflog of "+m.ref,
+		"Enter check out | X reset | Esc close",
+		"Enter | X | Esc")}
+
+	if len(m.entries) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(panelBg).Italic(true)
+		rows = append(rows, padRow(emptyStyle.Render("  No reflog entries"), innerWidth, panelBg))
+	}
+
+	start, end := scrollWindow(m.cursor, len(m.entries), listRows(len(m.entries), m.height))
+	for i := start; i < end; i++ {
+		e := m.entries[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		selectorStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+		hashStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		actionStyle := lipgloss.NewStyle().Foreground(theme.BranchFeature).Background(bg).Bold(true)
+		messageStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Background(bg)
+
+		old := "·······"
+		if e.Old != "" {
+			old = shortHash(e.Old)
+		}
+		date := e.Date.Format("2006-01-02 15:04")
+
+		row := rowBg.Render("  ") + selectorStyle.Render(e.Selector) + rowBg.Render("  ") +
+			hashStyle.Render(old) + selectorStyle.Render(" → ") + hashStyle.Render(shortHash(e.New)) +
+			rowBg.Render("  ") + actionStyle.Render(truncateRunes(e.Action, innerWidth/4))
+		if avail := innerWidth - lipgloss.Width(row) - len(date) - 4; avail > 3 && e.Message != "" {
+			row += rowBg.Render("  ") + messageStyle.Render(truncateRunes(e.Message, avail))
+		}
+		if gap := innerWidth - lipgloss.Width(row) - len(date) - 1; gap >= 1 {
+			row += rowBg.Width(gap).Render("") + selectorStyle.Render(date)
+		}
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	return panel(theme, m.width, theme.BranchFeature, rows)
+}
+
+// Show opens the panel with the reflog of ref, keeping the cursor in range
+// when the list is refreshed.
+func (m *ReflogModal) Show(ref string, entries []git.ReflogEntry) {
+	if ref != m.ref {
+		m.cursor = 0
+	}
+	m.visible = true
+	m.ref = ref
+	m.entries = entries
+	if m.cursor >= len(entries) {
+		m.cursor = len(entries) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *ReflogModal) Hide() {
+	m.visible = false
+	m.entries = nil
+	m.cursor = 0
+}
+
+func (m *ReflogModal) IsVisible() bool {
+	return m.visible
+}
+
+// Ref returns the ref whose reflog is shown.
+func (m ReflogModal) Ref() string {
+	return m.ref
+}
+
+// MoveUp moves the cursor up.
+func (m *ReflogModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *ReflogModal) MoveDown() {
+	if m.cursor < len(m.entries)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedEntry returns the highlighted entry, or nil.
+func (m ReflogModal) SelectedEntry() *git.ReflogEntry {
+	if m.cursor >= 0 && m.cursor < len(m.entries) {
+		return &m.entries[m.cursor]
+	}
+	return nil
+}
+
+func (m *ReflogModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
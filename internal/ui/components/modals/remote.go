@@ -0,0 +1,131 @@
+package modals
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// RemoteModal is an inline panel listing the configured remotes and their
+// URLs.
+type RemoteModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	remotes []git.Remote
+	cursor  int
+}
+
+func NewRemoteModal(s *styles.Styles) RemoteModal {
+	return RemoteModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m RemoteModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	// border(2) + title(1) + remote rows
+	return listRows(len(m.remotes), m.height) + 3
+}
+
+// View renders the inline remote list.
+func (m RemoteModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, "Remotes",
+		"f fetch | F fetch+prune | p push | a add | r rename | e edit URL | d remove | Esc close",
+		"f | F | p | a | r | e | d | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.remotes), listRows(len(m.remotes), m.height))
+	for i := start; i < end; i++ {
+		remote := m.remotes[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		nameStyle := lipgloss.NewStyle().Foreground(theme.BranchFeature).Background(bg).Bold(true)
+		urlStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+
+		url := remote.FetchURL
+		if remote.PushURL != remote.FetchURL {
+			url += " (push: " + remote.PushURL + ")"
+		}
+		name := truncateRunes(remote.Name, innerWidth/3)
+		row := rowBg.Render("  ") + nameStyle.Render(name)
+		if avail := innerWidth - lipgloss.Width(row) - 2; avail > 3 {
+			row += rowBg.Render("  ") + urlStyle.Render(truncateRunes(url, avail))
+		}
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	if len(m.remotes) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(panelBg).Italic(true)
+		rows = append(rows, padRow(emptyStyle.Render("  No remotes (a to add one)"), innerWidth, panelBg))
+	}
+
+	return panel(theme, m.width, theme.BranchFeature, rows)
+}
+
+// Show opens the panel with the given remotes, keeping the cursor in range
+// when the list is refreshed.
+func (m *RemoteModal) Show(remotes []git.Remote) {
+	m.visible = true
+	m.remotes = remotes
+	if m.cursor >= len(remotes) {
+		m.cursor = len(remotes) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *RemoteModal) Hide() {
+	m.visible = false
+	m.remotes = nil
+	m.cursor = 0
+}
+
+func (m *RemoteModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *RemoteModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *RemoteModal) MoveDown() {
+	if m.cursor < len(m.remotes)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedRemote returns the highlighted remote, or nil.
+func (m RemoteModal) SelectedRemote() *git.Remote {
+	if m.cursor >= 0 && m.cursor < len(m.remotes) {
+		return &m.remotes[m.cursor]
+	}
+	return nil
+}
+
+func (m *RemoteModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
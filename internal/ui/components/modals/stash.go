@@ -0,0 +1,124 @@
+package modals
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// StashModal is an inline panel listing the stashes, newest first.
+type StashModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	stashes []git.Stash
+	cursor  int
+}
+
+func NewStashModal(s *styles.Styles) StashModal {
+	return StashModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m StashModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	return listRows(len(m.stashes), m.height) + 3 // border(2) + title(1) + rows
+}
+
+// View renders the inline stash list.
+func (m StashModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, "Stashes",
+		"a apply | p pop | d drop | b branch | Enter show | Esc close",
+		"a | p | d | b | Enter | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.stashes), listRows(len(m.stashes), m.height))
+	for i := start; i < end; i++ {
+		s := m.stashes[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		nameStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		msgStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Background(bg)
+
+		row := rowBg.Render("  ") + nameStyle.Render(s.Name) + rowBg.Render("  ")
+		if avail := innerWidth - lipgloss.Width(row); avail > 3 {
+			row += msgStyle.Render(truncateRunes(s.Message, avail))
+		}
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	if len(m.stashes) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(panelBg).Italic(true)
+		rows = append(rows, padRow(emptyStyle.Render("  No stashes (s to stash your changes)"), innerWidth, panelBg))
+	}
+
+	return panel(theme, m.width, theme.Subtext, rows)
+}
+
+// Show opens the panel with the given stashes, keeping the cursor in range
+// when the list is refreshed.
+func (m *StashModal) Show(stashes []git.Stash) {
+	m.visible = true
+	m.stashes = stashes
+	if m.cursor >= len(stashes) {
+		m.cursor = len(stashes) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *StashModal) Hide() {
+	m.visible = false
+	m.stashes = nil
+	m.cursor = 0
+}
+
+func (m *StashModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *StashModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *StashModal) MoveDown() {
+	if m.cursor < len(m.stashes)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedStash returns the highlighted stash, or nil.
+func (m *StashModal) SelectedStash() *git.Stash {
+	if m.cursor >= 0 && m.cursor < len(m.stashes) {
+		return &m.stashes[m.cursor]
+	}
+	return nil
+}
+
+func (m *StashModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,138 @@
+package modals
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// SubmoduleModal is an inline panel listing the repository's submodules
+// and the state of their checkouts.
+type SubmoduleModal struct {
+	styles     *styles.Styles
+	visible    bool
+	width      int
+	height     int
+	submodules []git.Submodule
+	cursor     int
+}
+
+func NewSubmoduleModal(s *styles.Styles) SubmoduleModal {
+	return SubmoduleModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m SubmoduleModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	// border(2) + title(1) + submodule rows
+	return listRows(len(m.submodules), m.height) + 3
+}
+
+// View renders the inline submodule list.
+func (m SubmoduleModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, "Submodules",
+		"Enter open | u update | i init | s sync | Esc close",
+		"Enter | u | i | s | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.submodules), listRows(len(m.submodules), m.height))
+	for i := start; i < end; i++ {
+		sm := m.submodules[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		pathStyle := lipgloss.NewStyle().Foreground(theme.BranchMain).Background(bg).Bold(true)
+		hashStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		urlStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+		noteStyle := urlStyle.Italic(true)
+
+		// ✓ up to date, + checkout differs, ! conflict, - not initialized.
+		mark, markColor := "✓ ", theme.DiffAdd
+		note := ""
+		switch {
+		case sm.Conflict:
+			mark, markColor, note = "! ", theme.DiffRemove, " conflict"
+		case !sm.Initialized:
+			mark, markColor, note = "- ", theme.Subtext, " not initialized"
+		case sm.OutOfDate:
+			mark, markColor, note = "+ ", theme.Tag, " checkout differs"
+		}
+		prefix := lipgloss.NewStyle().Foreground(markColor).Background(bg).Render(mark)
+
+		row := prefix + pathStyle.Render(truncateRunes(sm.Path, innerWidth/3)) +
+			rowBg.Render(" ") + hashStyle.Render(shortHash(sm.Hash))
+		if avail := innerWidth - lipgloss.Width(row) - len(note) - 2; avail > 3 && sm.URL != "" {
+			row += rowBg.Render("  ") + urlStyle.Render(truncateRunes(sm.URL, avail))
+		}
+		row += noteStyle.Render(note)
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	return panel(theme, m.width, theme.BranchMain, rows)
+}
+
+// Show opens the panel with the given submodules, keeping the cursor in
+// range when the list is refreshed.
+func (m *SubmoduleModal) Show(submodules []git.Submodule) {
+	m.visible = true
+	m.submodules = submodules
+	if m.cursor >= len(submodules) {
+		m.cursor = len(submodules) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *SubmoduleModal) Hide() {
+	m.visible = false
+	m.submodules = nil
+	m.cursor = 0
+}
+
+func (m *SubmoduleModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *SubmoduleModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *SubmoduleModal) MoveDown() {
+	if m.cursor < len(m.submodules)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedSubmodule returns the highlighted submodule, or nil.
+func (m SubmoduleModal) SelectedSubmodule() *git.Submodule {
+	if m.cursor >= 0 && m.cursor < len(m.submodules) {
+		return &m.submodules[m.cursor]
+	}
+	return nil
+}
+
+func (m *SubmoduleModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -0,0 +1,171 @@
+package modals
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// maxTagMessageRows caps how many annotation lines the details show.
+const maxTagMessageRows = 4
+
+// TagModal is an inline panel listing tags, with the tagger, date and
+// annotation of the tag under the cursor.
+type TagModal struct {
+	styles  *styles.Styles
+	visible bool
+	width   int
+	height  int
+	tags    []git.Tag
+	cursor  int
+}
+
+func NewTagModal(s *styles.Styles) TagModal {
+	return TagModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m TagModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	// border(2) + title(1) + tag rows + separator(1) + details
+	return listRows(len(m.tags), m.height) + 4 + len(m.detailLines())
+}
+
+// detailLines returns the unstyled details of the selected tag.
+func (m TagModal) detailLines() []string {
+	tag := m.SelectedTag()
+	if tag == nil {
+		return nil
+	}
+	if !tag.Annotated {
+		return []string{"Lightweight tag on " + shortHash(tag.Hash)}
+	}
+	lines := []string{"Tagger: " + tag.Tagger + " <" + tag.TaggerEmail + ">  " +
+		tag.Date.Format("2006-01-02 15:04:05")}
+	if tag.Message != "" {
+		msg := strings.Split(tag.Message, "\n")
+		if len(msg) > maxTagMessageRows {
+			msg = append(msg[:maxTagMessageRows-1], "…")
+		}
+		lines = append(lines, msg...)
+	}
+	return lines
+}
+
+// View renders the inline tag list.
+func (m TagModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, "Tags",
+		"n new | d delete | p push | P push all | Esc close",
+		"n | d | p | P | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.tags), listRows(len(m.tags), m.height))
+	for i := start; i < end; i++ {
+		tag := m.tags[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		nameStyle := lipgloss.NewStyle().Foreground(theme.Tag).Background(bg).Bold(true)
+		hashStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		subjectStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+
+		name := truncateRunes(tag.Name, innerWidth/2)
+		row := rowBg.Render("  ") + nameStyle.Render(name) + rowBg.Render(" ") + hashStyle.Render(shortHash(tag.Hash))
+		if subject := firstLine(tag.Message); subject != "" {
+			if avail := innerWidth - lipgloss.Width(row) - 2; avail > 3 {
+				row += rowBg.Render("  ") + subjectStyle.Render(truncateRunes(subject, avail))
+			}
+		}
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	if len(m.tags) == 0 {
+		emptyStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(panelBg).Italic(true)
+		rows = append(rows, padRow(emptyStyle.Render("  No tags (n to tag the selected commit)"), innerWidth, panelBg))
+	}
+
+	rows = append(rows, lipgloss.NewStyle().Foreground(theme.Border).Background(panelBg).
+		Render(strings.Repeat("─", innerWidth)))
+	detailStyle := lipgloss.NewStyle().Foreground(theme.Foreground).Background(panelBg)
+	for _, line := range m.detailLines() {
+		rows = append(rows, detailStyle.Width(innerWidth).Render(truncateRunes("  "+line, innerWidth)))
+	}
+
+	return panel(theme, m.width, theme.Tag, rows)
+}
+
+// Show opens the panel with the given tags, keeping the cursor in range
+// when the list is refreshed.
+func (m *TagModal) Show(tags []git.Tag) {
+	m.visible = true
+	m.tags = tags
+	if m.cursor >= len(tags) {
+		m.cursor = len(tags) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *TagModal) Hide() {
+	m.visible = false
+	m.tags = nil
+	m.cursor = 0
+}
+
+func (m *TagModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *TagModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *TagModal) MoveDown() {
+	if m.cursor < len(m.tags)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedTag returns the highlighted tag, or nil.
+func (m TagModal) SelectedTag() *git.Tag {
+	if m.cursor >= 0 && m.cursor < len(m.tags) {
+		return &m.tags[m.cursor]
+	}
+	return nil
+}
+
+func (m *TagModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// shortHash abbreviates a full commit hash for display.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
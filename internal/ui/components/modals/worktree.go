@@ -0,0 +1,151 @@
+package modals
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/yourusername/lazygit-lite/internal/git"
+	"github.com/yourusername/lazygit-lite/internal/ui/styles"
+)
+
+// WorktreeModal is an inline panel listing the repository's worktrees and
+// what each has checked out.
+type WorktreeModal struct {
+	styles    *styles.Styles
+	visible   bool
+	width     int
+	height    int
+	worktrees []git.Worktree
+	cursor    int
+}
+
+func NewWorktreeModal(s *styles.Styles) WorktreeModal {
+	return WorktreeModal{
+		styles:  s,
+		visible: false,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Height returns the number of terminal rows this component occupies when visible.
+func (m WorktreeModal) Height() int {
+	if !m.visible {
+		return 0
+	}
+	// border(2) + title(1) + worktree rows
+	return listRows(len(m.worktrees), m.height) + 3
+}
+
+// View renders the inline worktree list.
+func (m WorktreeModal) View() string {
+	if !m.visible {
+		return ""
+	}
+
+	theme := m.styles.Theme
+	panelBg := theme.BackgroundPanel
+	innerWidth := innerWidthFor(m.width)
+
+	rows := []string{titleRow(theme, innerWidth, "Worktrees",
+		"Enter switch | n new | d remove | P prune | Esc close",
+		"Enter | n | d | P | Esc")}
+
+	start, end := scrollWindow(m.cursor, len(m.worktrees), listRows(len(m.worktrees), m.height))
+	for i := start; i < end; i++ {
+		w := m.worktrees[i]
+		bg := panelBg
+		if i == m.cursor {
+			bg = theme.Selection
+		}
+		rowBg := lipgloss.NewStyle().Background(bg)
+		currentStyle := lipgloss.NewStyle().Foreground(theme.Head).Background(bg)
+		branchStyle := lipgloss.NewStyle().Foreground(theme.BranchMain).Background(bg).Bold(true)
+		hashStyle := lipgloss.NewStyle().Foreground(theme.CommitHash).Background(bg)
+		pathStyle := lipgloss.NewStyle().Foreground(theme.Subtext).Background(bg)
+		noteStyle := pathStyle.Italic(true)
+
+		prefix := rowBg.Render("  ")
+		if w.IsCurrent {
+			prefix = currentStyle.Render("* ")
+		}
+
+		var head string
+		switch {
+		case w.Bare:
+			head = noteStyle.Render("(bare)")
+		case w.Branch != "":
+			head = branchStyle.Render(truncateRunes(w.Branch, innerWidth/3))
+		default:
+			head = hashStyle.Render(shortHash(w.Head)) + noteStyle.Render(" (detached)")
+		}
+
+		note := ""
+		if w.IsMain {
+			note += " main"
+		}
+		if w.Locked {
+			note += " locked"
+		}
+		if w.Prunable {
+			note += " prunable"
+		}
+
+		row := prefix + head
+		if avail := innerWidth - lipgloss.Width(row) - len(note) - 2; avail > 3 {
+			row += rowBg.Render("  ") + pathStyle.Render(truncateRunes(w.Path, avail))
+		}
+		row += noteStyle.Render(note)
+		rows = append(rows, padRow(row, innerWidth, bg))
+	}
+
+	return panel(theme, m.width, theme.BranchMain, rows)
+}
+
+// Show opens the panel with the given worktrees, keeping the cursor in
+// range when the list is refreshed.
+func (m *WorktreeModal) Show(worktrees []git.Worktree) {
+	m.visible = true
+	m.worktrees = worktrees
+	if m.cursor >= len(worktrees) {
+		m.cursor = len(worktrees) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+func (m *WorktreeModal) Hide() {
+	m.visible = false
+	m.worktrees = nil
+	m.cursor = 0
+}
+
+func (m *WorktreeModal) IsVisible() bool {
+	return m.visible
+}
+
+// MoveUp moves the cursor up.
+func (m *WorktreeModal) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *WorktreeModal) MoveDown() {
+	if m.cursor < len(m.worktrees)-1 {
+		m.cursor++
+	}
+}
+
+// SelectedWorktree returns the highlighted worktree, or nil.
+func (m WorktreeModal) SelectedWorktree() *git.Worktree {
+	if m.cursor >= 0 && m.cursor < len(m.worktrees) {
+		return &m.worktrees[m.cursor]
+	}
+	return nil
+}
+
+func (m *WorktreeModal) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
@@ -6,10 +6,13 @@ type KeyMap struct {
 	Quit        []string
 	Help        []string
 	Commit      []string
+	Amend       []string
+	Reword      []string
 	Push        []string
 	Pull        []string
 	Fetch       []string
 	Branch      []string
+	NewBranch   []string
 	Up          []string
 	Down        []string
 	Top         []string
@@ -20,6 +23,37 @@ type KeyMap struct {
 	CopyHash    []string
 	CopyMessage []string
 	CopyDiff    []string
+	Stage       []string
+	NextHunk    []string
+	PrevHunk    []string
+	NextLine    []string
+	PrevLine    []string
+	Output      []string
+	Rebase      []string
+	Continue    []string
+	Skip        []string
+	Abort       []string
+	Mark        []string
+	CherryPick  []string
+	Revert      []string
+	Reset       []string
+	Stash       []string
+	Stashes     []string
+	Tags        []string
+	Remotes     []string
+	Worktrees   []string
+	Submodules  []string
+	ParentRepo  []string
+	Merge       []string
+	Fixup       []string
+	Autosquash  []string
+	Conflicts   []string
+	Bisect      []string
+	Blame       []string
+	FileHistory []string
+	Reflog      []string
+	Undo        []string
+	Redo        []string
 }
 
 func DefaultKeyMap() KeyMap {
@@ -27,10 +61,13 @@ func DefaultKeyMap() KeyMap {
 		Quit:        []string{"q", "ctrl+c"},
 		Help:        []string{"?"},
 		Commit:      []string{"c"},
+		Amend:       []string{"A"},
+		Reword:      []string{"r"},
 		Push:        []string{"p"},
 		Pull:        []string{"P"},
 		Fetch:       []string{"f"},
 		Branch:      []string{"b"},
+		NewBranch:   []string{"n"},
 		Up:          []string{"k", "up"},
 		Down:        []string{"j", "down"},
 		Top:         []string{"g", "home"},
@@ -41,6 +78,37 @@ func DefaultKeyMap() KeyMap {
 		CopyHash:    []string{"y"},
 		CopyMessage: []string{"Y"},
 		CopyDiff:    []string{"ctrl+y"},
+		Stage:       []string{" "},
+		NextHunk:    []string{"tab"},
+		PrevHunk:    []string{"shift+tab"},
+		NextLine:    []string{"J"},
+		PrevLine:    []string{"K"},
+		Output:      []string{"o"},
+		Rebase:      []string{"i"},
+		Continue:    []string{"alt+c"},
+		Skip:        []string{"alt+s"},
+		Abort:       []string{"alt+a"},
+		Mark:        []string{"v"},
+		CherryPick:  []string{"C"},
+		Revert:      []string{"R"},
+		Reset:       []string{"X"},
+		Stash:       []string{"s"},
+		Stashes:     []string{"S"},
+		Tags:        []string{"t"},
+		Remotes:     []string{"M"},
+		Worktrees:   []string{"W"},
+		Submodules:  []string{"O"},
+		ParentRepo:  []string{"backspace"},
+		Merge:       []string{"m"},
+		Fixup:       []string{"F"},
+		Autosquash:  []string{"ctrl+f"},
+		Conflicts:   []string{"x"},
+		Bisect:      []string{"B"},
+		Blame:       []string{"a"},
+		FileHistory: []string{"H"},
+		Reflog:      []string{"L"},
+		Undo:        []string{"z"},
+		Redo:        []string{"Z"},
 	}
 }
 
@@ -62,6 +62,12 @@ func (l *Layout) Render(mainPanel, actionBar string) string {
 // The entire output is placed into a full-screen area with a dark background
 // using lipgloss.Place + WithWhitespaceBackground to fill every cell.
 func (l *Layout) RenderWithExtra(mainPanel, extraPanel, actionBar string) string {
+	return l.RenderTitled("Commits", mainPanel, extraPanel, actionBar)
+}
+
+// RenderTitled is like RenderWithExtra but titles the main panel, for views
+// that take its place such as a blame.
+func (l *Layout) RenderTitled(title, mainPanel, extraPanel, actionBar string) string {
 	extraHeight := 0
 	if extraPanel != "" {
 		extraHeight = lipgloss.Height(extraPanel)
@@ -80,7 +86,7 @@ func (l *Layout) RenderWithExtra(mainPanel, extraPanel, actionBar string) string
 		Width(contentW).
 		Height(contentH).
 		Render(mainPanel)
-	mainBox = l.renderWithTitle(mainBox, titleStyle.Render(" Commits "))
+	mainBox = l.renderWithTitle(mainBox, titleStyle.Render(" "+title+" "))
 
 	var combined string
 	if extraPanel != "" {